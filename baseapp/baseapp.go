@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"io"
 	"runtime/debug"
+	"strconv"
 	"strings"
 
+	"github.com/golang/protobuf/proto"
 	"github.com/pkg/errors"
 
 	abci "github.com/tendermint/tendermint/abci/types"
@@ -26,6 +28,9 @@ import (
 // and to avoid affecting the Merkle root.
 var dbHeaderKey = []byte("header")
 
+// Key to store the consensus params in the main store.
+var mainConsensusParamsKey = []byte("consensus_params")
+
 // Enum mode for app.runTx
 type runTxMode uint8
 
@@ -49,6 +54,7 @@ type BaseApp struct {
 	queryRouter QueryRouter          // router for redirecting query calls
 	codespacer  *sdk.Codespacer      // handle module codespacing
 	txDecoder   sdk.TxDecoder        // unmarshal []byte into sdk.Tx
+	baseKey     sdk.StoreKey         // main store key, set on loading the store
 
 	anteHandler sdk.AnteHandler // ante handler for fee and auth
 
@@ -58,6 +64,7 @@ type BaseApp struct {
 	endBlocker       sdk.EndBlocker   // logic to run after all txs, and to determine valset changes
 	addrPeerFilter   sdk.PeerFilter   // filter peers by address and port
 	pubkeyPeerFilter sdk.PeerFilter   // filter peers by public key
+	mempoolPolicy    MempoolPolicy    // admit checked txs into the mempool of the node
 
 	//--------------------
 	// Volatile
@@ -68,9 +75,18 @@ type BaseApp struct {
 	deliverState     *state                  // for DeliverTx
 	signedValidators []abci.SigningValidator // absent validators from begin block
 
+	// consensus params set in InitChain and stored in the main store,
+	// may be nil
+	consensusParams *abci.ConsensusParams
+
 	// minimum fees for spam prevention
 	minimumFees sdk.Coins
 
+	// snapshots of the committed state, taken every snapshotInterval blocks
+	// if the interval is positive
+	snapshotStore    store.SnapshotStore
+	snapshotInterval int64
+
 	// flag for sealing
 	sealed bool
 }
@@ -147,6 +163,12 @@ func (app *BaseApp) MountStore(key sdk.StoreKey, typ sdk.StoreType) {
 	app.cms.MountStoreWithDB(key, typ, nil)
 }
 
+// Cache up to size keys of a mounted IAVL store across blocks, to be called
+// before the stores are loaded
+func (app *BaseApp) SetInterBlockCache(key sdk.StoreKey, size int) {
+	app.cms.SetInterBlockCache(key, size)
+}
+
 // load latest application version
 func (app *BaseApp) LoadLatestVersion(mainKey sdk.StoreKey) error {
 	err := app.cms.LoadLatestVersion()
@@ -183,6 +205,19 @@ func (app *BaseApp) initFromStore(mainKey sdk.StoreKey) error {
 	if main == nil {
 		return errors.New("baseapp expects MultiStore with 'main' KVStore")
 	}
+	app.baseKey = mainKey
+
+	// Load the consensus params set in InitChain, if any
+	consensusParamsBz := main.Get(mainConsensusParamsKey)
+	if consensusParamsBz != nil {
+		var consensusParams = &abci.ConsensusParams{}
+		err := proto.Unmarshal(consensusParamsBz, consensusParams)
+		if err != nil {
+			return errors.Wrap(err, "failed to decode consensus params")
+		}
+		app.setConsensusParams(consensusParams)
+	}
+
 	// Needed for `gaiad export`, which inits from store but never calls initchain
 	app.setCheckState(abci.Header{})
 
@@ -202,6 +237,31 @@ func (app *BaseApp) NewContext(isCheckTx bool, header abci.Header) sdk.Context {
 	return sdk.NewContext(app.deliverState.ms, header, false, app.Logger)
 }
 
+// setConsensusParams sets the consensus params of the chain.
+func (app *BaseApp) setConsensusParams(consensusParams *abci.ConsensusParams) {
+	app.consensusParams = consensusParams
+}
+
+// storeConsensusParams persists the consensus params in the main store, to be
+// loaded on restart.
+func (app *BaseApp) storeConsensusParams(consensusParams *abci.ConsensusParams) {
+	consensusParamsBz, err := proto.Marshal(consensusParams)
+	if err != nil {
+		panic(err)
+	}
+	mainStore := app.cms.GetKVStore(app.baseKey)
+	mainStore.Set(mainConsensusParamsKey, consensusParamsBz)
+}
+
+// getMaximumBlockGas returns the maximum gas of a block from the consensus
+// params, a non-positive value means that block gas is unlimited.
+func (app *BaseApp) getMaximumBlockGas() (maxGas int64) {
+	if app.consensusParams == nil || app.consensusParams.BlockSize == nil {
+		return 0
+	}
+	return app.consensusParams.BlockSize.MaxGas
+}
+
 type state struct {
 	ms  sdk.CacheMultiStore
 	ctx sdk.Context
@@ -255,6 +315,11 @@ func (app *BaseApp) InitChain(req abci.RequestInitChain) (res abci.ResponseInitC
 	app.setDeliverState(abci.Header{ChainID: req.ChainId})
 	app.setCheckState(abci.Header{ChainID: req.ChainId})
 
+	if req.ConsensusParams != nil {
+		app.setConsensusParams(req.ConsensusParams)
+		app.storeConsensusParams(req.ConsensusParams)
+	}
+
 	if app.initChainer == nil {
 		return
 	}
@@ -384,16 +449,28 @@ func handleQueryP2P(app *BaseApp, path []string, req abci.RequestQuery) (res abc
 func handleQueryCustom(app *BaseApp, path []string, req abci.RequestQuery) (res abci.ResponseQuery) {
 	// path[0] should be "custom" because "/custom" prefix is required for keeper queries.
 	// the queryRouter routes using path[1]. For example, in the path "custom/gov/proposal", queryRouter routes using "gov"
-	if path[1] == "" {
-		sdk.ErrUnknownRequest("No route for custom query specified").QueryResult()
+	if len(path) < 2 || path[1] == "" {
+		return sdk.ErrUnknownRequest("No route for custom query specified").QueryResult()
 	}
 	querier := app.queryRouter.Route(path[1])
 	if querier == nil {
-		sdk.ErrUnknownRequest(fmt.Sprintf("no custom querier found for route %s", path[1])).QueryResult()
+		return sdk.ErrUnknownRequest(fmt.Sprintf("no custom querier found for route %s", path[1])).QueryResult()
 	}
 
-	ctx := sdk.NewContext(app.cms.CacheMultiStore(), app.checkState.ctx.BlockHeader(), true, app.Logger).
-		WithMinimumFees(app.minimumFees)
+	// the querier runs against the state at the requested height, or the
+	// latest committed state if none is requested
+	var ctx sdk.Context
+	if req.Height > 0 {
+		cacheMS, err := app.cms.CacheMultiStoreWithVersion(req.Height)
+		if err != nil {
+			return sdk.ErrUnknownRequest(fmt.Sprintf("cannot query height %d: %v", req.Height, err)).QueryResult()
+		}
+		ctx = sdk.NewContext(cacheMS, app.checkState.ctx.BlockHeader(), true, app.Logger).
+			WithBlockHeight(req.Height)
+	} else {
+		ctx = sdk.NewContext(app.cms.CacheMultiStore(), app.checkState.ctx.BlockHeader(), true, app.Logger)
+	}
+	ctx = ctx.WithMinimumFees(app.minimumFees)
 	// Passes the rest of the path as an argument to the querier.
 	// For example, in the path "custom/gov/proposal/test", the gov querier gets []string{"proposal", "test"} as the path
 	resBytes, err := querier(ctx, path[2:], req)
@@ -429,6 +506,15 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 		app.deliverState.ctx = app.deliverState.ctx.WithBlockHeader(req.Header).WithBlockHeight(req.Header.Height)
 	}
 
+	// add the block gas meter, limited by the consensus params
+	var gasMeter sdk.GasMeter
+	if maxGas := app.getMaximumBlockGas(); maxGas > 0 {
+		gasMeter = sdk.NewGasMeter(maxGas)
+	} else {
+		gasMeter = sdk.NewInfiniteGasMeter()
+	}
+	app.deliverState.ctx = app.deliverState.ctx.WithBlockGasMeter(gasMeter)
+
 	if app.beginBlocker != nil {
 		res = app.beginBlocker(app.deliverState.ctx, req)
 	}
@@ -454,13 +540,21 @@ func (app *BaseApp) CheckTx(txBytes []byte) (res abci.ResponseCheckTx) {
 		result = app.runTx(runTxModeCheck, txBytes, tx)
 	}
 
+	// Tendermint has no priority in the response, it is returned as a tag
+	tags := result.Tags
+	if result.IsOK() {
+		tags = tags.AppendTag(sdk.TagPriority, []byte(strconv.FormatInt(result.Priority, 10)))
+	} else if app.mempoolPolicy != nil {
+		app.mempoolPolicy.RemoveTx(tmhash.Sum(txBytes))
+	}
+
 	return abci.ResponseCheckTx{
 		Code:      uint32(result.Code),
 		Data:      result.Data,
 		Log:       result.Log,
 		GasWanted: result.GasWanted,
 		GasUsed:   result.GasUsed,
-		Tags:      result.Tags,
+		Tags:      tags,
 	}
 }
 
@@ -475,6 +569,11 @@ func (app *BaseApp) DeliverTx(txBytes []byte) (res abci.ResponseDeliverTx) {
 		result = app.runTx(runTxModeDeliver, txBytes, tx)
 	}
 
+	// The tx left the mempool of the node, if it was there
+	if app.mempoolPolicy != nil {
+		app.mempoolPolicy.RemoveTx(tmhash.Sum(txBytes))
+	}
+
 	// Even though the Result.Code is not OK, there are still effects,
 	// namely fee deductions and sequence incrementing.
 
@@ -509,10 +608,11 @@ func validateBasicTxMsgs(msgs []sdk.Msg) sdk.Error {
 }
 
 // retrieve the context for the ante handler and store the tx bytes; store
-// the signing validators if the tx runs within the deliverTx() state.
+// the signing validators if the tx runs within the deliverTx() state. Each tx
+// starts with its own gas meter, which is usually replaced by the ante handler.
 func (app *BaseApp) getContextForAnte(mode runTxMode, txBytes []byte) (ctx sdk.Context) {
 	// Get the context
-	ctx = getState(app, mode).ctx.WithTxBytes(txBytes)
+	ctx = getState(app, mode).ctx.WithTxBytes(txBytes).WithGasMeter(sdk.NewInfiniteGasMeter())
 	if mode == runTxModeDeliver {
 		ctx = ctx.WithSigningValidators(app.signedValidators)
 	}
@@ -522,8 +622,8 @@ func (app *BaseApp) getContextForAnte(mode runTxMode, txBytes []byte) (ctx sdk.C
 // Iterates through msgs and executes them
 func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (result sdk.Result) {
 	// accumulate results
-	logs := make([]string, 0, len(msgs))
-	var data []byte   // NOTE: we just append them all (?!)
+	msgLogs := make([]sdk.MsgLog, 0, len(msgs))
+	msgResults := make([]sdk.MsgResult, 0, len(msgs))
 	var tags sdk.Tags // also just append them all
 	var code sdk.ABCICodeType
 	for msgIdx, msg := range msgs {
@@ -544,26 +644,35 @@ func (app *BaseApp) runMsgs(ctx sdk.Context, msgs []sdk.Msg, mode runTxMode) (re
 		// NOTE: GasWanted is determined by ante handler and
 		// GasUsed by the GasMeter
 
-		// Append Data and Tags
-		data = append(data, msgResult.Data...)
+		// Record the result of the message, and append its Tags
+		msgResults = append(msgResults, sdk.MsgResult{
+			MsgIndex: msgIdx,
+			Route:    msgType,
+			Data:     msgResult.Data,
+			Tags:     msgResult.Tags,
+		})
 		tags = append(tags, msgResult.Tags...)
 
+		// Construct usable logs in multi-message transactions, kept out of
+		// the Data as they are nondeterministic.
+		msgLogs = append(msgLogs, sdk.MsgLog{
+			MsgIndex: msgIdx,
+			Success:  msgResult.IsOK(),
+			Log:      msgResult.Log,
+		})
+
 		// Stop execution and return on first failed message.
 		if !msgResult.IsOK() {
-			logs = append(logs, fmt.Sprintf("Msg %d failed: %s", msgIdx, msgResult.Log))
 			code = msgResult.Code
 			break
 		}
-
-		// Construct usable logs in multi-message transactions.
-		logs = append(logs, fmt.Sprintf("Msg %d: %s", msgIdx, msgResult.Log))
 	}
 
 	// Set the final gas values.
 	result = sdk.Result{
 		Code:    code,
-		Data:    data,
-		Log:     strings.Join(logs, "\n"),
+		Data:    sdk.EncodeMsgResults(msgResults),
+		Log:     sdk.EncodeMsgLogs(msgLogs),
 		GasUsed: ctx.GasMeter().GasConsumed(),
 		// TODO: FeeAmount/FeeDenom
 		Tags: tags,
@@ -596,8 +705,9 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 	// NOTE: GasWanted should be returned by the AnteHandler. GasUsed is
 	// determined by the GasMeter. We need access to the context to get the gas
 	// meter so we initialize upfront.
-	var gasWanted int64
+	var gasWanted, priority int64
 	var msCache sdk.CacheMultiStore
+	var blockGasConsumed bool
 	ctx := app.getContextForAnte(mode, txBytes)
 	ctx = app.initializeContext(ctx, mode)
 
@@ -615,8 +725,23 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 
 		result.GasWanted = gasWanted
 		result.GasUsed = ctx.GasMeter().GasConsumed()
+		result.GasBreakdown = ctx.GasMeter().GasBreakdown()
 	}()
 
+	// The gas used by txs which did not reach the end of runTx, e.g. because
+	// the ante handler aborted or ran out of gas, is consumed from the block
+	// gas meter as well. This runs before the recovery above.
+	defer func() {
+		if mode == runTxModeDeliver && !blockGasConsumed {
+			app.consumeBlockGas(ctx.GasMeter().GasConsumed())
+		}
+	}()
+
+	// Refuse any tx once the block is out of gas
+	if mode == runTxModeDeliver && app.deliverState.ctx.BlockGasMeter().IsOutOfGas() {
+		return sdk.ErrOutOfGas("no block gas left to run tx").Result()
+	}
+
 	var msgs = tx.GetMsgs()
 	if err := validateBasicTxMsgs(msgs); err != nil {
 		return err.Result()
@@ -624,35 +749,69 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 
 	// run the ante handler
 	if app.anteHandler != nil {
-		newCtx, result, abort := app.anteHandler(ctx, tx, (mode == runTxModeSimulate))
-		if abort {
-			return result
+		// Cache wrap the state changes of the ante handler, they are only
+		// written if the ante handler does not abort and the tx is accepted.
+		// Simulations already run on a cache of the check state.
+		anteCtx := ctx
+		var anteMsCache sdk.CacheMultiStore
+		if mode != runTxModeSimulate {
+			anteMsCache = app.cacheTxMultiStore(mode, txBytes)
+			anteCtx = ctx.WithMultiStore(anteMsCache)
 		}
+
+		newCtx, result, abort := app.anteHandler(anteCtx, tx, (mode == runTxModeSimulate))
 		if !newCtx.IsZero() {
 			ctx = newCtx
 		}
+		if abort {
+			return result
+		}
 
 		gasWanted = result.GasWanted
+		priority = result.Priority
+
+		// Refuse txs which could never fit in a block, then apply the
+		// admission policy of the mempool
+		if mode == runTxModeCheck {
+			if maxGas := app.getMaximumBlockGas(); maxGas > 0 && gasWanted > maxGas {
+				return sdk.ErrOutOfGas(fmt.Sprintf(
+					"tx gas wanted %d exceeds the maximum block gas %d", gasWanted, maxGas)).Result()
+			}
+			if app.mempoolPolicy != nil {
+				if err := app.mempoolPolicy.AdmitTx(tmhash.Sum(txBytes), priority); err != nil {
+					return err.Result()
+				}
+			}
+		}
+
+		if anteMsCache != nil {
+			anteMsCache.Write()
+		}
 	}
 
 	if mode == runTxModeSimulate {
 		result = app.runMsgs(ctx, msgs, mode)
 		result.GasWanted = gasWanted
+		result.Priority = priority
 		return
 	}
 
 	// Keep the state in a transient CacheWrap in case processing the messages
 	// fails.
-	msCache = getState(app, mode).CacheMultiStore()
-	if msCache.TracingEnabled() {
-		msCache = msCache.WithTracingContext(sdk.TraceContext(
-			map[string]interface{}{"txHash": cmn.HexBytes(tmhash.Sum(txBytes)).String()},
-		)).(sdk.CacheMultiStore)
-	}
-
+	msCache = app.cacheTxMultiStore(mode, txBytes)
 	ctx = ctx.WithMultiStore(msCache)
 	result = app.runMsgs(ctx, msgs, mode)
 	result.GasWanted = gasWanted
+	result.Priority = priority
+
+	// Consume the gas used by the tx from the block gas meter, the state
+	// changes of the messages are discarded if the block runs out of gas
+	if mode == runTxModeDeliver {
+		blockGasConsumed = true
+		if !app.consumeBlockGas(ctx.GasMeter().GasConsumed()) {
+			return sdk.ErrOutOfGas("block gas limit exceeded").Result()
+		}
+	}
 
 	// only update state if all messages pass
 	if result.IsOK() {
@@ -662,6 +821,34 @@ func (app *BaseApp) runTx(mode runTxMode, txBytes []byte, tx sdk.Tx) (result sdk
 	return
 }
 
+// cacheTxMultiStore cache-wraps the state of the mode for a tx, whose writes
+// are traced and listened to with its hash.
+func (app *BaseApp) cacheTxMultiStore(mode runTxMode, txBytes []byte) sdk.CacheMultiStore {
+	msCache := getState(app, mode).CacheMultiStore()
+	if msCache.TracingEnabled() || msCache.ListeningEnabled() {
+		msCache = msCache.WithTracingContext(sdk.TraceContext(
+			map[string]interface{}{"txHash": cmn.HexBytes(tmhash.Sum(txBytes)).String()},
+		)).(sdk.CacheMultiStore)
+	}
+	return msCache
+}
+
+// consumeBlockGas consumes the gas used by a tx from the block gas meter. It
+// returns false if the block gas limit is exceeded, the gas is consumed
+// nonetheless so that no further txs are run in the block.
+func (app *BaseApp) consumeBlockGas(gas sdk.Gas) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, isOutOfGas := r.(sdk.ErrorOutOfGas); !isOutOfGas {
+				panic(r)
+			}
+			ok = false
+		}
+	}()
+	app.deliverState.ctx.BlockGasMeter().ConsumeGas(gas, "block gas meter")
+	return true
+}
+
 // EndBlock implements the ABCI application interface.
 func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBlock) {
 	if app.deliverState.ms.TracingEnabled() {
@@ -694,11 +881,28 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	app.Logger.Debug("Commit synced",
 		"commit", commitID,
 	)
+	for name, metrics := range app.cms.InterBlockCacheMetrics() {
+		app.Logger.Debug("Inter-block cache", "store", name,
+			"hits", metrics.Hits, "misses", metrics.Misses, "size", metrics.Size)
+	}
+
+	// Snapshot the committed state, before the next block is delivered
+	if app.snapshotInterval > 0 && commitID.Version%app.snapshotInterval == 0 {
+		snapshot, err := app.CreateSnapshot(commitID.Version)
+		if err != nil {
+			app.Logger.Error("Snapshot failed", "height", commitID.Version, "err", err)
+		} else {
+			app.Logger.Info("Snapshot created", "height", snapshot.Height, "chunks", len(snapshot.ChunkHashes))
+		}
+	}
 
 	// Reset the Check state to the latest committed
 	// NOTE: safe because Tendermint holds a lock on the mempool for Commit.
 	// Use the header from this latest block.
 	app.setCheckState(header)
+	if app.mempoolPolicy != nil {
+		app.mempoolPolicy.Commit()
+	}
 
 	// Empty the Deliver state
 	app.deliverState = nil
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/binary"
 	"fmt"
+	"io/ioutil"
 	"os"
 	"testing"
 
@@ -11,10 +12,13 @@ import (
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	cmn "github.com/tendermint/tendermint/libs/common"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -132,6 +136,49 @@ func TestLoadVersion(t *testing.T) {
 	testLoadVersionHelper(t, app, int64(2), commitID2)
 }
 
+// Test that snapshots are taken at the snapshot interval, and that they can be
+// restored into the empty state of another app.
+func TestSnapshots(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	snapshotStore := store.NewLocalSnapshotStore(dir)
+
+	logger := defaultLogger()
+	capKey := sdk.NewKVStoreKey("main")
+	app := NewBaseApp(t.Name(), logger, dbm.NewMemDB(), nil,
+		SetSnapshotStore(snapshotStore), SetSnapshotInterval(2))
+	app.MountStoresIAVL(capKey)
+	require.Nil(t, app.LoadLatestVersion(capKey))
+
+	var commitIDs []sdk.CommitID
+	for height := int64(1); height <= 5; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		app.deliverState.ctx.KVStore(capKey).Set([]byte("height"), []byte(fmt.Sprint(height)))
+		app.Commit()
+		commitIDs = append(commitIDs, app.LastCommitID())
+	}
+
+	snapshots, err := app.ListSnapshots()
+	require.Nil(t, err)
+	require.Len(t, snapshots, 2)
+	require.Equal(t, int64(4), snapshots[0].Height)
+	require.Equal(t, int64(2), snapshots[1].Height)
+
+	newApp := NewBaseApp(t.Name(), logger, dbm.NewMemDB(), nil, SetSnapshotStore(snapshotStore))
+	newApp.MountStoresIAVL(capKey)
+	require.Nil(t, newApp.LoadLatestVersion(capKey))
+	_, err = newApp.RestoreSnapshot(4)
+	require.Nil(t, err)
+	testLoadVersionHelper(t, newApp, int64(4), commitIDs[3])
+	ctx := newApp.checkState.ctx
+	require.Equal(t, []byte("4"), ctx.KVStore(capKey).Get([]byte("height")))
+
+	// the state is not empty anymore
+	_, err = newApp.RestoreSnapshot(2)
+	require.NotNil(t, err)
+}
+
 func testLoadVersionHelper(t *testing.T, app *BaseApp, expectedHeight int64, expectedID sdk.CommitID) {
 	lastHeight := app.LastBlockHeight()
 	lastID := app.LastCommitID()
@@ -244,7 +291,9 @@ func TestInitChainer(t *testing.T) {
 	err := app.LoadLatestVersion(capKey) // needed to make stores non-nil
 	require.Nil(t, err)
 
-	app.InitChain(abci.RequestInitChain{AppStateBytes: []byte("{}"), ChainId: "test-chain-id"}) // must have valid JSON genesis file, even if empty
+	consensusParams := &abci.ConsensusParams{BlockSize: &abci.BlockSize{MaxGas: 100}}
+	app.InitChain(abci.RequestInitChain{AppStateBytes: []byte("{}"), ChainId: "test-chain-id", ConsensusParams: consensusParams}) // must have valid JSON genesis file, even if empty
+	require.Equal(t, int64(100), app.getMaximumBlockGas())
 
 	// assert that chainID is set correctly in InitChain
 	chainID := app.deliverState.ctx.ChainID()
@@ -268,6 +317,9 @@ func TestInitChainer(t *testing.T) {
 	res = app.Query(query)
 	require.Equal(t, value, res.Value)
 
+	// ensure the consensus params were loaded
+	require.Equal(t, int64(100), app.getMaximumBlockGas())
+
 	// commit and ensure we can still query
 	app.BeginBlock(abci.RequestBeginBlock{})
 	app.Commit()
@@ -511,6 +563,73 @@ func TestDeliverTx(t *testing.T) {
 	}
 }
 
+type testStateListener struct {
+	changeSets []sdk.ChangeSet
+}
+
+func (l *testStateListener) OnCommit(changeSet sdk.ChangeSet) error {
+	l.changeSets = append(l.changeSets, changeSet)
+	return nil
+}
+
+// Test that the state listeners are notified of the changes of each block.
+func TestStateListeners(t *testing.T) {
+	beginKey, anteKey, deliverKey := []byte("begin-key"), []byte("ante-key"), []byte("deliver-key")
+	beginBlockerOpt := func(bapp *BaseApp) {
+		bapp.SetBeginBlocker(func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+			ctx.KVStore(capKey2).Set(beginKey, []byte("begin"))
+			return abci.ResponseBeginBlock{}
+		})
+	}
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+			ctx.KVStore(capKey1).Set(anteKey, []byte("ante"))
+			return
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(typeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			// the msgs of counter 2 fail after writing
+			if msg.(msgCounter).Counter == 2 {
+				ctx.KVStore(capKey1).Set(deliverKey, []byte("failed"))
+				return sdk.ErrInternal("failing counter").Result()
+			}
+			ctx.KVStore(capKey1).Set(deliverKey, []byte("deliver"))
+			return sdk.Result{}
+		})
+	}
+	listener := &testStateListener{}
+	app := setupBaseApp(t, beginBlockerOpt, anteOpt, routerOpt, SetStateListeners(listener))
+
+	codec := codec.New()
+	registerTestCodec(codec)
+	txBytes, err := codec.MarshalBinary(newTxCounter(0, 1))
+	require.NoError(t, err)
+	failedTxBytes, err := codec.MarshalBinary(newTxCounter(1, 2))
+	require.NoError(t, err)
+
+	app.InitChain(abci.RequestInitChain{})
+	app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: 1}})
+	require.True(t, app.DeliverTx(txBytes).IsOK())
+	require.False(t, app.DeliverTx(failedTxBytes).IsOK())
+	require.True(t, app.CheckTx(txBytes).IsOK())
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+
+	// the changes of the check txs and of the failed msgs are not committed
+	txHash := cmn.HexBytes(tmhash.Sum(txBytes)).String()
+	failedTxHash := cmn.HexBytes(tmhash.Sum(failedTxBytes)).String()
+	require.Equal(t, []sdk.ChangeSet{{
+		Height: 1,
+		Changes: []sdk.StoreChange{
+			{StoreName: capKey2.Name(), Key: beginKey, Value: []byte("begin")},
+			{StoreName: capKey1.Name(), TxHash: txHash, Key: anteKey, Value: []byte("ante")},
+			{StoreName: capKey1.Name(), TxHash: txHash, Key: deliverKey, Value: []byte("deliver")},
+			{StoreName: capKey1.Name(), TxHash: failedTxHash, Key: anteKey, Value: []byte("ante")},
+		},
+	}}, listener.changeSets)
+}
+
 // Number of messages doesn't matter to CheckTx.
 func TestMultiMsgCheckTx(t *testing.T) {
 	// TODO: ensure we get the same results
@@ -580,6 +699,22 @@ func TestMultiMsgDeliverTx(t *testing.T) {
 		require.Equal(t, int64(4), msgCounter)
 		msgCounter2 := getIntFromStore(store, deliverKey2)
 		require.Equal(t, int64(2), msgCounter2)
+
+		// the results of the messages are encoded into the data of the tx
+		msgResults, err := sdk.DecodeMsgResults(res.Data)
+		require.NoError(t, err)
+		require.Equal(t, []sdk.MsgResult{
+			{MsgIndex: 0, Route: typeMsgCounter, Tags: sdk.NewTags("action", []byte("counter1"))},
+			{MsgIndex: 1, Route: typeMsgCounter2, Tags: sdk.NewTags("action", []byte("counter2"))},
+			{MsgIndex: 2, Route: typeMsgCounter2, Tags: sdk.NewTags("action", []byte("counter2"))},
+		}, msgResults)
+		msgLogs, err := sdk.DecodeMsgLogs(res.Log)
+		require.NoError(t, err)
+		require.Equal(t, []sdk.MsgLog{
+			{MsgIndex: 0, Success: true},
+			{MsgIndex: 1, Success: true},
+			{MsgIndex: 2, Success: true},
+		}, msgLogs)
 	}
 }
 
@@ -822,6 +957,193 @@ func TestTxGasLimits(t *testing.T) {
 	}
 }
 
+// Test that transactions exceeding the block gas limit fail
+func TestMaxBlockGasLimits(t *testing.T) {
+	gasGranted := int64(10)
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+			newCtx = ctx.WithGasMeter(sdk.NewGasMeter(gasGranted))
+
+			defer func() {
+				if r := recover(); r != nil {
+					switch rType := r.(type) {
+					case sdk.ErrorOutOfGas:
+						log := fmt.Sprintf("out of gas in location: %v", rType.Descriptor)
+						res = sdk.ErrOutOfGas(log).Result()
+						res.GasWanted = gasGranted
+						res.GasUsed = newCtx.GasMeter().GasConsumed()
+					default:
+						panic(r)
+					}
+				}
+			}()
+
+			count := tx.(*txTest).Counter
+			newCtx.GasMeter().ConsumeGas(count, "counter-ante")
+			res = sdk.Result{
+				GasWanted: gasGranted,
+			}
+			return
+		})
+
+	}
+
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(typeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+			count := msg.(msgCounter).Counter
+			ctx.GasMeter().ConsumeGas(count, "counter-handler")
+			return sdk.Result{}
+		})
+	}
+
+	app := setupBaseApp(t, anteOpt, routerOpt)
+	app.InitChain(abci.RequestInitChain{
+		ConsensusParams: &abci.ConsensusParams{
+			BlockSize: &abci.BlockSize{
+				MaxGas: 100,
+			},
+		},
+	})
+
+	testCases := []struct {
+		tx                *txTest
+		numDelivers       int
+		gasUsedPerDeliver int64
+		fail              bool
+		failAfterDeliver  int
+	}{
+		{newTxCounter(0, 0), 0, 0, false, 0},
+		{newTxCounter(9, 1), 2, 10, false, 0},
+		{newTxCounter(10, 0), 3, 10, false, 0},
+		{newTxCounter(10, 0), 10, 10, false, 0},
+		{newTxCounter(2, 7), 11, 9, false, 0},
+		{newTxCounter(10, 0), 10, 10, false, 0}, // hit the limit but pass
+
+		{newTxCounter(10, 0), 11, 10, true, 10},
+		{newTxCounter(10, 0), 15, 10, true, 10},
+		{newTxCounter(9, 0), 12, 9, true, 11}, // fly past the limit
+	}
+
+	for i, tc := range testCases {
+		tx := tc.tx
+
+		// reset the block gas
+		app.BeginBlock(abci.RequestBeginBlock{})
+
+		// execute the transaction multiple times
+		for j := 0; j < tc.numDelivers; j++ {
+			res := app.Deliver(tx)
+			blockGasMeter := app.deliverState.ctx.BlockGasMeter()
+
+			// check for failed transactions
+			if tc.fail && (j+1) > tc.failAfterDeliver {
+				require.Equal(t, res.Code, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfGas), fmt.Sprintf("%d: %v, %v", i, tc, res))
+				require.True(t, blockGasMeter.IsOutOfGas())
+			} else {
+				// check gas used and wanted
+				expBlockGasUsed := tc.gasUsedPerDeliver * int64(j+1)
+				require.Equal(t, expBlockGasUsed, blockGasMeter.GasConsumed(), fmt.Sprintf("%d,%d: %v, %v", i, j, tc, res))
+				require.True(t, res.IsOK(), fmt.Sprintf("%d,%d: %v, %v", i, j, tc, res))
+			}
+		}
+	}
+}
+
+// Test that CheckTx refuses transactions wanting more gas than the block gas
+// limit, without writing the state changes of the ante handler
+func TestCheckTxMaxBlockGas(t *testing.T) {
+	anteKey := []byte("ante-key")
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+			ctx.KVStore(capKey1).Set(anteKey, []byte("ante"))
+			res = sdk.Result{
+				GasWanted: tx.(*txTest).Counter,
+			}
+			return
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(typeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} })
+	}
+
+	app := setupBaseApp(t, anteOpt, routerOpt)
+	app.InitChain(abci.RequestInitChain{
+		ConsensusParams: &abci.ConsensusParams{
+			BlockSize: &abci.BlockSize{
+				MaxGas: 100,
+			},
+		},
+	})
+
+	res := app.Check(newTxCounter(101, 0))
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeOutOfGas), res.Code, res.Log)
+	require.Nil(t, app.checkState.ctx.KVStore(capKey1).Get(anteKey))
+
+	res = app.Check(newTxCounter(100, 0))
+	require.True(t, res.IsOK(), res.Log)
+	require.NotNil(t, app.checkState.ctx.KVStore(capKey1).Get(anteKey))
+}
+
+// Test that CheckTx returns the priority of the tx and applies the admission
+// policy of the mempool, which tracks the txs until they are delivered.
+func TestCheckTxMempoolPolicy(t *testing.T) {
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (newCtx sdk.Context, res sdk.Result, abort bool) {
+			res.Priority = tx.(txTest).Counter
+			return
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(typeMsgCounter, func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} })
+	}
+	app := setupBaseApp(t, anteOpt, routerOpt, SetMempoolMaxTxs(2))
+	app.InitChain(abci.RequestInitChain{})
+
+	codec := codec.New()
+	registerTestCodec(codec)
+	txBytes := func(priority int64) []byte {
+		bz, err := codec.MarshalBinary(newTxCounter(priority, 0))
+		require.NoError(t, err)
+		return bz
+	}
+
+	res := app.CheckTx(txBytes(5))
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, sdk.MakeTag(sdk.TagPriority, []byte("5")), res.Tags[len(res.Tags)-1])
+	require.True(t, app.CheckTx(txBytes(3)).IsOK())
+
+	// once full, the mempool only admits the txs above the lowest priority,
+	// while its txs pass their recheck
+	floor, full := app.mempoolPolicy.(*PriorityFloorPolicy).Floor()
+	require.True(t, full)
+	require.Equal(t, int64(3), floor)
+	res = app.CheckTx(txBytes(2))
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeInsufficientFee), res.Code, res.Log)
+	require.True(t, app.CheckTx(txBytes(3)).IsOK())
+	require.True(t, app.CheckTx(txBytes(4)).IsOK())
+	res = app.CheckTx(txBytes(4))
+	require.True(t, res.IsOK(), res.Log)
+
+	// the delivered txs leave the mempool
+	app.BeginBlock(abci.RequestBeginBlock{})
+	app.DeliverTx(txBytes(3))
+	app.DeliverTx(txBytes(4))
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+	_, full = app.mempoolPolicy.(*PriorityFloorPolicy).Floor()
+	require.False(t, full)
+	require.True(t, app.CheckTx(txBytes(1)).IsOK())
+
+	// the txs dropped by Tendermint, which are not rechecked, are no longer
+	// tracked after the next commit
+	app.BeginBlock(abci.RequestBeginBlock{})
+	app.EndBlock(abci.RequestEndBlock{})
+	app.Commit()
+	policy := app.mempoolPolicy.(*PriorityFloorPolicy)
+	require.Len(t, policy.txs, 1)
+	require.Equal(t, int64(1), policy.priorities[0].priority)
+}
+
 //-------------------------------------------------------------------------------------------
 // Queries
 
@@ -880,6 +1202,51 @@ func TestQuery(t *testing.T) {
 	require.Equal(t, value, res.Value)
 }
 
+// Test that custom queriers can query the state at the requested height.
+func TestQueryCustomHeight(t *testing.T) {
+	key := []byte("height")
+	beginBlockerOpt := func(bapp *BaseApp) {
+		bapp.SetBeginBlocker(func(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+			ctx.KVStore(capKey1).Set(key, []byte(fmt.Sprintf("%d", req.Header.Height)))
+			return abci.ResponseBeginBlock{}
+		})
+	}
+	querierOpt := func(bapp *BaseApp) {
+		bapp.QueryRouter().AddRoute("height", func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, sdk.Error) {
+			value := ctx.KVStore(capKey1).Get(key)
+			return []byte(fmt.Sprintf("%s/%d", value, ctx.BlockHeight())), nil
+		})
+	}
+	pruningOpt := SetPruning(sdk.NewPruningStrategy(1, 0, 1))
+
+	app := setupBaseApp(t, beginBlockerOpt, querierOpt, pruningOpt)
+	app.InitChain(abci.RequestInitChain{})
+	for height := int64(1); height <= 4; height++ {
+		app.BeginBlock(abci.RequestBeginBlock{Header: abci.Header{Height: height}})
+		app.Commit()
+	}
+
+	query := func(height int64) abci.ResponseQuery {
+		return app.Query(abci.RequestQuery{Path: "/custom/height", Height: height})
+	}
+
+	// the latest state is queried if no height is requested
+	res := query(0)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, "4/4", string(res.Value))
+
+	res = query(3)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, "3/3", string(res.Value))
+
+	// the heights which are pruned or not committed cannot be queried
+	for _, height := range []int64{1, 2, 5} {
+		res = query(height)
+		require.False(t, res.IsOK(), "height %d", height)
+		require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnknownRequest), sdk.ABCICodeType(res.Code))
+	}
+}
+
 // Test p2p filter queries
 func TestP2PQuery(t *testing.T) {
 	addrPeerFilterOpt := func(bapp *BaseApp) {
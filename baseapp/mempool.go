@@ -0,0 +1,137 @@
+package baseapp
+
+import (
+	"container/heap"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MempoolPolicy is a node-local admission policy of the mempool. CheckTx
+// consults it once the AnteHandler accepted a tx, DeliverTx never does, so
+// that it may depend on the state of the node.
+type MempoolPolicy interface {
+	// AdmitTx returns an error to reject the tx of the given hash and priority
+	// from the mempool. It is called again when a tx of the mempool is
+	// rechecked after a commit.
+	AdmitTx(txHash []byte, priority int64) sdk.Error
+
+	// RemoveTx is called once the tx of the given hash left the mempool, as it
+	// got into a block or failed its check.
+	RemoveTx(txHash []byte)
+
+	// Commit is called once a block is committed, before Tendermint rechecks
+	// the txs remaining in the mempool.
+	Commit()
+}
+
+// PriorityFloorPolicy is a MempoolPolicy which tracks the priorities of the
+// txs it admitted into the mempool. While it tracks maxTxs txs or more, it
+// rejects the new txs whose priority does not exceed a floor, the lowest
+// priority in the mempool, so that the txs paying more still get in during
+// congestion. maxTxs should be lower than the size of the Tendermint mempool,
+// which rejects any tx once full.
+//
+// As Tendermint may drop a tx the policy admitted without notice, e.g. when
+// the mempool or its cache is full, the txs which were neither admitted nor
+// rechecked since the previous commit are no longer tracked once a block is
+// committed.
+type PriorityFloorPolicy struct {
+	maxTxs     int
+	generation int64                    // number of committed blocks
+	txs        map[string]*mempoolEntry // admitted txs by hash
+	priorities mempoolHeap              // admitted txs by lowest priority
+}
+
+var _ MempoolPolicy = (*PriorityFloorPolicy)(nil)
+
+// NewPriorityFloorPolicy returns a PriorityFloorPolicy of the given capacity.
+func NewPriorityFloorPolicy(maxTxs int) *PriorityFloorPolicy {
+	if maxTxs <= 0 {
+		panic(fmt.Sprintf("invalid mempool capacity: %d", maxTxs))
+	}
+	return &PriorityFloorPolicy{
+		maxTxs: maxTxs,
+		txs:    make(map[string]*mempoolEntry),
+	}
+}
+
+// Floor returns the priority the new txs must exceed, and whether the mempool
+// is full, the floor applying only while it is.
+func (p *PriorityFloorPolicy) Floor() (floor int64, full bool) {
+	if len(p.txs) < p.maxTxs {
+		return 0, false
+	}
+	return p.priorities[0].priority, true
+}
+
+// AdmitTx implements MempoolPolicy
+func (p *PriorityFloorPolicy) AdmitTx(txHash []byte, priority int64) sdk.Error {
+	if entry, ok := p.txs[string(txHash)]; ok {
+		entry.generation = p.generation
+		return nil
+	}
+	if floor, full := p.Floor(); full && priority <= floor {
+		return sdk.ErrInsufficientFee(fmt.Sprintf(
+			"mempool is full, tx priority %d must exceed %d", priority, floor))
+	}
+	entry := &mempoolEntry{txHash: string(txHash), priority: priority, generation: p.generation}
+	p.txs[entry.txHash] = entry
+	heap.Push(&p.priorities, entry)
+	return nil
+}
+
+// RemoveTx implements MempoolPolicy
+func (p *PriorityFloorPolicy) RemoveTx(txHash []byte) {
+	entry, ok := p.txs[string(txHash)]
+	if !ok {
+		return
+	}
+	delete(p.txs, entry.txHash)
+	heap.Remove(&p.priorities, entry.index)
+}
+
+// Commit implements MempoolPolicy, it stops tracking the txs which were
+// neither admitted nor rechecked since the previous commit.
+func (p *PriorityFloorPolicy) Commit() {
+	for _, entry := range p.txs {
+		if entry.generation < p.generation {
+			p.RemoveTx([]byte(entry.txHash))
+		}
+	}
+	p.generation++
+}
+
+// a tx tracked by the PriorityFloorPolicy
+type mempoolEntry struct {
+	txHash     string
+	priority   int64
+	generation int64 // generation in which the tx was last admitted or rechecked
+	index      int   // index in the heap
+}
+
+// min-heap of the tracked txs by priority, implementing heap.Interface
+type mempoolHeap []*mempoolEntry
+
+func (h mempoolHeap) Len() int           { return len(h) }
+func (h mempoolHeap) Less(i, j int) bool { return h[i].priority < h[j].priority }
+func (h mempoolHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *mempoolHeap) Push(x interface{}) {
+	entry := x.(*mempoolEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *mempoolHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return entry
+}
@@ -3,6 +3,7 @@ package baseapp
 import (
 	"fmt"
 
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -10,20 +11,22 @@ import (
 // for options that need access to non-exported fields of the BaseApp
 
 // SetPruning sets a pruning option on the multistore associated with the app
-func SetPruning(pruning string) func(*BaseApp) {
-	var pruningEnum sdk.PruningStrategy
-	switch pruning {
-	case "nothing":
-		pruningEnum = sdk.PruneNothing
-	case "everything":
-		pruningEnum = sdk.PruneEverything
-	case "syncable":
-		pruningEnum = sdk.PruneSyncable
-	default:
-		panic(fmt.Sprintf("invalid pruning strategy: %s", pruning))
+func SetPruning(pruning sdk.PruningStrategy) func(*BaseApp) {
+	if err := pruning.Validate(); err != nil {
+		panic(err)
 	}
 	return func(bap *BaseApp) {
-		bap.cms.SetPruning(pruningEnum)
+		bap.cms.SetPruning(pruning)
+	}
+}
+
+// SetStateListeners returns an option that adds listeners notified of the
+// changes of the app state committed by each block.
+func SetStateListeners(listeners ...sdk.StateListener) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		for _, listener := range listeners {
+			bap.cms.AddListener(listener)
+		}
 	}
 }
 
@@ -35,3 +38,32 @@ func SetMinimumFees(minFees string) func(*BaseApp) {
 	}
 	return func(bap *BaseApp) { bap.SetMinimumFees(fees) }
 }
+
+// SetSnapshotStore returns an option that sets the store of the snapshots of
+// the app state.
+func SetSnapshotStore(snapshotStore store.SnapshotStore) func(*BaseApp) {
+	return func(bap *BaseApp) { bap.snapshotStore = snapshotStore }
+}
+
+// SetSnapshotInterval returns an option that makes the app snapshot its state
+// every given number of blocks, a zero interval disables periodic snapshots.
+func SetSnapshotInterval(interval int64) func(*BaseApp) {
+	if interval < 0 {
+		panic(fmt.Sprintf("invalid snapshot interval: %d", interval))
+	}
+	return func(bap *BaseApp) { bap.snapshotInterval = interval }
+}
+
+// SetMempoolMaxTxs returns an option that rejects the txs whose priority does
+// not exceed the lowest one in the mempool once the node admitted the given
+// number of txs into it, a zero number disables the policy.
+func SetMempoolMaxTxs(maxTxs int) func(*BaseApp) {
+	if maxTxs < 0 {
+		panic(fmt.Sprintf("invalid mempool capacity: %d", maxTxs))
+	}
+	return func(bap *BaseApp) {
+		if maxTxs > 0 {
+			bap.SetMempoolPolicy(NewPriorityFloorPolicy(maxTxs))
+		}
+	}
+}
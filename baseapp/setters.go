@@ -50,6 +50,11 @@ func (app *BaseApp) SetAnteHandler(ah sdk.AnteHandler) {
 	}
 	app.anteHandler = ah
 }
+
+// SetAnteDecorators sets the AnteHandler running the decorators in order.
+func (app *BaseApp) SetAnteDecorators(decorators ...sdk.AnteDecorator) {
+	app.SetAnteHandler(sdk.ChainAnteDecorators(decorators...))
+}
 func (app *BaseApp) SetAddrPeerFilter(pf sdk.PeerFilter) {
 	if app.sealed {
 		panic("SetAddrPeerFilter() on sealed BaseApp")
@@ -62,6 +67,15 @@ func (app *BaseApp) SetPubKeyPeerFilter(pf sdk.PeerFilter) {
 	}
 	app.pubkeyPeerFilter = pf
 }
+
+// SetMempoolPolicy sets the policy admitting the checked txs into the mempool
+// of the node.
+func (app *BaseApp) SetMempoolPolicy(policy MempoolPolicy) {
+	if app.sealed {
+		panic("SetMempoolPolicy() on sealed BaseApp")
+	}
+	app.mempoolPolicy = policy
+}
 func (app *BaseApp) Router() Router {
 	if app.sealed {
 		panic("Router() on sealed BaseApp")
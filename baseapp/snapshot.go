@@ -0,0 +1,53 @@
+package baseapp
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/cosmos/cosmos-sdk/store"
+)
+
+// CreateSnapshot snapshots the state committed at the given height into the
+// snapshot store of the app.
+func (app *BaseApp) CreateSnapshot(height int64) (store.Snapshot, error) {
+	snapshotter, err := app.snapshotter()
+	if err != nil {
+		return store.Snapshot{}, err
+	}
+	return store.SaveSnapshot(snapshotter, app.snapshotStore, height)
+}
+
+// ListSnapshots returns the snapshots in the snapshot store of the app.
+func (app *BaseApp) ListSnapshots() ([]store.Snapshot, error) {
+	if app.snapshotStore == nil {
+		return nil, errors.New("no snapshot store set")
+	}
+	return app.snapshotStore.List()
+}
+
+// RestoreSnapshot restores the snapshot at the given height into the empty
+// state of the app, and loads the restored state.
+func (app *BaseApp) RestoreSnapshot(height int64) (store.Snapshot, error) {
+	if app.baseKey == nil {
+		return store.Snapshot{}, errors.New("app state must be loaded before restoring a snapshot")
+	}
+	snapshotter, err := app.snapshotter()
+	if err != nil {
+		return store.Snapshot{}, err
+	}
+	snapshot, err := store.RestoreSnapshot(snapshotter, app.snapshotStore, height)
+	if err != nil {
+		return store.Snapshot{}, err
+	}
+	return snapshot, app.initFromStore(app.baseKey)
+}
+
+func (app *BaseApp) snapshotter() (store.Snapshotter, error) {
+	if app.snapshotStore == nil {
+		return nil, errors.New("no snapshot store set")
+	}
+	snapshotter, ok := app.cms.(store.Snapshotter)
+	if !ok {
+		return nil, errors.New("multistore does not support snapshots")
+	}
+	return snapshotter, nil
+}
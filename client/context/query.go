@@ -307,7 +307,7 @@ func (ctx CLIContext) query(path string, key cmn.HexBytes) (res []byte, err erro
 		return resp.Value, nil
 	}
 
-	err = ctx.verifyProof(path, resp)
+	err = ctx.verifyProof(path, key, resp)
 	if err != nil {
 		return nil, err
 	}
@@ -326,9 +326,9 @@ func (ctx CLIContext) Certify(height int64) (lite.Commit, error) {
 	return check, nil
 }
 
-// verifyProof perform response proof verification
-// nolint: unparam
-func (ctx CLIContext) verifyProof(path string, resp abci.ResponseQuery) error {
+// verifyProof perform response proof verification of the value of the key, or
+// of the key-value pairs of the subspace for subspace queries
+func (ctx CLIContext) verifyProof(path string, key []byte, resp abci.ResponseQuery) error {
 
 	if ctx.Certifier == nil {
 		return fmt.Errorf("missing valid certifier to verify data from untrusted node")
@@ -354,7 +354,20 @@ func (ctx CLIContext) verifyProof(path string, resp abci.ResponseQuery) error {
 		return errors.Wrap(err, "failed in verifying the proof against appHash")
 	}
 
-	err = store.VerifyRangeProof(resp.Key, resp.Value, substoreCommitHash, &multiStoreProof.RangeProof)
+	if strings.HasSuffix(path, "/subspace") {
+		var kvs []sdk.KVPair
+		err = cdc.UnmarshalBinary(resp.Value, &kvs)
+		if err != nil {
+			return errors.Wrap(err, "failed to unmarshalBinary subspace key-value pairs")
+		}
+		err = store.VerifySubspaceProof(key, kvs, substoreCommitHash, &multiStoreProof.RangeProof)
+		if err != nil {
+			return errors.Wrap(err, "failed in the subspace proof verification")
+		}
+		return nil
+	}
+
+	err = store.VerifyRangeProof(key, resp.Value, substoreCommitHash, &multiStoreProof.RangeProof)
 	if err != nil {
 		return errors.Wrap(err, "failed in the range proof verification")
 	}
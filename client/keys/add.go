@@ -14,7 +14,9 @@ import (
 
 	ccrypto "github.com/cosmos/cosmos-sdk/crypto"
 	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/multisig"
 
+	tmcrypto "github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/libs/cli"
 )
 
@@ -25,6 +27,8 @@ const (
 	flagDryRun   = "dry-run"
 	flagAccount  = "account"
 	flagIndex    = "index"
+	flagMultisig = "multisig"
+	flagMultiK   = "multisig-threshold"
 )
 
 func addKeyCommand() *cobra.Command {
@@ -33,7 +37,11 @@ func addKeyCommand() *cobra.Command {
 		Short: "Create a new key, or import from seed",
 		Long: `Add a public/private key pair to the key store.
 If you select --seed/-s you can recover a key from the seed
-phrase, otherwise, a new key will be generated.`,
+phrase, otherwise, a new key will be generated.
+
+Use the --multisig flag to store a reference to a multisig public key made of the
+public keys of the given existing keys. Such a key cannot sign on its own, its
+signatures are assembled from the signatures of the keys it is made of.`,
 		RunE: runAddCmd,
 	}
 	cmd.Flags().StringP(flagType, "t", "secp256k1", "Type of private key (secp256k1|ed25519)")
@@ -43,6 +51,8 @@ phrase, otherwise, a new key will be generated.`,
 	cmd.Flags().Bool(flagDryRun, false, "Perform action, but don't add key to local keystore")
 	cmd.Flags().Uint32(flagAccount, 0, "Account number for HD derivation")
 	cmd.Flags().Uint32(flagIndex, 0, "Index number for HD derivation")
+	cmd.Flags().StringSlice(flagMultisig, nil, "Construct and store a multisig public key from the comma separated names of existing keys")
+	cmd.Flags().Uint(flagMultiK, 1, "Number of signatures required to sign with the multisig public key")
 	return cmd
 }
 
@@ -78,6 +88,32 @@ func runAddCmd(cmd *cobra.Command, args []string) error {
 			}
 		}
 
+		multisigKeys := viper.GetStringSlice(flagMultisig)
+		if len(multisigKeys) != 0 {
+			pks := make([]tmcrypto.PubKey, len(multisigKeys))
+			for i, keyname := range multisigKeys {
+				k, err := kb.Get(keyname)
+				if err != nil {
+					return err
+				}
+				pks[i] = k.GetPubKey()
+			}
+			multisigThreshold := viper.GetInt(flagMultiK)
+			if multisigThreshold <= 0 || multisigThreshold > len(pks) {
+				return fmt.Errorf("multisig threshold must be between 1 and %d", len(pks))
+			}
+
+			pk := multisig.NewPubKeyMultisigThreshold(multisigThreshold, pks)
+			info, err := kb.CreateMulti(name, pk)
+			if err != nil {
+				return err
+			}
+			// a multisig key has no seed phrase to back up
+			viper.Set(flagNoBackup, true)
+			printCreate(info, "")
+			return nil
+		}
+
 		// ask for a password when generating a local key
 		if !viper.GetBool(client.FlagUseLedger) {
 			pass, err = client.GetCheckPassword(
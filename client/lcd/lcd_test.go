@@ -30,6 +30,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	authrest "github.com/cosmos/cosmos-sdk/x/auth/client/rest"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 )
@@ -408,10 +409,18 @@ func TestTxs(t *testing.T) {
 
 	tests.WaitForHeight(resultTx.Height+1, port)
 
-	// check if tx is findable
+	// check if tx is findable, with the result of its message
 	res, body = Request(t, port, "GET", fmt.Sprintf("/txs/%s", resultTx.Hash), nil)
 	require.Equal(t, http.StatusOK, res.StatusCode, body)
 
+	var indexedTx tx.Info
+	err := cdc.UnmarshalJSON([]byte(body), &indexedTx)
+	require.NoError(t, err)
+	require.Len(t, indexedTx.MsgResults, 1)
+	require.Equal(t, 0, indexedTx.MsgResults[0].MsgIndex)
+	require.Equal(t, "bank", indexedTx.MsgResults[0].Route)
+	require.Contains(t, indexedTx.MsgResults[0].Tags, sdk.MakeTag("action", []byte("send")))
+
 	var indexedTxs []tx.Info
 
 	// check if tx is queryable
@@ -419,7 +428,7 @@ func TestTxs(t *testing.T) {
 	require.Equal(t, http.StatusOK, res.StatusCode, body)
 	require.NotEqual(t, "[]", body)
 
-	err := cdc.UnmarshalJSON([]byte(body), &indexedTxs)
+	err = cdc.UnmarshalJSON([]byte(body), &indexedTxs)
 	require.NoError(t, err)
 	require.Equal(t, 1, len(indexedTxs))
 
@@ -478,8 +487,11 @@ func TestPoolParamsQuery(t *testing.T) {
 	require.Equal(t, initialPool.DateLastCommissionReset, pool.DateLastCommissionReset)
 	require.Equal(t, initialPool.PrevBondedShares, pool.PrevBondedShares)
 	require.Equal(t, initialPool.BondedTokens, pool.BondedTokens)
-	require.Equal(t, initialPool.NextInflation(params), pool.Inflation)
-	initialPool = initialPool.ProcessProvisions(params) // provisions are added to the pool every hour
+
+	// provisions are minted into the loose tokens every hour
+	_, provisions := mint.InitialMinter().ProcessProvisions(mint.DefaultParams(),
+		initialPool.TokenSupply(), initialPool.BondedRatio())
+	initialPool.LooseTokens = initialPool.LooseTokens.Add(sdk.NewDecFromInt(provisions.Amount))
 	require.Equal(t, initialPool.LooseTokens, pool.LooseTokens)
 }
 
@@ -610,8 +622,7 @@ func TestSubmitProposal(t *testing.T) {
 	require.Equal(t, uint32(0), resultTx.CheckTx.Code)
 	require.Equal(t, uint32(0), resultTx.DeliverTx.Code)
 
-	var proposalID int64
-	cdc.UnmarshalBinaryBare(resultTx.DeliverTx.GetData(), &proposalID)
+	proposalID := getProposalID(t, resultTx)
 
 	// query proposal
 	proposal := getProposal(t, port, proposalID)
@@ -632,8 +643,7 @@ func TestDeposit(t *testing.T) {
 	require.Equal(t, uint32(0), resultTx.CheckTx.Code)
 	require.Equal(t, uint32(0), resultTx.DeliverTx.Code)
 
-	var proposalID int64
-	cdc.UnmarshalBinaryBare(resultTx.DeliverTx.GetData(), &proposalID)
+	proposalID := getProposalID(t, resultTx)
 
 	// query proposal
 	proposal := getProposal(t, port, proposalID)
@@ -666,8 +676,7 @@ func TestVote(t *testing.T) {
 	require.Equal(t, uint32(0), resultTx.CheckTx.Code)
 	require.Equal(t, uint32(0), resultTx.DeliverTx.Code)
 
-	var proposalID int64
-	cdc.UnmarshalBinaryBare(resultTx.DeliverTx.GetData(), &proposalID)
+	proposalID := getProposalID(t, resultTx)
 
 	// query proposal
 	proposal := getProposal(t, port, proposalID)
@@ -716,18 +725,15 @@ func TestProposalsQuery(t *testing.T) {
 
 	// Addr1 proposes (and deposits) proposals #1 and #2
 	resultTx := doSubmitProposal(t, port, seed, name, password1, addr)
-	var proposalID1 int64
-	cdc.UnmarshalBinaryBare(resultTx.DeliverTx.GetData(), &proposalID1)
+	proposalID1 := getProposalID(t, resultTx)
 	tests.WaitForHeight(resultTx.Height+1, port)
 	resultTx = doSubmitProposal(t, port, seed, name, password1, addr)
-	var proposalID2 int64
-	cdc.UnmarshalBinaryBare(resultTx.DeliverTx.GetData(), &proposalID2)
+	proposalID2 := getProposalID(t, resultTx)
 	tests.WaitForHeight(resultTx.Height+1, port)
 
 	// Addr2 proposes (and deposits) proposals #3
 	resultTx = doSubmitProposal(t, port, seed2, name2, password2, addr2)
-	var proposalID3 int64
-	cdc.UnmarshalBinaryBare(resultTx.DeliverTx.GetData(), &proposalID3)
+	proposalID3 := getProposalID(t, resultTx)
 	tests.WaitForHeight(resultTx.Height+1, port)
 
 	// Addr2 deposits on proposals #2 & #3
@@ -1010,9 +1016,7 @@ func doDelegate(t *testing.T, port, seed, name, password string,
 			}
 		],
 		"begin_unbondings": [],
-		"complete_unbondings": [],
-		"begin_redelegates": [],
-		"complete_redelegates": []
+		"begin_redelegates": []
 	}`, name, password, accnum, sequence, chainID, delAddr, valAddr, "steak", amount))
 
 	res, body := Request(t, port, "POST", fmt.Sprintf("/stake/delegators/%s/delegations", delAddr), jsonStr)
@@ -1047,9 +1051,7 @@ func doBeginUnbonding(t *testing.T, port, seed, name, password string,
 				"shares": "%d"
 			}
 		],
-		"complete_unbondings": [],
-		"begin_redelegates": [],
-		"complete_redelegates": []
+		"begin_redelegates": []
 	}`, name, password, accnum, sequence, chainID, delAddr, valAddr, amount))
 
 	res, body := Request(t, port, "POST", fmt.Sprintf("/stake/delegators/%s/delegations", delAddr), jsonStr)
@@ -1079,7 +1081,6 @@ func doBeginRedelegation(t *testing.T, port, seed, name, password string,
 		"chain_id": "%s",
 		"delegations": [],
 		"begin_unbondings": [],
-		"complete_unbondings": [],
 		"begin_redelegates": [
 			{
 				"delegator_addr": "%s",
@@ -1087,8 +1088,7 @@ func doBeginRedelegation(t *testing.T, port, seed, name, password string,
 				"validator_dst_addr": "%s",
 				"shares": "30"
 			}
-		],
-		"complete_redelegates": []
+		]
 	}`, name, password, accnum, sequence, chainID, delAddr, valSrcAddr, valDstAddr))
 
 	res, body := Request(t, port, "POST", fmt.Sprintf("/stake/delegators/%s/delegations", delAddr), jsonStr)
@@ -1211,6 +1211,18 @@ func getProposalsFilterStatus(t *testing.T, port string, status gov.ProposalStat
 	return proposals
 }
 
+// decodes the ID of the proposal submitted by the tx from the result of its msg
+func getProposalID(t *testing.T, resultTx ctypes.ResultBroadcastTxCommit) int64 {
+	msgResults, err := sdk.DecodeMsgResults(resultTx.DeliverTx.GetData())
+	require.Nil(t, err)
+	require.Len(t, msgResults, 1)
+	require.Equal(t, "gov", msgResults[0].Route)
+	var proposalID int64
+	err = cdc.UnmarshalBinaryBare(msgResults[0].Data, &proposalID)
+	require.Nil(t, err)
+	return proposalID
+}
+
 func doSubmitProposal(t *testing.T, port, seed, name, password string, proposerAddr sdk.AccAddress) (resultTx ctypes.ResultBroadcastTxCommit) {
 
 	acc := getAccount(t, port, proposerAddr)
@@ -101,20 +101,68 @@ func formatTxResult(cdc *codec.Codec, res *ctypes.ResultTx) (Info, error) {
 		return Info{}, err
 	}
 
+	// the Data of the txs which did not run their messages, or of the txs
+	// delivered before the results of the messages were encoded into it,
+	// holds no results
+	msgResults, err := sdk.DecodeMsgResults(res.TxResult.Data)
+	if err != nil {
+		msgResults = nil
+	}
+
+	// the logs of the messages are kept in the Log of the tx, apart from their
+	// deterministic results
+	msgLogs, err := sdk.DecodeMsgLogs(res.TxResult.Log)
+	if err != nil {
+		msgLogs = nil
+	}
+
 	return Info{
-		Hash:   res.Hash,
-		Height: res.Height,
-		Tx:     tx,
-		Result: res.TxResult,
+		Hash:       res.Hash,
+		Height:     res.Height,
+		Tx:         tx,
+		Result:     res.TxResult,
+		MsgResults: mergeMsgLogs(msgResults, msgLogs),
 	}, nil
 }
 
+// merges the logs of the messages into their results
+func mergeMsgLogs(msgResults []sdk.MsgResult, msgLogs []sdk.MsgLog) []MsgResult {
+	if msgResults == nil {
+		return nil
+	}
+	logs := make(map[int]string, len(msgLogs))
+	for _, msgLog := range msgLogs {
+		logs[msgLog.MsgIndex] = msgLog.Log
+	}
+	merged := make([]MsgResult, len(msgResults))
+	for i, msgResult := range msgResults {
+		merged[i] = MsgResult{
+			MsgIndex: msgResult.MsgIndex,
+			Route:    msgResult.Route,
+			Data:     msgResult.Data,
+			Log:      logs[msgResult.MsgIndex],
+			Tags:     msgResult.Tags,
+		}
+	}
+	return merged
+}
+
 // Info is used to prepare info to display
 type Info struct {
-	Hash   common.HexBytes        `json:"hash"`
-	Height int64                  `json:"height"`
-	Tx     sdk.Tx                 `json:"tx"`
-	Result abci.ResponseDeliverTx `json:"result"`
+	Hash       common.HexBytes        `json:"hash"`
+	Height     int64                  `json:"height"`
+	Tx         sdk.Tx                 `json:"tx"`
+	Result     abci.ResponseDeliverTx `json:"result"`
+	MsgResults []MsgResult            `json:"msg_results"`
+}
+
+// MsgResult is the result of a message of a tx along with its log
+type MsgResult struct {
+	MsgIndex int      `json:"msg_index"`
+	Route    string   `json:"route"`
+	Data     []byte   `json:"data"`
+	Log      string   `json:"log"`
+	Tags     sdk.Tags `json:"tags"`
 }
 
 func parseTx(cdc *codec.Codec, txBytes []byte) (sdk.Tx, error) {
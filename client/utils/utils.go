@@ -31,11 +31,15 @@ func SendTx(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, msgs []sdk.Msg)
 	}
 
 	if txBldr.SimulateGas || cliCtx.DryRun {
-		txBldr, err = EnrichCtxWithGas(txBldr, cliCtx, name, msgs)
+		result, adjusted, err := simulateMsgs(txBldr, cliCtx, name, msgs)
 		if err != nil {
 			return err
 		}
+		txBldr = txBldr.WithGas(adjusted)
 		fmt.Fprintf(os.Stderr, "estimated gas = %v\n", txBldr.Gas)
+		if cliCtx.DryRun {
+			printGasBreakdown(result.GasBreakdown)
+		}
 	}
 	if cliCtx.DryRun {
 		return nil
@@ -68,18 +72,31 @@ func EnrichCtxWithGas(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, name
 // CalculateGas simulates the execution of a transaction and returns
 // both the estimate obtained by the query and the adjusted amount.
 func CalculateGas(queryFunc func(string, common.HexBytes) ([]byte, error), cdc *amino.Codec, txBytes []byte, adjustment float64) (estimate, adjusted int64, err error) {
-	// run a simulation (via /app/simulate query) to
-	// estimate gas and update TxBuilder accordingly
-	rawRes, err := queryFunc("/app/simulate", txBytes)
+	result, err := simulateTx(queryFunc, cdc, txBytes)
 	if err != nil {
 		return
 	}
-	estimate, err = parseQueryResponse(cdc, rawRes)
+	estimate = result.GasUsed
+	adjusted = adjustGasEstimate(estimate, adjustment)
+	return
+}
+
+// simulateTx runs a simulation (via /app/simulate query) of a transaction and
+// returns its result.
+func simulateTx(queryFunc func(string, common.HexBytes) ([]byte, error), cdc *amino.Codec, txBytes []byte) (result sdk.Result, err error) {
+	rawRes, err := queryFunc("/app/simulate", txBytes)
 	if err != nil {
 		return
 	}
-	adjusted = adjustGasEstimate(estimate, adjustment)
-	return
+	return parseSimulationResult(cdc, rawRes)
+}
+
+// printGasBreakdown prints the gas consumed by each operation of a simulated
+// transaction to os.Stderr.
+func printGasBreakdown(breakdown []sdk.GasConsumption) {
+	for _, consumption := range breakdown {
+		fmt.Fprintf(os.Stderr, "  %s = %v\n", consumption.Descriptor, consumption.Gas)
+	}
 }
 
 // PrintUnsignedStdTx builds an unsigned StdTx and prints it to os.Stdout.
@@ -138,14 +155,58 @@ func SignStdTx(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, name string,
 	return txBldr.SignStdTx(name, passphrase, stdTx, appendSig)
 }
 
+// SignStdTxWithSignerAddress produces the signature of a StdTx by the key with
+// the given name on behalf of the signer address, e.g. as one of the keys of a
+// multisig account. The account number and sequence of the signer address are
+// fetched from the chain unless given.
+func SignStdTxWithSignerAddress(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, addr sdk.AccAddress, name string, stdTx auth.StdTx) (sig auth.StdSignature, err error) {
+	// Check whether the address is a signer
+	if !isTxSigner(addr, stdTx.GetSigners()) {
+		return sig, fmt.Errorf("the generated transaction's intended signer does not match the given signer: %v", addr)
+	}
+
+	if txBldr.AccountNumber == 0 {
+		accNum, err := cliCtx.GetAccountNumber(addr)
+		if err != nil {
+			return sig, err
+		}
+		txBldr = txBldr.WithAccountNumber(accNum)
+	}
+
+	if txBldr.Sequence == 0 {
+		accSeq, err := cliCtx.GetAccountSequence(addr)
+		if err != nil {
+			return sig, err
+		}
+		txBldr = txBldr.WithSequence(accSeq)
+	}
+
+	passphrase, err := keys.GetPassphrase(name)
+	if err != nil {
+		return sig, err
+	}
+	return authtxb.MakeSignature(name, passphrase, auth.StdSignMsg{
+		ChainID:       txBldr.ChainID,
+		AccountNumber: txBldr.AccountNumber,
+		Sequence:      txBldr.Sequence,
+		Fee:           stdTx.Fee,
+		Msgs:          stdTx.GetMsgs(),
+		Memo:          stdTx.GetMemo(),
+	})
+}
+
 // nolint
-// SimulateMsgs simulates the transaction and returns the gas estimate and the adjusted value.
-func simulateMsgs(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, name string, msgs []sdk.Msg) (estimated, adjusted int64, err error) {
+// SimulateMsgs simulates the transaction and returns its result and the adjusted gas estimate.
+func simulateMsgs(txBldr authtxb.TxBuilder, cliCtx context.CLIContext, name string, msgs []sdk.Msg) (result sdk.Result, adjusted int64, err error) {
 	txBytes, err := txBldr.BuildWithPubKey(name, msgs)
 	if err != nil {
 		return
 	}
-	estimated, adjusted, err = CalculateGas(cliCtx.Query, cliCtx.Codec, txBytes, txBldr.GasAdjustment)
+	result, err = simulateTx(cliCtx.Query, cliCtx.Codec, txBytes)
+	if err != nil {
+		return
+	}
+	adjusted = adjustGasEstimate(result.GasUsed, txBldr.GasAdjustment)
 	return
 }
 
@@ -154,13 +215,18 @@ func adjustGasEstimate(estimate int64, adjustment float64) int64 {
 }
 
 func parseQueryResponse(cdc *amino.Codec, rawRes []byte) (int64, error) {
-	var simulationResult sdk.Result
-	if err := cdc.UnmarshalBinary(rawRes, &simulationResult); err != nil {
+	simulationResult, err := parseSimulationResult(cdc, rawRes)
+	if err != nil {
 		return 0, err
 	}
 	return simulationResult.GasUsed, nil
 }
 
+func parseSimulationResult(cdc *amino.Codec, rawRes []byte) (simulationResult sdk.Result, err error) {
+	err = cdc.UnmarshalBinary(rawRes, &simulationResult)
+	return
+}
+
 func prepareTxContext(txBldr authtxb.TxBuilder, cliCtx context.CLIContext) (authtxb.TxBuilder, error) {
 	if err := cliCtx.EnsureAccountExists(); err != nil {
 		return txBldr, err
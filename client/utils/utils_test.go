@@ -21,6 +21,16 @@ func TestParseQueryResponse(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestParseSimulationResult(t *testing.T) {
+	cdc := app.MakeCodec()
+	breakdown := []sdk.GasConsumption{{Descriptor: "WriteFlat", Gas: 10}, {Descriptor: "memo", Gas: 5}}
+	sdkResBytes := cdc.MustMarshalBinary(sdk.Result{GasUsed: 15, GasBreakdown: breakdown})
+	result, err := parseSimulationResult(cdc, sdkResBytes)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(15), result.GasUsed)
+	assert.Equal(t, breakdown, result.GasBreakdown)
+}
+
 func TestCalculateGas(t *testing.T) {
 	cdc := app.MakeCodec()
 	makeQueryFunc := func(gasUsed int64, wantErr bool) func(string, common.HexBytes) ([]byte, error) {
@@ -15,15 +15,23 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/authz"
 	"github.com/cosmos/cosmos-sdk/x/bank"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 const (
 	appName = "GaiaApp"
+
+	// number of keys of each hot store cached across blocks
+	interBlockCacheSize = 10000
 )
 
 // default home directories for expected binaries
@@ -42,9 +50,14 @@ type GaiaApp struct {
 	keyAccount       *sdk.KVStoreKey
 	keyStake         *sdk.KVStoreKey
 	tkeyStake        *sdk.TransientStoreKey
+	keyMint          *sdk.KVStoreKey
+	keyDistr         *sdk.KVStoreKey
 	keySlashing      *sdk.KVStoreKey
 	keyGov           *sdk.KVStoreKey
+	keyUpgrade       *sdk.KVStoreKey
 	keyFeeCollection *sdk.KVStoreKey
+	keyFeeGrant      *sdk.KVStoreKey
+	keyAuthz         *sdk.KVStoreKey
 	keyParams        *sdk.KVStoreKey
 	tkeyParams       *sdk.TransientStoreKey
 
@@ -54,7 +67,12 @@ type GaiaApp struct {
 	bankKeeper          bank.Keeper
 	stakeKeeper         stake.Keeper
 	slashingKeeper      slashing.Keeper
+	mintKeeper          mint.Keeper
+	distrKeeper         distr.Keeper
 	govKeeper           gov.Keeper
+	upgradeKeeper       upgrade.Keeper
+	feeGrantKeeper      feegrant.Keeper
+	authzKeeper         authz.Keeper
 	paramsKeeper        params.Keeper
 }
 
@@ -72,9 +90,14 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 		keyAccount:       sdk.NewKVStoreKey("acc"),
 		keyStake:         sdk.NewKVStoreKey("stake"),
 		tkeyStake:        sdk.NewTransientStoreKey("transient_stake"),
+		keyMint:          sdk.NewKVStoreKey("mint"),
+		keyDistr:         sdk.NewKVStoreKey("distr"),
 		keySlashing:      sdk.NewKVStoreKey("slashing"),
 		keyGov:           sdk.NewKVStoreKey("gov"),
+		keyUpgrade:       sdk.NewKVStoreKey("upgrade"),
 		keyFeeCollection: sdk.NewKVStoreKey("fee"),
+		keyFeeGrant:      sdk.NewKVStoreKey("feegrant"),
+		keyAuthz:         sdk.NewKVStoreKey("authz"),
 		keyParams:        sdk.NewKVStoreKey("params"),
 		tkeyParams:       sdk.NewTransientStoreKey("transient_params"),
 	}
@@ -88,32 +111,66 @@ func NewGaiaApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOptio
 
 	// add handlers
 	app.bankKeeper = bank.NewBaseKeeper(app.accountMapper)
-	app.paramsKeeper = params.NewKeeper(app.cdc, app.keyParams)
-	app.stakeKeeper = stake.NewKeeper(app.cdc, app.keyStake, app.tkeyStake, app.bankKeeper, app.RegisterCodespace(stake.DefaultCodespace))
-	app.slashingKeeper = slashing.NewKeeper(app.cdc, app.keySlashing, app.stakeKeeper, app.paramsKeeper.Getter(), app.RegisterCodespace(slashing.DefaultCodespace))
-	app.stakeKeeper = app.stakeKeeper.WithValidatorHooks(app.slashingKeeper.ValidatorHooks())
-	app.govKeeper = gov.NewKeeper(app.cdc, app.keyGov, app.paramsKeeper.Setter(), app.bankKeeper, app.stakeKeeper, app.RegisterCodespace(gov.DefaultCodespace))
 	app.feeCollectionKeeper = auth.NewFeeCollectionKeeper(app.cdc, app.keyFeeCollection)
+	app.paramsKeeper = params.NewKeeper(app.cdc, app.keyParams)
+	app.feeGrantKeeper = feegrant.NewKeeper(app.cdc, app.keyFeeGrant, app.Router(), app.RegisterCodespace(feegrant.DefaultCodespace))
+	app.authzKeeper = authz.NewKeeper(app.cdc, app.keyAuthz, app.Router(), app.RegisterCodespace(authz.DefaultCodespace))
+	stakeKeeper := stake.NewKeeper(app.cdc, app.keyStake, app.tkeyStake, app.bankKeeper, app.RegisterCodespace(stake.DefaultCodespace))
+	app.distrKeeper = distr.NewKeeper(app.cdc, app.keyDistr, app.paramsKeeper.Setter(), app.bankKeeper, &stakeKeeper,
+		app.feeCollectionKeeper, app.RegisterCodespace(distr.DefaultCodespace))
+	app.slashingKeeper = slashing.NewKeeper(app.cdc, app.keySlashing, &stakeKeeper, app.paramsKeeper.Getter(), app.RegisterCodespace(slashing.DefaultCodespace))
+
+	// register the staking hooks
+	// NOTE: the keepers above hold a reference to stakeKeeper so that they
+	// observe the hooks being set
+	stakeKeeper = stakeKeeper.WithHooks(NewStakingHooks(app.distrKeeper.Hooks(), app.slashingKeeper.Hooks()))
+	app.stakeKeeper = stakeKeeper
+	app.mintKeeper = mint.NewKeeper(app.cdc, app.keyMint, app.stakeKeeper, app.feeCollectionKeeper)
+
+	// register the params which may be changed through governance proposals
+	paramRegistry := params.NewRegistry()
+	gov.RegisterParams(paramRegistry)
+	slashing.RegisterParams(paramRegistry)
+	distr.RegisterParams(paramRegistry)
+	auth.RegisterParams(paramRegistry)
+	stake.RegisterParams(paramRegistry, app.stakeKeeper)
+
+	// NOTE: binaries supporting a software upgrade register its upgrade handler
+	// here with app.upgradeKeeper.SetUpgradeHandler
+	app.upgradeKeeper = upgrade.NewKeeper(app.cdc, app.keyUpgrade, app.RegisterCodespace(upgrade.DefaultCodespace))
+	app.govKeeper = gov.NewKeeper(app.cdc, app.keyGov, app.paramsKeeper.Setter(), paramRegistry, app.upgradeKeeper,
+		app.bankKeeper, app.stakeKeeper, app.RegisterCodespace(gov.DefaultCodespace))
 
 	// register message routes
 	app.Router().
 		AddRoute("bank", bank.NewHandler(app.bankKeeper)).
 		AddRoute("stake", stake.NewHandler(app.stakeKeeper)).
+		AddRoute("distr", distr.NewHandler(app.distrKeeper)).
 		AddRoute("slashing", slashing.NewHandler(app.slashingKeeper)).
-		AddRoute("gov", gov.NewHandler(app.govKeeper))
+		AddRoute("gov", gov.NewHandler(app.govKeeper)).
+		AddRoute("feegrant", feegrant.NewHandler(app.feeGrantKeeper)).
+		AddRoute("authz", authz.NewHandler(app.authzKeeper))
 
 	app.QueryRouter().
 		AddRoute("gov", gov.NewQuerier(app.govKeeper)).
-		AddRoute("stake", stake.NewQuerier(app.stakeKeeper, app.cdc))
+		AddRoute("stake", stake.NewQuerier(app.stakeKeeper, app.cdc)).
+		AddRoute("distr", distr.NewQuerier(app.distrKeeper, app.cdc)).
+		AddRoute("feegrant", feegrant.NewQuerier(app.feeGrantKeeper)).
+		AddRoute("authz", authz.NewQuerier(app.authzKeeper))
 
 	// initialize BaseApp
 	app.SetInitChainer(app.initChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetEndBlocker(app.EndBlocker)
-	app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, app.feeCollectionKeeper))
-	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyStake,
-		app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyParams)
+	app.SetAnteDecorators(auth.NewAnteDecoratorsWithFeeGrants(app.accountMapper, app.feeCollectionKeeper,
+		app.feeGrantKeeper, app.paramsKeeper.Getter())...)
+	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyStake, app.keyMint, app.keyDistr,
+		app.keySlashing, app.keyGov, app.keyUpgrade, app.keyFeeCollection, app.keyFeeGrant, app.keyAuthz, app.keyParams)
 	app.MountStoresTransient(app.tkeyParams, app.tkeyStake)
+	// the stores read by every block
+	for _, key := range []*sdk.KVStoreKey{app.keyStake, app.keyMint, app.keyDistr, app.keyFeeCollection, app.keyParams} {
+		app.SetInterBlockCache(key, interBlockCacheSize)
+	}
 	err := app.LoadLatestVersion(app.keyMain)
 	if err != nil {
 		cmn.Exit(err.Error())
@@ -127,8 +184,11 @@ func MakeCodec() *codec.Codec {
 	var cdc = codec.New()
 	bank.RegisterCodec(cdc)
 	stake.RegisterCodec(cdc)
+	distr.RegisterCodec(cdc)
 	slashing.RegisterCodec(cdc)
 	gov.RegisterCodec(cdc)
+	feegrant.RegisterCodec(cdc)
+	authz.RegisterCodec(cdc)
 	auth.RegisterCodec(cdc)
 	sdk.RegisterCodec(cdc)
 	codec.RegisterCrypto(cdc)
@@ -137,6 +197,15 @@ func MakeCodec() *codec.Codec {
 
 // application updates every end block
 func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	// apply a due software upgrade, or halt if this binary does not support it
+	upgrade.BeginBlocker(ctx, app.upgradeKeeper)
+
+	// mint new tokens for this new block
+	mint.BeginBlocker(ctx, app.mintKeeper)
+
+	// distribute rewards from the previous block
+	distr.BeginBlocker(ctx, req, app.distrKeeper)
+
 	tags := slashing.BeginBlocker(ctx, req, app.slashingKeeper)
 
 	return abci.ResponseBeginBlock{
@@ -148,7 +217,10 @@ func (app *GaiaApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) ab
 // nolint: unparam
 func (app *GaiaApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 	tags := gov.EndBlocker(ctx, app.govKeeper)
-	validatorUpdates := stake.EndBlocker(ctx, app.stakeKeeper)
+
+	validatorUpdates, endBlockerTags := stake.EndBlocker(ctx, app.stakeKeeper)
+	tags = append(tags, endBlockerTags...)
+
 	// Add these new validators to the addr -> pubkey map.
 	app.slashingKeeper.AddValidators(ctx, validatorUpdates)
 	return abci.ResponseEndBlock{
@@ -172,10 +244,16 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 	// load the accounts
 	for _, gacc := range genesisState.Accounts {
 		acc := gacc.ToAccount()
-		acc.AccountNumber = app.accountMapper.GetNextAccountNumber(ctx)
+		acc.SetAccountNumber(app.accountMapper.GetNextAccountNumber(ctx))
 		app.accountMapper.SetAccount(ctx, acc)
 	}
 
+	mint.InitGenesis(ctx, app.mintKeeper, genesisState.MintData)
+
+	// load the distribution information first, the stake genesis calls the
+	// distribution hooks for each validator and delegation
+	distr.InitGenesis(ctx, app.distrKeeper, genesisState.DistrData)
+
 	// load the initial stake information
 	validators, err := stake.InitGenesis(ctx, app.stakeKeeper, genesisState.StakeData)
 	if err != nil {
@@ -187,6 +265,9 @@ func (app *GaiaApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) abci
 	slashing.InitGenesis(ctx, app.slashingKeeper, genesisState.StakeData)
 
 	gov.InitGenesis(ctx, app.govKeeper, genesisState.GovData)
+	feegrant.InitGenesis(ctx, app.feeGrantKeeper, genesisState.FeeGrantData)
+	authz.InitGenesis(ctx, app.authzKeeper, genesisState.AuthzData)
+	upgrade.InitGenesis(ctx, app.upgradeKeeper, genesisState.UpgradeData)
 	err = GaiaValidateGenesisState(genesisState)
 	if err != nil {
 		// TODO find a way to do this w/o panics
@@ -212,9 +293,14 @@ func (app *GaiaApp) ExportAppStateAndValidators() (appState json.RawMessage, val
 	app.accountMapper.IterateAccounts(ctx, appendAccount)
 
 	genState := GenesisState{
-		Accounts:  accounts,
-		StakeData: stake.WriteGenesis(ctx, app.stakeKeeper),
-		GovData:   gov.WriteGenesis(ctx, app.govKeeper),
+		Accounts:     accounts,
+		StakeData:    stake.WriteGenesis(ctx, app.stakeKeeper),
+		MintData:     mint.WriteGenesis(ctx, app.mintKeeper),
+		DistrData:    distr.WriteGenesis(ctx, app.distrKeeper),
+		GovData:      gov.WriteGenesis(ctx, app.govKeeper),
+		FeeGrantData: feegrant.WriteGenesis(ctx, app.feeGrantKeeper),
+		AuthzData:    authz.WriteGenesis(ctx, app.authzKeeper),
+		UpgradeData:  upgrade.WriteGenesis(ctx, app.upgradeKeeper),
 	}
 	appState, err = codec.MarshalJSONIndent(app.cdc, genState)
 	if err != nil {
@@ -223,3 +309,51 @@ func (app *GaiaApp) ExportAppStateAndValidators() (appState json.RawMessage, val
 	validators = stake.WriteValidators(ctx, app.stakeKeeper)
 	return appState, validators, nil
 }
+
+//______________________________________________________________________________________________
+
+// Combined Staking Hooks
+type StakingHooks struct {
+	dh distr.Hooks
+	sh slashing.Hooks
+}
+
+func NewStakingHooks(dh distr.Hooks, sh slashing.Hooks) StakingHooks {
+	return StakingHooks{dh, sh}
+}
+
+var _ sdk.StakingHooks = StakingHooks{}
+
+// nolint
+func (h StakingHooks) OnValidatorCreated(ctx sdk.Context, addr sdk.ValAddress) {
+	h.dh.OnValidatorCreated(ctx, addr)
+	h.sh.OnValidatorCreated(ctx, addr)
+}
+func (h StakingHooks) OnValidatorModified(ctx sdk.Context, addr sdk.ValAddress) {
+	h.dh.OnValidatorModified(ctx, addr)
+	h.sh.OnValidatorModified(ctx, addr)
+}
+func (h StakingHooks) OnValidatorRemoved(ctx sdk.Context, addr sdk.ValAddress) {
+	h.dh.OnValidatorRemoved(ctx, addr)
+	h.sh.OnValidatorRemoved(ctx, addr)
+}
+func (h StakingHooks) OnValidatorBonded(ctx sdk.Context, addr sdk.ConsAddress) {
+	h.dh.OnValidatorBonded(ctx, addr)
+	h.sh.OnValidatorBonded(ctx, addr)
+}
+func (h StakingHooks) OnValidatorBeginUnbonding(ctx sdk.Context, addr sdk.ConsAddress) {
+	h.dh.OnValidatorBeginUnbonding(ctx, addr)
+	h.sh.OnValidatorBeginUnbonding(ctx, addr)
+}
+func (h StakingHooks) OnDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	h.dh.OnDelegationCreated(ctx, delAddr, valAddr)
+	h.sh.OnDelegationCreated(ctx, delAddr, valAddr)
+}
+func (h StakingHooks) OnDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	h.dh.OnDelegationSharesModified(ctx, delAddr, valAddr)
+	h.sh.OnDelegationSharesModified(ctx, delAddr, valAddr)
+}
+func (h StakingHooks) OnDelegationRemoved(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	h.dh.OnDelegationRemoved(ctx, delAddr, valAddr)
+	h.sh.OnDelegationRemoved(ctx, delAddr, valAddr)
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/libs/db"
@@ -23,6 +25,8 @@ func setGenesis(gapp *GaiaApp, accs ...*auth.BaseAccount) error {
 	genesisState := GenesisState{
 		Accounts:  genaccs,
 		StakeData: stake.DefaultGenesisState(),
+		MintData:  mint.DefaultGenesisState(),
+		DistrData: distr.DefaultGenesisState(),
 	}
 
 	stateBytes, err := codec.MarshalJSONIndent(gapp.cdc, genesisState)
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/codec"
@@ -11,9 +12,14 @@ import (
 	"github.com/cosmos/cosmos-sdk/server/config"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 	stakeTypes "github.com/cosmos/cosmos-sdk/x/stake/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 
 	"github.com/spf13/pflag"
 
@@ -32,15 +38,27 @@ var (
 
 // State to Unmarshal
 type GenesisState struct {
-	Accounts  []GenesisAccount   `json:"accounts"`
-	StakeData stake.GenesisState `json:"stake"`
-	GovData   gov.GenesisState   `json:"gov"`
+	Accounts     []GenesisAccount      `json:"accounts"`
+	StakeData    stake.GenesisState    `json:"stake"`
+	MintData     mint.GenesisState     `json:"mint"`
+	DistrData    distr.GenesisState    `json:"distr"`
+	GovData      gov.GenesisState      `json:"gov"`
+	FeeGrantData feegrant.GenesisState `json:"feegrant"`
+	AuthzData    authz.GenesisState    `json:"authz"`
+	UpgradeData  upgrade.GenesisState  `json:"upgrade"`
 }
 
 // GenesisAccount doesn't need pubkey or sequence
 type GenesisAccount struct {
 	Address sdk.AccAddress `json:"address"`
 	Coins   sdk.Coins      `json:"coins"`
+
+	// vesting account fields
+	OriginalVesting  sdk.Coins `json:"original_vesting"`  // total vesting coins upon initialization
+	DelegatedFree    sdk.Coins `json:"delegated_free"`    // delegated vested coins at time of delegation
+	DelegatedVesting sdk.Coins `json:"delegated_vesting"` // delegated vesting coins at time of delegation
+	StartTime        time.Time `json:"start_time"`        // vesting start time, zero for delayed vesting
+	EndTime          time.Time `json:"end_time"`          // vesting end time
 }
 
 func NewGenesisAccount(acc *auth.BaseAccount) GenesisAccount {
@@ -51,18 +69,51 @@ func NewGenesisAccount(acc *auth.BaseAccount) GenesisAccount {
 }
 
 func NewGenesisAccountI(acc auth.Account) GenesisAccount {
-	return GenesisAccount{
+	gacc := GenesisAccount{
 		Address: acc.GetAddress(),
 		Coins:   acc.GetCoins(),
 	}
+
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		gacc.OriginalVesting = vacc.GetOriginalVesting()
+		gacc.DelegatedFree = vacc.GetDelegatedFree()
+		gacc.DelegatedVesting = vacc.GetDelegatedVesting()
+		gacc.StartTime = vacc.GetStartTime()
+		gacc.EndTime = vacc.GetEndTime()
+	}
+
+	return gacc
 }
 
-// convert GenesisAccount to auth.BaseAccount
-func (ga *GenesisAccount) ToAccount() (acc *auth.BaseAccount) {
-	return &auth.BaseAccount{
+// convert GenesisAccount to auth.Account, the account is a vesting account if
+// it has original vesting coins, which vest continuously from its start time
+// if it has one and at once at its end time otherwise
+func (ga *GenesisAccount) ToAccount() auth.Account {
+	bacc := &auth.BaseAccount{
 		Address: ga.Address,
 		Coins:   ga.Coins.Sort(),
 	}
+
+	if ga.OriginalVesting.IsZero() {
+		return bacc
+	}
+
+	bvacc := &auth.BaseVestingAccount{
+		BaseAccount:      bacc,
+		OriginalVesting:  ga.OriginalVesting.Sort(),
+		DelegatedFree:    ga.DelegatedFree.Sort(),
+		DelegatedVesting: ga.DelegatedVesting.Sort(),
+		EndTime:          ga.EndTime,
+	}
+	if !ga.StartTime.IsZero() {
+		return &auth.ContinuousVestingAccount{
+			BaseVestingAccount: bvacc,
+			StartTime:          ga.StartTime,
+		}
+	}
+	return &auth.DelayedVestingAccount{
+		BaseVestingAccount: bvacc,
+	}
 }
 
 // get app init parameters for server init command
@@ -192,9 +243,14 @@ func GaiaAppGenState(cdc *codec.Codec, appGenTxs []json.RawMessage) (genesisStat
 
 	// create the final app state
 	genesisState = GenesisState{
-		Accounts:  genaccs,
-		StakeData: stakeData,
-		GovData:   gov.DefaultGenesisState(),
+		Accounts:     genaccs,
+		StakeData:    stakeData,
+		MintData:     mint.DefaultGenesisState(),
+		DistrData:    distr.DefaultGenesisState(),
+		GovData:      gov.DefaultGenesisState(),
+		FeeGrantData: feegrant.DefaultGenesisState(),
+		AuthzData:    authz.DefaultGenesisState(),
+		UpgradeData:  upgrade.DefaultGenesisState(),
 	}
 	return
 }
@@ -246,7 +302,19 @@ func GaiaValidateGenesisState(genesisState GenesisState) (err error) {
 	if err != nil {
 		return
 	}
-	return
+	err = mint.ValidateGenesis(genesisState.MintData)
+	if err != nil {
+		return
+	}
+	err = feegrant.ValidateGenesis(genesisState.FeeGrantData)
+	if err != nil {
+		return
+	}
+	err = authz.ValidateGenesis(genesisState.AuthzData)
+	if err != nil {
+		return
+	}
+	return upgrade.ValidateGenesis(genesisState.UpgradeData)
 }
 
 func validateGenesisStateValidators(validators []stakeTypes.Validator) (err error) {
@@ -266,6 +334,7 @@ func validateGenesisStateValidators(validators []stakeTypes.Validator) (err erro
 }
 
 // Ensures that there are no duplicate accounts in the genesis state,
+// and that the vesting schedules of vesting accounts are valid
 func validateGenesisStateAccounts(accs []GenesisAccount) (err error) {
 	addrMap := make(map[string]bool, len(accs))
 	for i := 0; i < len(accs); i++ {
@@ -275,6 +344,19 @@ func validateGenesisStateAccounts(accs []GenesisAccount) (err error) {
 			return fmt.Errorf("Duplicate account in genesis state: Address %v", acc.Address)
 		}
 		addrMap[strAddr] = true
+
+		if acc.OriginalVesting.IsZero() {
+			continue
+		}
+		if !acc.OriginalVesting.IsNotNegative() {
+			return fmt.Errorf("Negative original vesting coins in genesis state: Address %v", acc.Address)
+		}
+		if acc.EndTime.IsZero() {
+			return fmt.Errorf("Vesting account without end time in genesis state: Address %v", acc.Address)
+		}
+		if !acc.StartTime.IsZero() && !acc.StartTime.Before(acc.EndTime) {
+			return fmt.Errorf("Vesting start time must be before end time in genesis state: Address %v", acc.Address)
+		}
 	}
 	return
 }
@@ -2,10 +2,13 @@ package app
 
 import (
 	"testing"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/stake"
 	stakeTypes "github.com/cosmos/cosmos-sdk/x/stake/types"
 	"github.com/stretchr/testify/require"
@@ -45,6 +48,8 @@ func makeGenesisState(genTxs []GaiaGenTx) GenesisState {
 	return GenesisState{
 		Accounts:  genaccs,
 		StakeData: stakeData,
+		MintData:  mint.DefaultGenesisState(),
+		DistrData: distr.DefaultGenesisState(),
 		GovData:   gov.DefaultGenesisState(),
 	}
 }
@@ -54,7 +59,23 @@ func TestToAccount(t *testing.T) {
 	addr := sdk.AccAddress(priv.PubKey().Address())
 	authAcc := auth.NewBaseAccountWithAddress(addr)
 	genAcc := NewGenesisAccount(&authAcc)
-	require.Equal(t, authAcc, *genAcc.ToAccount())
+	require.Equal(t, &authAcc, genAcc.ToAccount())
+
+	// vesting accounts are restored with their vesting schedule
+	coins := sdk.Coins{sdk.NewInt64Coin("steak", 50)}
+	authAcc.Coins = coins
+	startTime := time.Unix(1000, 0).UTC()
+	endTime := time.Unix(2000, 0).UTC()
+
+	cvacc := auth.NewContinuousVestingAccount(&authAcc, startTime, endTime)
+	genAcc = NewGenesisAccountI(cvacc)
+	require.Equal(t, coins, genAcc.OriginalVesting)
+	require.Equal(t, cvacc, genAcc.ToAccount())
+
+	dvacc := auth.NewDelayedVestingAccount(&authAcc, endTime)
+	genAcc = NewGenesisAccountI(dvacc)
+	require.True(t, genAcc.StartTime.IsZero())
+	require.Equal(t, dvacc, genAcc.ToAccount())
 }
 
 func TestGaiaAppGenTx(t *testing.T) {
@@ -100,4 +121,17 @@ func TestGaiaGenesisValidation(t *testing.T) {
 	genesisState.StakeData.Validators = append(genesisState.StakeData.Validators, val2)
 	err = GaiaValidateGenesisState(genesisState)
 	require.NotNil(t, err)
+	// Test vesting account without end time fails
+	genesisState = makeGenesisState(genTxs[:1])
+	genesisState.Accounts[0].OriginalVesting = genesisState.Accounts[0].Coins
+	err = GaiaValidateGenesisState(genesisState)
+	require.NotNil(t, err)
+	// Test vesting account ending before it starts fails
+	genesisState.Accounts[0].StartTime = time.Unix(2000, 0)
+	genesisState.Accounts[0].EndTime = time.Unix(1000, 0)
+	err = GaiaValidateGenesisState(genesisState)
+	require.NotNil(t, err)
+	genesisState.Accounts[0].EndTime = time.Unix(3000, 0)
+	err = GaiaValidateGenesisState(genesisState)
+	require.Nil(t, err)
 }
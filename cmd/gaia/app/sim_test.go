@@ -15,8 +15,10 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	banksim "github.com/cosmos/cosmos-sdk/x/bank/simulation"
+	distr "github.com/cosmos/cosmos-sdk/x/distribution"
 	"github.com/cosmos/cosmos-sdk/x/gov"
 	govsim "github.com/cosmos/cosmos-sdk/x/gov/simulation"
+	"github.com/cosmos/cosmos-sdk/x/mint"
 	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
 	slashingsim "github.com/cosmos/cosmos-sdk/x/slashing/simulation"
 	stake "github.com/cosmos/cosmos-sdk/x/stake"
@@ -71,11 +73,15 @@ func appStateFn(r *rand.Rand, accs []simulation.Account) json.RawMessage {
 	stakeGenesis.Validators = validators
 	stakeGenesis.Bonds = delegations
 	// No inflation, for now
-	stakeGenesis.Params.InflationMax = sdk.NewDec(0)
-	stakeGenesis.Params.InflationMin = sdk.NewDec(0)
+	mintGenesis := mint.DefaultGenesisState()
+	mintGenesis.Minter.Inflation = sdk.ZeroDec()
+	mintGenesis.Params.InflationMax = sdk.ZeroDec()
+	mintGenesis.Params.InflationMin = sdk.ZeroDec()
 	genesis := GenesisState{
 		Accounts:  genesisAccounts,
 		StakeData: stakeGenesis,
+		MintData:  mintGenesis,
+		DistrData: distr.DefaultGenesisState(),
 		GovData:   govGenesis,
 	}
 
@@ -97,9 +103,7 @@ func testAndRunTxs(app *GaiaApp) []simulation.WeightedOperation {
 		{5, stakesim.SimulateMsgEditValidator(app.stakeKeeper)},
 		{100, stakesim.SimulateMsgDelegate(app.accountMapper, app.stakeKeeper)},
 		{100, stakesim.SimulateMsgBeginUnbonding(app.accountMapper, app.stakeKeeper)},
-		{100, stakesim.SimulateMsgCompleteUnbonding(app.stakeKeeper)},
 		{100, stakesim.SimulateMsgBeginRedelegate(app.accountMapper, app.stakeKeeper)},
-		{100, stakesim.SimulateMsgCompleteRedelegate(app.stakeKeeper)},
 		{100, slashingsim.SimulateMsgUnjail(app.slashingKeeper)},
 	}
 }
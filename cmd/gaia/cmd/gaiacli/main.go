@@ -12,10 +12,14 @@ import (
 	"github.com/cosmos/cosmos-sdk/client/tx"
 	"github.com/cosmos/cosmos-sdk/version"
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authzcmd "github.com/cosmos/cosmos-sdk/x/authz/client/cli"
 	bankcmd "github.com/cosmos/cosmos-sdk/x/bank/client/cli"
+	distrcmd "github.com/cosmos/cosmos-sdk/x/distribution/client/cli"
+	feegrantcmd "github.com/cosmos/cosmos-sdk/x/feegrant/client/cli"
 	govcmd "github.com/cosmos/cosmos-sdk/x/gov/client/cli"
 	slashingcmd "github.com/cosmos/cosmos-sdk/x/slashing/client/cli"
 	stakecmd "github.com/cosmos/cosmos-sdk/x/stake/client/cli"
+	upgradecmd "github.com/cosmos/cosmos-sdk/x/upgrade/client/cli"
 
 	"github.com/cosmos/cosmos-sdk/cmd/gaia/app"
 	"path"
@@ -92,6 +96,28 @@ func main() {
 		stakeCmd,
 	)
 
+	//Add distribution commands
+	distrCmd := &cobra.Command{
+		Use:   "distr",
+		Short: "Fee and reward distribution subcommands",
+	}
+	distrCmd.AddCommand(
+		client.GetCommands(
+			distrcmd.GetCmdQueryParams("distr", cdc),
+			distrcmd.GetCmdQueryFeePool("distr", cdc),
+			distrcmd.GetCmdQueryValidatorDistInfo("distr", cdc),
+			distrcmd.GetCmdQueryDelegationDistInfo("distr", cdc),
+			distrcmd.GetCmdQueryWithdrawAddr("distr", cdc),
+		)...)
+	distrCmd.AddCommand(
+		client.PostCommands(
+			distrcmd.GetCmdWithdrawRewards(cdc),
+			distrcmd.GetCmdSetWithdrawAddr(cdc),
+		)...)
+	rootCmd.AddCommand(
+		distrCmd,
+	)
+
 	//Add stake commands
 	govCmd := &cobra.Command{
 		Use:   "gov",
@@ -103,6 +129,7 @@ func main() {
 			govcmd.GetCmdQueryVote("gov", cdc),
 			govcmd.GetCmdQueryVotes("gov", cdc),
 			govcmd.GetCmdQueryProposals("gov", cdc),
+			upgradecmd.GetCmdQueryPlan("upgrade", cdc),
 		)...)
 	govCmd.AddCommand(
 		client.PostCommands(
@@ -114,11 +141,51 @@ func main() {
 		govCmd,
 	)
 
+	//Add feegrant commands
+	feegrantCmd := &cobra.Command{
+		Use:   "feegrant",
+		Short: "Fee allowance subcommands",
+	}
+	feegrantCmd.AddCommand(
+		client.GetCommands(
+			feegrantcmd.GetCmdQueryFeeAllowance("feegrant", cdc),
+			feegrantcmd.GetCmdQueryFeeGrants("feegrant", cdc),
+		)...)
+	feegrantCmd.AddCommand(
+		client.PostCommands(
+			feegrantcmd.GetCmdGrantFeeAllowance(cdc),
+			feegrantcmd.GetCmdRevokeFeeAllowance(cdc),
+		)...)
+	rootCmd.AddCommand(
+		feegrantCmd,
+	)
+
+	//Add authz commands
+	authzCmd := &cobra.Command{
+		Use:   "authz",
+		Short: "Authorization subcommands",
+	}
+	authzCmd.AddCommand(
+		client.GetCommands(
+			authzcmd.GetCmdQueryAuthorization("authz", cdc),
+			authzcmd.GetCmdQueryAuthorizations("authz", cdc),
+		)...)
+	authzCmd.AddCommand(
+		client.PostCommands(
+			authzcmd.GetCmdGrantAuthorization(cdc),
+			authzcmd.GetCmdRevokeAuthorization(cdc),
+			authzcmd.GetCmdExec(cdc),
+		)...)
+	rootCmd.AddCommand(
+		authzCmd,
+	)
+
 	//Add auth and bank commands
 	rootCmd.AddCommand(
 		client.GetCommands(
 			authcmd.GetAccountCmd("acc", cdc, authcmd.GetAccountDecoder(cdc)),
 			authcmd.GetSignCommand(cdc, authcmd.GetAccountDecoder(cdc)),
+			authcmd.GetMultiSignCommand(cdc, authcmd.GetAccountDecoder(cdc)),
 		)...)
 	rootCmd.AddCommand(
 		client.PostCommands(
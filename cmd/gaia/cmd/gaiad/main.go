@@ -17,6 +17,7 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/cmd/gaia/app"
 	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/store"
 )
 
 func main() {
@@ -44,8 +45,12 @@ func main() {
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
 	return app.NewGaiaApp(logger, db, traceStore,
-		baseapp.SetPruning(viper.GetString("pruning")),
+		baseapp.SetPruning(server.PruningStrategy()),
 		baseapp.SetMinimumFees(viper.GetString("minimum_fees")),
+		baseapp.SetMempoolMaxTxs(viper.GetInt("mempool_max_txs")),
+		baseapp.SetSnapshotStore(store.NewLocalSnapshotStore(server.SnapshotDir(viper.GetString(cli.HomeFlag)))),
+		baseapp.SetSnapshotInterval(viper.GetInt64("snapshot_interval")),
+		baseapp.SetStateListeners(server.StateListeners()...),
 	)
 }
 
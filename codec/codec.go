@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 
+	"github.com/cosmos/cosmos-sdk/crypto/multisig"
 	amino "github.com/tendermint/go-amino"
 	"github.com/tendermint/tendermint/crypto/encoding/amino"
 )
@@ -19,6 +20,7 @@ func New() *Codec {
 // Register the go-crypto to the codec
 func RegisterCrypto(cdc *Codec) {
 	cryptoAmino.RegisterAmino(cdc)
+	multisig.RegisterAmino(cdc)
 }
 
 // attempt to make some pretty json
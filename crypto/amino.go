@@ -1,6 +1,7 @@
 package crypto
 
 import (
+	"github.com/cosmos/cosmos-sdk/crypto/multisig"
 	"github.com/tendermint/go-amino"
 	"github.com/tendermint/tendermint/crypto/encoding/amino"
 )
@@ -16,4 +17,5 @@ func init() {
 func RegisterAmino(cdc *amino.Codec) {
 	cdc.RegisterConcrete(PrivKeyLedgerSecp256k1{},
 		"tendermint/PrivKeyLedgerSecp256k1", nil)
+	multisig.RegisterAmino(cdc)
 }
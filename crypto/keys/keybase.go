@@ -157,6 +157,12 @@ func (kb dbKeybase) CreateOffline(name string, pub tmcrypto.PubKey) (Info, error
 	return kb.writeOfflineKey(pub, name), nil
 }
 
+// CreateMulti creates a new reference to a multisig (offline) keypair. It
+// returns the created key info.
+func (kb dbKeybase) CreateMulti(name string, pub tmcrypto.PubKey) (Info, error) {
+	return kb.writeMultisigKey(name, pub), nil
+}
+
 func (kb *dbKeybase) persistDerivedKey(seed []byte, passwd, name, fullHdPath string) (info Info, err error) {
 	// create master key and derive first key:
 	masterPriv, ch := hd.ComputeMastersFromSeed(seed)
@@ -257,6 +263,8 @@ func (kb dbKeybase) Sign(name, passphrase string, msg []byte) (sig []byte, pub t
 		}
 		cdc.MustUnmarshalBinary([]byte(signed), sig)
 		return sig, linfo.GetPubKey(), nil
+	case multiInfo:
+		return nil, nil, fmt.Errorf("cannot sign with a multisig key, sign with its keys and combine the signatures")
 	}
 	sig, err = priv.Sign(msg)
 	if err != nil {
@@ -368,7 +376,7 @@ func (kb dbKeybase) Delete(name, passphrase string) error {
 		kb.db.DeleteSync(infoKey(name))
 		return nil
 	case ledgerInfo:
-	case offlineInfo:
+	case offlineInfo, multiInfo:
 		if passphrase != "yes" {
 			return fmt.Errorf("enter 'yes' exactly to delete the key - this cannot be undone")
 		}
@@ -431,6 +439,12 @@ func (kb dbKeybase) writeOfflineKey(pub tmcrypto.PubKey, name string) Info {
 	return info
 }
 
+func (kb dbKeybase) writeMultisigKey(name string, pub tmcrypto.PubKey) Info {
+	info := newMultiInfo(name, pub)
+	kb.writeInfo(info, name)
+	return info
+}
+
 func (kb dbKeybase) writeInfo(info Info, name string) {
 	// write the info by key
 	key := infoKey(name)
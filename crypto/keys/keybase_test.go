@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/cosmos/cosmos-sdk/crypto/keys/hd"
+	"github.com/cosmos/cosmos-sdk/crypto/multisig"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/tendermint/tendermint/crypto"
@@ -101,6 +102,29 @@ func TestKeyManagement(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, 1, len(keyS))
 
+	// create a multisig key from the offline key and a new one
+	m1 := "multi"
+	pub2 := ed25519.GenPrivKey().PubKey()
+	multi := multisig.NewPubKeyMultisigThreshold(1, []crypto.PubKey{pub1, pub2})
+	i, err = cstore.CreateMulti(m1, multi)
+	require.Nil(t, err)
+	require.Equal(t, multi, i.GetPubKey())
+	require.Equal(t, TypeMulti, i.GetType())
+	i, err = cstore.GetByAddress(types.AccAddress(multi.Address()))
+	require.NoError(t, err)
+	require.Equal(t, m1, i.GetName())
+	_, _, err = cstore.Sign(m1, "", []byte("msg"))
+	require.NotNil(t, err)
+
+	// delete the multisig key
+	err = cstore.Delete(m1, "no")
+	require.NotNil(t, err)
+	err = cstore.Delete(m1, "yes")
+	require.NoError(t, err)
+	keyS, err = cstore.List()
+	require.NoError(t, err)
+	require.Equal(t, 1, len(keyS))
+
 	// addr cache gets nuked
 	err = cstore.Delete(n2, p2)
 	require.NoError(t, err)
@@ -34,6 +34,9 @@ type Keybase interface {
 	// Create, store, and return a new offline key reference
 	CreateOffline(name string, pubkey crypto.PubKey) (info Info, err error)
 
+	// Create, store, and return a new multisig key reference
+	CreateMulti(name string, pubkey crypto.PubKey) (info Info, err error)
+
 	// The following operations will *only* work on locally-stored keys
 	Update(name, oldpass string, getNewpass func() (string, error)) error
 	Import(name string, armor string) (err error)
@@ -53,12 +56,14 @@ const (
 	TypeLocal   KeyType = 0
 	TypeLedger  KeyType = 1
 	TypeOffline KeyType = 2
+	TypeMulti   KeyType = 3
 )
 
 var keyTypes = map[KeyType]string{
 	TypeLocal:   "local",
 	TypeLedger:  "ledger",
 	TypeOffline: "offline",
+	TypeMulti:   "multi",
 }
 
 // String implements the stringer interface for KeyType.
@@ -81,6 +86,7 @@ type Info interface {
 var _ Info = &localInfo{}
 var _ Info = &ledgerInfo{}
 var _ Info = &offlineInfo{}
+var _ Info = &multiInfo{}
 
 // localInfo is the public information about a locally stored key
 type localInfo struct {
@@ -173,6 +179,36 @@ func (i offlineInfo) GetAddress() types.AccAddress {
 	return i.PubKey.Address().Bytes()
 }
 
+// multiInfo is the public information about a multisig key, which is a
+// threshold public key over keys which are usually stored elsewhere
+type multiInfo struct {
+	Name   string        `json:"name"`
+	PubKey crypto.PubKey `json:"pubkey"`
+}
+
+func newMultiInfo(name string, pub crypto.PubKey) Info {
+	return &multiInfo{
+		Name:   name,
+		PubKey: pub,
+	}
+}
+
+func (i multiInfo) GetType() KeyType {
+	return TypeMulti
+}
+
+func (i multiInfo) GetName() string {
+	return i.Name
+}
+
+func (i multiInfo) GetPubKey() crypto.PubKey {
+	return i.PubKey
+}
+
+func (i multiInfo) GetAddress() types.AccAddress {
+	return i.PubKey.Address().Bytes()
+}
+
 // encoding info
 func writeInfo(i Info) []byte {
 	return cdc.MustMarshalBinary(i)
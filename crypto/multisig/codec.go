@@ -0,0 +1,23 @@
+package multisig
+
+import (
+	amino "github.com/tendermint/go-amino"
+	cryptoAmino "github.com/tendermint/tendermint/crypto/encoding/amino"
+)
+
+// nolint
+const PubKeyMultisigThresholdAminoRoute = "tendermint/PubKeyMultisigThreshold"
+
+var cdc = amino.NewCodec()
+
+func init() {
+	cryptoAmino.RegisterAmino(cdc)
+	RegisterAmino(cdc)
+}
+
+// RegisterAmino registers the threshold public key in the given (amino) codec,
+// the codec must already have the crypto.PubKey interface registered.
+func RegisterAmino(cdc *amino.Codec) {
+	cdc.RegisterConcrete(PubKeyMultisigThreshold{},
+		PubKeyMultisigThresholdAminoRoute, nil)
+}
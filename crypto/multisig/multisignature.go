@@ -0,0 +1,61 @@
+package multisig
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
+)
+
+// Multisignature is used to represent the signature object used in the
+// threshold public key. It holds a signature for each of the public keys, in
+// the same order, where the public keys which did not sign have an empty
+// signature.
+type Multisignature struct {
+	Sigs [][]byte `json:"sigs"`
+}
+
+// NewMultisig returns a new Multisignature of size n, without any signatures.
+func NewMultisig(n int) *Multisignature {
+	return &Multisignature{Sigs: make([][]byte, n)}
+}
+
+// AddSignature adds the signature of the public key at the given index,
+// replacing any signature already added for it.
+func (mSig *Multisignature) AddSignature(sig []byte, index int) {
+	mSig.Sigs[index] = sig
+}
+
+// AddSignatureFromPubKey adds the signature of the given public key, which
+// must be one of the keys of the threshold public key.
+func (mSig *Multisignature) AddSignatureFromPubKey(sig []byte, pubkey crypto.PubKey, keys []crypto.PubKey) error {
+	for i, key := range keys {
+		if key.Equals(pubkey) {
+			mSig.AddSignature(sig, i)
+			return nil
+		}
+	}
+	return fmt.Errorf("provided key %X is not part of the multisig keys", pubkey.Bytes())
+}
+
+// NumSigned returns the number of public keys which signed.
+func (mSig *Multisignature) NumSigned() int {
+	signed := 0
+	for _, sig := range mSig.Sigs {
+		if len(sig) != 0 {
+			signed++
+		}
+	}
+	return signed
+}
+
+// Marshal the multisignature with amino, this is the format expected by
+// PubKeyMultisigThreshold.VerifyBytes
+func (mSig *Multisignature) Marshal() []byte {
+	return cdc.MustMarshalBinaryBare(mSig)
+}
+
+// UnmarshalMultisignature decodes a multisignature marshalled with Marshal
+func UnmarshalMultisignature(bz []byte) (mSig Multisignature, err error) {
+	err = cdc.UnmarshalBinaryBare(bz, &mSig)
+	return
+}
@@ -0,0 +1,87 @@
+package multisig
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// PubKeyMultisigThreshold implements a K of N threshold public key. A signature
+// is only valid if at least K of the N public keys signed the message.
+type PubKeyMultisigThreshold struct {
+	K       uint            `json:"threshold"`
+	PubKeys []crypto.PubKey `json:"pubkeys"`
+}
+
+var _ crypto.PubKey = PubKeyMultisigThreshold{}
+
+// NewPubKeyMultisigThreshold returns a new K of N threshold public key. It
+// panics if k is not positive or exceeds the number of public keys.
+func NewPubKeyMultisigThreshold(k int, pubkeys []crypto.PubKey) crypto.PubKey {
+	if k <= 0 {
+		panic("threshold k of n multisignature: k <= 0")
+	}
+	if len(pubkeys) < k {
+		panic("threshold k of n multisignature: len(pubkeys) < k")
+	}
+	return PubKeyMultisigThreshold{uint(k), pubkeys}
+}
+
+// VerifyBytes checks that the marshalled Multisignature holds a valid signature
+// of the message by at least K of the public keys.
+func (pk PubKeyMultisigThreshold) VerifyBytes(msg []byte, marshalledSig []byte) bool {
+	var sig Multisignature
+	if err := cdc.UnmarshalBinaryBare(marshalledSig, &sig); err != nil {
+		return false
+	}
+	if len(sig.Sigs) != len(pk.PubKeys) {
+		return false
+	}
+
+	signed := uint(0)
+	for i, subSig := range sig.Sigs {
+		if len(subSig) == 0 {
+			continue
+		}
+		if !pk.PubKeys[i].VerifyBytes(msg, subSig) {
+			return false
+		}
+		signed++
+	}
+	return signed >= pk.K
+}
+
+// Bytes returns the amino encoded version of the threshold public key
+func (pk PubKeyMultisigThreshold) Bytes() []byte {
+	return cdc.MustMarshalBinaryBare(pk)
+}
+
+// Address returns the address of the threshold public key, the truncated hash
+// of its amino encoding
+func (pk PubKeyMultisigThreshold) Address() crypto.Address {
+	return crypto.Address(tmhash.SumTruncated(pk.Bytes()))
+}
+
+// Equals returns whether the other public key is the same threshold public key
+// over the same public keys, in the same order
+func (pk PubKeyMultisigThreshold) Equals(other crypto.PubKey) bool {
+	otherKey, ok := other.(PubKeyMultisigThreshold)
+	if !ok {
+		return false
+	}
+	if pk.K != otherKey.K || len(pk.PubKeys) != len(otherKey.PubKeys) {
+		return false
+	}
+	for i := range pk.PubKeys {
+		if !pk.PubKeys[i].Equals(otherKey.PubKeys[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements the stringer interface
+func (pk PubKeyMultisigThreshold) String() string {
+	return fmt.Sprintf("PubKeyMultisigThreshold{%d of %d}", pk.K, len(pk.PubKeys))
+}
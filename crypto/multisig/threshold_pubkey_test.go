@@ -0,0 +1,82 @@
+package multisig
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/tendermint/crypto"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	"github.com/tendermint/tendermint/crypto/secp256k1"
+)
+
+func generateKeys(n int) ([]crypto.PrivKey, []crypto.PubKey) {
+	privs := make([]crypto.PrivKey, n)
+	pubs := make([]crypto.PubKey, n)
+	for i := 0; i < n; i++ {
+		if i%2 == 0 {
+			privs[i] = secp256k1.GenPrivKey()
+		} else {
+			privs[i] = ed25519.GenPrivKey()
+		}
+		pubs[i] = privs[i].PubKey()
+	}
+	return privs, pubs
+}
+
+func TestThresholdMultisigVerifyBytes(t *testing.T) {
+	msg := []byte("message")
+	privs, pubs := generateKeys(5)
+	pk := NewPubKeyMultisigThreshold(3, pubs)
+
+	mSig := NewMultisig(len(pubs))
+	require.False(t, pk.VerifyBytes(msg, mSig.Marshal()))
+
+	for i := 0; i < 3; i++ {
+		sig, err := privs[i].Sign(msg)
+		require.Nil(t, err)
+		require.Nil(t, mSig.AddSignatureFromPubKey(sig, pubs[i], pubs))
+
+		// only valid once the threshold is reached
+		require.Equal(t, i == 2, pk.VerifyBytes(msg, mSig.Marshal()), "%d", i)
+	}
+	require.Equal(t, 3, mSig.NumSigned())
+
+	// a single invalid signature invalidates the multisignature
+	sig, err := privs[4].Sign([]byte("other message"))
+	require.Nil(t, err)
+	mSig.AddSignature(sig, 4)
+	require.False(t, pk.VerifyBytes(msg, mSig.Marshal()))
+
+	// signatures of keys outside the multisig cannot be added
+	other := ed25519.GenPrivKey()
+	sig, err = other.Sign(msg)
+	require.Nil(t, err)
+	require.NotNil(t, mSig.AddSignatureFromPubKey(sig, other.PubKey(), pubs))
+
+	// the multisignature must hold a signature slot for every key
+	mSig = NewMultisig(len(pubs) - 1)
+	for i := 0; i < 3; i++ {
+		sig, err := privs[i].Sign(msg)
+		require.Nil(t, err)
+		mSig.AddSignature(sig, i)
+	}
+	require.False(t, pk.VerifyBytes(msg, mSig.Marshal()))
+	require.False(t, pk.VerifyBytes(msg, []byte("garbage")))
+}
+
+func TestThresholdMultisigEncoding(t *testing.T) {
+	_, pubs := generateKeys(3)
+	pk := NewPubKeyMultisigThreshold(2, pubs)
+
+	var decoded crypto.PubKey
+	err := cdc.UnmarshalBinaryBare(pk.Bytes(), &decoded)
+	require.Nil(t, err)
+	require.True(t, pk.Equals(decoded))
+	require.Equal(t, pk.Address(), decoded.Address())
+
+	// the threshold and the order of the keys are part of the key
+	require.False(t, pk.Equals(NewPubKeyMultisigThreshold(3, pubs)))
+	require.False(t, pk.Equals(NewPubKeyMultisigThreshold(2, []crypto.PubKey{pubs[1], pubs[0], pubs[2]})))
+	require.Panics(t, func() { NewPubKeyMultisigThreshold(4, pubs) })
+	require.Panics(t, func() { NewPubKeyMultisigThreshold(0, pubs) })
+}
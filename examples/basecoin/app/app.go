@@ -150,6 +150,13 @@ func (app *BasecoinApp) initChainer(ctx sdk.Context, req abci.RequestInitChain)
 		app.accountMapper.SetAccount(ctx, acc)
 	}
 
+	// the light clients of the counterparty chains are only created at genesis
+	err = ibc.InitGenesis(ctx, app.ibcMapper, genesisState.IBCGenesis)
+	if err != nil {
+		// TODO: https://github.com/cosmos/cosmos-sdk/issues/468
+		panic(err)
+	}
+
 	return abci.ResponseInitChain{}
 }
 
@@ -172,7 +179,10 @@ func (app *BasecoinApp) ExportAppStateAndValidators() (appState json.RawMessage,
 
 	app.accountMapper.IterateAccounts(ctx, appendAccountsFn)
 
-	genState := types.GenesisState{Accounts: accounts}
+	genState := types.GenesisState{
+		Accounts:   accounts,
+		IBCGenesis: ibc.WriteGenesis(ctx, app.ibcMapper),
+	}
 	appState, err = codec.MarshalJSONIndent(app.cdc, genState)
 	if err != nil {
 		return nil, nil, err
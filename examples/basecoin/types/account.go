@@ -4,6 +4,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
 )
 
 var _ auth.Account = (*AppAccount)(nil)
@@ -48,7 +49,8 @@ func GetAccountDecoder(cdc *codec.Codec) auth.AccountDecoder {
 
 // GenesisState reflects the genesis state of the application.
 type GenesisState struct {
-	Accounts []*GenesisAccount `json:"accounts"`
+	Accounts   []*GenesisAccount `json:"accounts"`
+	IBCGenesis ibc.GenesisState  `json:"ibc"`
 }
 
 // GenesisAccount reflects a genesis account the application expects in it's
@@ -91,7 +91,7 @@ func NewDemocoinApp(logger log.Logger, db dbm.DB) *DemocoinApp {
 	// Initialize BaseApp.
 	app.SetInitChainer(app.initChainerFn(app.coolKeeper, app.powKeeper))
 	app.MountStoresIAVL(app.capKeyMainStore, app.capKeyAccountStore, app.capKeyPowStore, app.capKeyIBCStore, app.capKeyStakingStore)
-	app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, app.feeCollectionKeeper))
+	app.SetAnteDecorators(auth.NewAnteDecorators(app.accountMapper, app.feeCollectionKeeper)...)
 	err := app.LoadLatestVersion(app.capKeyMainStore)
 	if err != nil {
 		cmn.Exit(err.Error())
@@ -157,6 +157,11 @@ func (app *DemocoinApp) initChainerFn(coolKeeper cool.Keeper, powKeeper pow.Keep
 			//	return sdk.ErrGenesisParse("").TraceCause(err, "")
 		}
 
+		err = ibc.InitGenesis(ctx, app.ibcMapper, genesisState.IBCGenesis)
+		if err != nil {
+			panic(err) // TODO https://github.com/cosmos/cosmos-sdk/issues/468
+		}
+
 		return abci.ResponseInitChain{}
 	}
 }
@@ -181,6 +186,7 @@ func (app *DemocoinApp) ExportAppStateAndValidators() (appState json.RawMessage,
 		Accounts:    accounts,
 		POWGenesis:  pow.WriteGenesis(ctx, app.powKeeper),
 		CoolGenesis: cool.WriteGenesis(ctx, app.coolKeeper),
+		IBCGenesis:  ibc.WriteGenesis(ctx, app.ibcMapper),
 	}
 	appState, err = codec.MarshalJSONIndent(app.cdc, genState)
 	if err != nil {
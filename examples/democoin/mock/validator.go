@@ -58,6 +58,11 @@ func (v Validator) GetBondHeight() int64 {
 	return 0
 }
 
+// Implements sdk.Validator
+func (v Validator) GetCommission() sdk.Dec {
+	return sdk.ZeroDec()
+}
+
 // Implements sdk.Validator
 func (v Validator) GetMoniker() string {
 	return ""
@@ -4,6 +4,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/ibc"
 
 	"github.com/cosmos/cosmos-sdk/examples/democoin/x/cool"
 	"github.com/cosmos/cosmos-sdk/examples/democoin/x/pow"
@@ -52,6 +53,7 @@ type GenesisState struct {
 	Accounts    []*GenesisAccount `json:"accounts"`
 	POWGenesis  pow.Genesis       `json:"pow"`
 	CoolGenesis cool.Genesis      `json:"cool"`
+	IBCGenesis  ibc.GenesisState  `json:"ibc"`
 }
 
 // GenesisAccount doesn't need pubkey or sequence
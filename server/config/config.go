@@ -8,12 +8,21 @@ import (
 
 const (
 	defaultMinimumFees = ""
+	defaultPruning     = "syncable"
 )
 
 // BaseConfig defines the server's basic configuration
 type BaseConfig struct {
 	// Tx minimum fee
 	MinFees string `mapstructure:"minimum_fees"`
+
+	// Pruning strategy: syncable, nothing, everything or custom
+	Pruning string `mapstructure:"pruning"`
+
+	// Values of the custom pruning strategy
+	PruningKeepRecent int64 `mapstructure:"pruning_keep_recent"`
+	PruningKeepEvery  int64 `mapstructure:"pruning_keep_every"`
+	PruningInterval   int64 `mapstructure:"pruning_interval"`
 }
 
 // Config defines the server's top level configuration
@@ -33,8 +42,34 @@ func (c *Config) MinimumFees() sdk.Coins {
 	return fees
 }
 
+// PruningStrategy returns the pruning strategy of the given name, the custom
+// one being made of the configured values.
+func (c *Config) PruningStrategy() (sdk.PruningStrategy, error) {
+	switch c.Pruning {
+	case "syncable":
+		return sdk.PruneSyncable, nil
+	case "nothing":
+		return sdk.PruneNothing, nil
+	case "everything":
+		return sdk.PruneEverything, nil
+	case "custom":
+		pruning := sdk.NewPruningStrategy(c.PruningKeepRecent, c.PruningKeepEvery, c.PruningInterval)
+		return pruning, pruning.Validate()
+	default:
+		return sdk.PruningStrategy{}, fmt.Errorf("invalid pruning strategy: %s", c.Pruning)
+	}
+}
+
 // DefaultConfig returns server's default configuration.
-func DefaultConfig() *Config { return &Config{BaseConfig{MinFees: defaultMinimumFees}} }
+func DefaultConfig() *Config {
+	return &Config{BaseConfig{
+		MinFees:           defaultMinimumFees,
+		Pruning:           defaultPruning,
+		PruningKeepRecent: sdk.PruneSyncable.KeepRecent,
+		PruningKeepEvery:  sdk.PruneSyncable.KeepEvery,
+		PruningInterval:   sdk.PruneSyncable.Interval,
+	}}
+}
 
 //_____________________________________________________________________
 
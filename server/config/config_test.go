@@ -17,3 +17,26 @@ func TestSetMinimumFees(t *testing.T) {
 	cfg.SetMinimumFees(sdk.Coins{sdk.NewCoin("foo", sdk.NewInt(100))})
 	require.Equal(t, "100foo", cfg.MinFees)
 }
+
+func TestPruningStrategy(t *testing.T) {
+	cfg := DefaultConfig()
+	pruning, err := cfg.PruningStrategy()
+	require.Nil(t, err)
+	require.Equal(t, sdk.PruneSyncable, pruning)
+
+	cfg.Pruning = "custom"
+	cfg.PruningKeepRecent = 10
+	cfg.PruningKeepEvery = 0
+	cfg.PruningInterval = 5
+	pruning, err = cfg.PruningStrategy()
+	require.Nil(t, err)
+	require.Equal(t, sdk.NewPruningStrategy(10, 0, 5), pruning)
+
+	cfg.PruningInterval = -1
+	_, err = cfg.PruningStrategy()
+	require.NotNil(t, err)
+
+	cfg.Pruning = "sometimes"
+	_, err = cfg.PruningStrategy()
+	require.NotNil(t, err)
+}
@@ -15,6 +15,15 @@ const defaultConfigTemplate = `# This is a TOML config file.
 
 # Validators reject any tx from the mempool with less than the minimum fee per gas.
 minimum_fees = "{{ .BaseConfig.MinFees }}"
+
+# Pruning strategy of the app state: syncable, nothing, everything or custom.
+# The custom strategy keeps the pruning_keep_recent states before the latest one
+# and every pruning_keep_every-th state (0 keeps none of them), deleting the other
+# states every pruning_interval blocks in the background.
+pruning = "{{ .BaseConfig.Pruning }}"
+pruning_keep_recent = {{ .BaseConfig.PruningKeepRecent }}
+pruning_keep_every = {{ .BaseConfig.PruningKeepEvery }}
+pruning_interval = {{ .BaseConfig.PruningInterval }}
 `
 
 var configTemplate *template.Template
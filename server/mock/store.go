@@ -38,6 +38,22 @@ func (ms multiStore) WithTracingContext(tc sdk.TraceContext) sdk.MultiStore {
 	panic("not implemented")
 }
 
+func (ms multiStore) ListeningEnabled() bool {
+	panic("not implemented")
+}
+
+func (ms multiStore) AddListener(listener sdk.StateListener) {
+	panic("not implemented")
+}
+
+func (ms multiStore) SetInterBlockCache(key sdk.StoreKey, size int) {
+	panic("not implemented")
+}
+
+func (ms multiStore) InterBlockCacheMetrics() map[string]sdk.InterBlockCacheMetrics {
+	panic("not implemented")
+}
+
 func (ms multiStore) WithTracer(w io.Writer) sdk.MultiStore {
 	panic("not implemented")
 }
@@ -74,6 +90,10 @@ func (ms multiStore) LoadVersion(ver int64) error {
 	panic("not implemented")
 }
 
+func (ms multiStore) CacheMultiStoreWithVersion(version int64) (sdk.CacheMultiStore, error) {
+	panic("not implemented")
+}
+
 func (ms multiStore) GetKVStore(key sdk.StoreKey) sdk.KVStore {
 	return ms.kv[key]
 }
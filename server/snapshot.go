@@ -0,0 +1,140 @@
+package server
+
+import (
+	"fmt"
+	"path/filepath"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Snapshotter is implemented by the applications which can snapshot their
+// state into their snapshot store and restore it.
+type Snapshotter interface {
+	LastCommitID() sdk.CommitID
+	CreateSnapshot(height int64) (store.Snapshot, error)
+	ListSnapshots() ([]store.Snapshot, error)
+	RestoreSnapshot(height int64) (store.Snapshot, error)
+}
+
+// SnapshotDir returns the directory of the snapshots of the node.
+func SnapshotDir(home string) string {
+	return filepath.Join(home, "snapshots")
+}
+
+// SnapshotCmd returns the commands managing the snapshots of the app state.
+// The node must be stopped, as the app state is opened by the commands.
+func SnapshotCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Create, list and restore snapshots of the app state",
+	}
+	cmd.AddCommand(
+		snapshotCreateCmd(ctx, appCreator),
+		snapshotListCmd(ctx, appCreator),
+		snapshotRestoreCmd(ctx, appCreator),
+	)
+	return cmd
+}
+
+func snapshotCreateCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "create [height]",
+		Short: "Snapshot the app state at a committed height, the latest one by default",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := loadSnapshotter(ctx, appCreator)
+			if err != nil {
+				return err
+			}
+			height := app.LastCommitID().Version
+			if len(args) == 1 {
+				height, err = strconv.ParseInt(args[0], 10, 64)
+				if err != nil {
+					return err
+				}
+			}
+
+			snapshot, err := app.CreateSnapshot(height)
+			if err != nil {
+				return errors.Errorf("error creating snapshot: %v\n", err)
+			}
+			printSnapshot(snapshot)
+			return nil
+		},
+	}
+}
+
+func snapshotListCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List the snapshots of the app state",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			app, err := loadSnapshotter(ctx, appCreator)
+			if err != nil {
+				return err
+			}
+			snapshots, err := app.ListSnapshots()
+			if err != nil {
+				return err
+			}
+			for _, snapshot := range snapshots {
+				printSnapshot(snapshot)
+			}
+			return nil
+		},
+	}
+}
+
+func snapshotRestoreCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore <height>",
+		Short: "Restore the snapshot at the given height into the empty app state",
+		Long: `Restore the snapshot at the given height into the empty app state.
+Every chunk of the snapshot is verified, and the restored state is verified
+against the app hash printed once the snapshot is restored, which must match the
+app hash of the block at the next height of a trusted chain.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+			app, err := loadSnapshotter(ctx, appCreator)
+			if err != nil {
+				return err
+			}
+
+			snapshot, err := app.RestoreSnapshot(height)
+			if err != nil {
+				return errors.Errorf("error restoring snapshot: %v\n", err)
+			}
+			printSnapshot(snapshot)
+			return nil
+		},
+	}
+}
+
+func loadSnapshotter(ctx *Context, appCreator AppCreator) (Snapshotter, error) {
+	home := viper.GetString("home")
+	app, err := appCreator(home, ctx.Logger, "")
+	if err != nil {
+		return nil, err
+	}
+	snapshotter, ok := app.(Snapshotter)
+	if !ok {
+		return nil, errors.New("the app does not support snapshots")
+	}
+	return snapshotter, nil
+}
+
+func printSnapshot(snapshot store.Snapshot) {
+	fmt.Printf("height: %d\tformat: %d\tchunks: %d\tapp hash: %X\n",
+		snapshot.Height, snapshot.Format, len(snapshot.ChunkHashes), snapshot.CommitID().Hash)
+}
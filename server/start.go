@@ -12,14 +12,24 @@ import (
 	"github.com/tendermint/tendermint/node"
 	pvm "github.com/tendermint/tendermint/privval"
 	"github.com/tendermint/tendermint/proxy"
+
+	"github.com/cosmos/cosmos-sdk/server/config"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 const (
-	flagWithTendermint = "with-tendermint"
-	flagAddress        = "address"
-	flagTraceStore     = "trace-store"
-	flagPruning        = "pruning"
-	flagMinimumFees    = "minimum_fees"
+	flagWithTendermint    = "with-tendermint"
+	flagAddress           = "address"
+	flagTraceStore        = "trace-store"
+	flagPruning           = "pruning"
+	flagPruningKeepRecent = "pruning_keep_recent"
+	flagPruningKeepEvery  = "pruning_keep_every"
+	flagPruningInterval   = "pruning_interval"
+	flagMinimumFees       = "minimum_fees"
+	flagSnapshotInterval  = "snapshot_interval"
+	flagStreamingDir      = "streaming_dir"
+	flagMempoolMaxTxs     = "mempool_max_txs"
 )
 
 // StartCmd runs the service passed in, either stand-alone or in-process with
@@ -45,14 +55,35 @@ func StartCmd(ctx *Context, appCreator AppCreator) *cobra.Command {
 	cmd.Flags().Bool(flagWithTendermint, true, "Run abci app embedded in-process with tendermint")
 	cmd.Flags().String(flagAddress, "tcp://0.0.0.0:26658", "Listen address")
 	cmd.Flags().String(flagTraceStore, "", "Enable KVStore tracing to an output file")
-	cmd.Flags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything")
+	cmd.Flags().String(flagPruning, "syncable", "Pruning strategy: syncable, nothing, everything, custom")
+	cmd.Flags().Int64(flagPruningKeepRecent, sdk.PruneSyncable.KeepRecent, "Number of recent states kept by the custom pruning strategy")
+	cmd.Flags().Int64(flagPruningKeepEvery, sdk.PruneSyncable.KeepEvery, "Keep every given state with the custom pruning strategy, 0 to keep none of them")
+	cmd.Flags().Int64(flagPruningInterval, sdk.PruneSyncable.Interval, "Number of blocks between two runs of the custom pruning strategy")
 	cmd.Flags().String(flagMinimumFees, "", "Minimum fees validator will accept for transactions")
+	cmd.Flags().Int64(flagSnapshotInterval, 0, "Snapshot the app state every given number of blocks, 0 to disable snapshots")
+	cmd.Flags().String(flagStreamingDir, "", "Write the changes of the app state of each block into a file of the directory")
+	cmd.Flags().Int(flagMempoolMaxTxs, 0, "Number of admitted txs from which the mempool only admits txs of a higher priority than the lowest one, 0 to disable")
 
 	// add support for all Tendermint-specific command line options
 	tcmd.AddNodeFlags(cmd)
 	return cmd
 }
 
+// PruningStrategy returns the pruning strategy of the app state set in the
+// config file or by the flags of the start command. It panics if the strategy
+// is invalid.
+func PruningStrategy() sdk.PruningStrategy {
+	conf, err := config.ParseConfig()
+	if err != nil {
+		panic(err)
+	}
+	pruning, err := conf.PruningStrategy()
+	if err != nil {
+		panic(err)
+	}
+	return pruning
+}
+
 func startStandAlone(ctx *Context, appCreator AppCreator) error {
 	addr := viper.GetString(flagAddress)
 	home := viper.GetString("home")
@@ -120,3 +151,13 @@ func startInProcess(ctx *Context, appCreator AppCreator) (*node.Node, error) {
 	tmNode.RunForever()
 	return tmNode, nil
 }
+
+// StateListeners returns the listeners of the changes of the app state set by
+// the flags of the start command.
+func StateListeners() []sdk.StateListener {
+	var listeners []sdk.StateListener
+	if dir := viper.GetString(flagStreamingDir); dir != "" {
+		listeners = append(listeners, store.NewFileStateListener(dir))
+	}
+	return listeners
+}
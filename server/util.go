@@ -150,6 +150,7 @@ func AddCommands(
 		client.LineBreak,
 		tendermintCmd,
 		ExportCmd(ctx, cdc, appExport),
+		SnapshotCmd(ctx, appCreator),
 		client.LineBreak,
 		version.VersionCmd,
 	)
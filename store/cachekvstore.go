@@ -1,12 +1,8 @@
 package store
 
 import (
-	"bytes"
 	"io"
-	"sort"
 	"sync"
-
-	cmn "github.com/tendermint/tendermint/libs/common"
 )
 
 // If value is nil but deleted is false, it means the parent doesn't have the
@@ -18,10 +14,12 @@ type cValue struct {
 }
 
 // cacheKVStore wraps an in-memory cache around an underlying KVStore.
+// The dirty items are also kept sorted by key, for iteration and Write.
 type cacheKVStore struct {
-	mtx    sync.Mutex
-	cache  map[string]cValue
-	parent KVStore
+	mtx         sync.Mutex
+	cache       map[string]cValue
+	sortedCache *sortedTree
+	parent      KVStore
 }
 
 var _ CacheKVStore = (*cacheKVStore)(nil)
@@ -29,8 +27,9 @@ var _ CacheKVStore = (*cacheKVStore)(nil)
 // nolint
 func NewCacheKVStore(parent KVStore) *cacheKVStore {
 	return &cacheKVStore{
-		cache:  make(map[string]cValue),
-		parent: parent,
+		cache:       make(map[string]cValue),
+		sortedCache: newSortedTree(),
+		parent:      parent,
 	}
 }
 
@@ -95,32 +94,25 @@ func (ci *cacheKVStore) Write() {
 	ci.mtx.Lock()
 	defer ci.mtx.Unlock()
 
-	// We need a copy of all of the keys.
-	// Not the best, but probably not a bottleneck depending.
-	keys := make([]string, 0, len(ci.cache))
-	for key, dbValue := range ci.cache {
-		if dbValue.dirty {
-			keys = append(keys, key)
-		}
-	}
-
-	sort.Strings(keys)
-
-	// TODO: Consider allowing usage of Batch, which would allow the write to
-	// at least happen atomically.
-	for _, key := range keys {
-		cacheValue := ci.cache[key]
+	// The dirty items are written in order.
+	// The write does not need to be atomic: the changes only reach the db when
+	// the rootMultiStore commits, which writes all its stores in a batch.
+	iter := newMemIterator(nil, nil, ci.sortedCache.snapshot(), true)
+	for ; iter.Valid(); iter.Next() {
+		key := iter.Key()
+		cacheValue := ci.cache[string(key)]
 		if cacheValue.deleted {
-			ci.parent.Delete([]byte(key))
+			ci.parent.Delete(key)
 		} else if cacheValue.value == nil {
 			// Skip, it already doesn't exist in parent.
 		} else {
-			ci.parent.Set([]byte(key), cacheValue.value)
+			ci.parent.Set(key, cacheValue.value)
 		}
 	}
 
 	// Clear the cache
 	ci.cache = make(map[string]cValue)
+	ci.sortedCache.reset()
 }
 
 //----------------------------------------
@@ -158,35 +150,16 @@ func (ci *cacheKVStore) iterator(start, end []byte, ascending bool) Iterator {
 		parent = ci.parent.ReverseIterator(start, end)
 	}
 
-	items := ci.dirtyItems(ascending)
-	cache = newMemIterator(start, end, items)
+	// The cache iterator iterates over a snapshot of the dirty items, which
+	// is not affected by later writes.
+	ci.mtx.Lock()
+	root := ci.sortedCache.snapshot()
+	ci.mtx.Unlock()
+	cache = newMemIterator(start, end, root, ascending)
 
 	return newCacheMergeIterator(parent, cache, ascending)
 }
 
-// Constructs a slice of dirty items, to use w/ memIterator.
-func (ci *cacheKVStore) dirtyItems(ascending bool) []cmn.KVPair {
-	items := make([]cmn.KVPair, 0, len(ci.cache))
-
-	for key, cacheValue := range ci.cache {
-		if !cacheValue.dirty {
-			continue
-		}
-
-		items = append(items, cmn.KVPair{Key: []byte(key), Value: cacheValue.value})
-	}
-
-	sort.Slice(items, func(i, j int) bool {
-		if ascending {
-			return bytes.Compare(items[i].Key, items[j].Key) < 0
-		}
-
-		return bytes.Compare(items[i].Key, items[j].Key) > 0
-	})
-
-	return items
-}
-
 //----------------------------------------
 // etc
 
@@ -196,11 +169,14 @@ func (ci *cacheKVStore) assertValidKey(key []byte) {
 	}
 }
 
-// Only entrypoint to mutate ci.cache.
+// Only entrypoint to mutate ci.cache and ci.sortedCache.
 func (ci *cacheKVStore) setCacheValue(key, value []byte, deleted bool, dirty bool) {
 	ci.cache[string(key)] = cValue{
 		value:   value,
 		deleted: deleted,
 		dirty:   dirty,
 	}
+	if dirty {
+		ci.sortedCache.set(string(key), value)
+	}
 }
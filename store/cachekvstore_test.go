@@ -490,6 +490,42 @@ func (krc *keyRangeCounter) key() int {
 	return thisKeyRange.start + krc.idx
 }
 
+func TestCacheKVIteratorSnapshot(t *testing.T) {
+	st := newCacheKVStore()
+	for i := 0; i < 10; i++ {
+		st.Set(keyFmt(i), valFmt(i))
+	}
+
+	// writes after the creation of an iterator are not iterated over
+	itr := st.Iterator(nil, nil)
+	ritr := st.ReverseIterator(nil, nil)
+	for i := 0; i < 10; i++ {
+		st.Delete(keyFmt(i))
+		st.Set(keyFmt(i+10), valFmt(i+10))
+	}
+	for i := 0; i < 10; i++ {
+		require.True(t, itr.Valid())
+		require.Equal(t, keyFmt(i), itr.Key())
+		require.Equal(t, valFmt(i), itr.Value())
+		itr.Next()
+
+		require.True(t, ritr.Valid())
+		require.Equal(t, keyFmt(9-i), ritr.Key())
+		ritr.Next()
+	}
+	require.False(t, itr.Valid())
+	require.False(t, ritr.Valid())
+
+	// while new iterators iterate over them
+	itr = st.Iterator(nil, nil)
+	for i := 10; i < 20; i++ {
+		require.True(t, itr.Valid())
+		require.Equal(t, keyFmt(i), itr.Key())
+		itr.Next()
+	}
+	require.False(t, itr.Valid())
+}
+
 //--------------------------------------------------------
 
 func bz(s string) []byte { return []byte(s) }
@@ -515,3 +551,61 @@ func BenchmarkCacheKVStoreGetKeyFound(b *testing.B) {
 		st.Get([]byte{byte((i & 0xFF0000) >> 16), byte((i & 0xFF00) >> 8), byte(i & 0xFF)})
 	}
 }
+
+// BaseApp cache-wraps the deliver state of the block in runTx, and then the
+// ante handler and the messages of each tx. The following benchmarks iterate
+// several times from the top of such chains over the items written below.
+
+func newNestedCacheKVStores(depth, numItems int) []CacheKVStore {
+	stores := []CacheKVStore{newCacheKVStore()}
+	for d := 1; d < depth; d++ {
+		stores = append(stores, NewCacheKVStore(stores[d-1]))
+	}
+	// spread the writes over the chain, most of them in the block cache
+	for i := 0; i < numItems; i++ {
+		d := 0
+		if i%10 == 0 {
+			d = i / 10 % depth
+		}
+		stores[d].Set(keyFmt(i), valFmt(i))
+	}
+	return stores
+}
+
+func benchmarkCacheKVStoreNestedIteration(b *testing.B, depth, numItems int) {
+	stores := newNestedCacheKVStores(depth, numItems)
+	top := stores[depth-1]
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		// each iteration is preceded by a write, as in a tx iterating over a
+		// range it modifies
+		top.Set(keyFmt(i%numItems), valFmt(i))
+		itr := top.Iterator(keyFmt(numItems/4), keyFmt(numItems/4+100))
+		for ; itr.Valid(); itr.Next() {
+			_ = itr.Value()
+		}
+		itr.Close()
+	}
+}
+
+func BenchmarkCacheKVStoreNestedIteration1(b *testing.B) {
+	benchmarkCacheKVStoreNestedIteration(b, 1, 10000)
+}
+
+func BenchmarkCacheKVStoreNestedIteration3(b *testing.B) {
+	benchmarkCacheKVStoreNestedIteration(b, 3, 10000)
+}
+
+func BenchmarkCacheKVStoreNestedIteration5(b *testing.B) {
+	benchmarkCacheKVStoreNestedIteration(b, 5, 10000)
+}
+
+func BenchmarkCacheKVStoreWrite(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		stores := newNestedCacheKVStores(2, 10000)
+		b.StartTimer()
+		stores[1].Write()
+		stores[0].Write()
+	}
+}
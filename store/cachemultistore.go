@@ -2,6 +2,7 @@ package store
 
 import (
 	"io"
+	"sort"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -18,20 +19,42 @@ type cacheMultiStore struct {
 
 	traceWriter  io.Writer
 	traceContext TraceContext
+
+	// The writes are recorded if the rootMultiStore has listeners. rms is set
+	// for the caches of the rootMultiStore, whose writes are recorded as they
+	// happen and handed to the rootMultiStore when written, while the writes
+	// of their caches are recorded when written into them.
+	recorder *changeRecorder
+	rms      *rootMultiStore
 }
 
 var _ CacheMultiStore = cacheMultiStore{}
 
 func newCacheMultiStoreFromRMS(rms *rootMultiStore) cacheMultiStore {
+	stores := make(map[StoreKey]CacheWrapper, len(rms.stores))
+	for key, store := range rms.stores {
+		stores[key] = store
+	}
+	cms := newCacheMultiStore(rms, stores)
+	if rms.ListeningEnabled() {
+		cms.recorder = newChangeRecorder()
+		cms.rms = rms
+	}
+	return cms
+}
+
+// newCacheMultiStore cache-wraps the given stores of a rootMultiStore, which
+// may be the stores at a previous version.
+func newCacheMultiStore(rms *rootMultiStore, stores map[StoreKey]CacheWrapper) cacheMultiStore {
 	cms := cacheMultiStore{
 		db:           NewCacheKVStore(dbStoreAdapter{rms.db}),
-		stores:       make(map[StoreKey]CacheWrap, len(rms.stores)),
+		stores:       make(map[StoreKey]CacheWrap, len(stores)),
 		keysByName:   rms.keysByName,
 		traceWriter:  rms.traceWriter,
 		traceContext: rms.traceContext,
 	}
 
-	for key, store := range rms.stores {
+	for key, store := range stores {
 		if cms.TracingEnabled() {
 			cms.stores[key] = store.CacheWrapWithTrace(cms.traceWriter, cms.traceContext)
 		} else {
@@ -50,11 +73,26 @@ func newCacheMultiStoreFromCMS(cms cacheMultiStore) cacheMultiStore {
 		traceContext: cms.traceContext,
 	}
 
+	// record the writes into a cache of the rootMultiStore, annotated with
+	// the tracing context of this cache, which is copied to be updated on
+	// its own
+	if cms.rms != nil {
+		cms2.recorder = cms.recorder
+		cms2.traceContext = make(TraceContext, len(cms.traceContext))
+		for k, v := range cms.traceContext {
+			cms2.traceContext[k] = v
+		}
+	}
+
 	for key, store := range cms.stores {
+		var parent CacheWrapper = store
+		if cms.rms != nil {
+			parent = newListenKVStore(store.(KVStore), key.Name(), cms2.recorder, cms2.traceContext)
+		}
 		if cms2.TracingEnabled() {
-			cms2.stores[key] = store.CacheWrapWithTrace(cms2.traceWriter, cms2.traceContext)
+			cms2.stores[key] = parent.CacheWrapWithTrace(cms2.traceWriter, cms2.traceContext)
 		} else {
-			cms2.stores[key] = store.CacheWrap()
+			cms2.stores[key] = parent.CacheWrap()
 		}
 	}
 
@@ -95,6 +133,12 @@ func (cms cacheMultiStore) ResetTraceContext() MultiStore {
 	return cms
 }
 
+// ListeningEnabled returns if the writes are recorded for the listeners of
+// the rootMultiStore.
+func (cms cacheMultiStore) ListeningEnabled() bool {
+	return cms.recorder != nil
+}
+
 // Implements Store.
 func (cms cacheMultiStore) GetStoreType() StoreType {
 	return sdk.StoreTypeMulti
@@ -103,8 +147,18 @@ func (cms cacheMultiStore) GetStoreType() StoreType {
 // Implements CacheMultiStore.
 func (cms cacheMultiStore) Write() {
 	cms.db.Write()
-	for _, store := range cms.stores {
-		store.Write()
+	// the stores are written in the order of their names, for the recorded
+	// changes to be deterministic
+	keys := make([]StoreKey, 0, len(cms.stores))
+	for key := range cms.stores {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name() < keys[j].Name() })
+	for _, key := range keys {
+		cms.stores[key].Write()
+	}
+	if cms.rms != nil {
+		cms.rms.recorder.changes = append(cms.rms.recorder.changes, cms.recorder.flush()...)
 	}
 }
 
@@ -130,7 +184,11 @@ func (cms cacheMultiStore) GetStore(key StoreKey) Store {
 
 // Implements MultiStore.
 func (cms cacheMultiStore) GetKVStore(key StoreKey) KVStore {
-	return cms.stores[key].(KVStore)
+	store := cms.stores[key].(KVStore)
+	if cms.rms != nil {
+		store = newListenKVStore(store, key.Name(), cms.recorder, cms.traceContext)
+	}
+	return store
 }
 
 // Implements MultiStore.
@@ -0,0 +1,84 @@
+package store
+
+import (
+	"sync"
+
+	dbm "github.com/tendermint/tendermint/libs/db"
+)
+
+// commitDB defers the batches written by the IAVL stores of a rootMultiStore,
+// so that the rootMultiStore writes the changes of all its stores along with
+// the commit info in a single batch.
+//
+// The deferred writes are not visible to reads until they are written, the
+// IAVL stores only read the nodes they save from the nodes they keep in memory
+// until the rootMultiStore writes the commit.
+type commitDB struct {
+	dbm.DB
+
+	mtx     sync.Mutex
+	pending []commitOp
+}
+
+type commitOp struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+func newCommitDB(db dbm.DB) *commitDB {
+	return &commitDB{DB: db}
+}
+
+// Implements DB.
+// The batch is deferred until writePending when it is written.
+func (cdb *commitDB) NewBatch() dbm.Batch {
+	return &commitBatch{cdb: cdb}
+}
+
+// writePending moves the deferred writes into the batch.
+func (cdb *commitDB) writePending(batch dbm.Batch) {
+	cdb.mtx.Lock()
+	defer cdb.mtx.Unlock()
+
+	for _, op := range cdb.pending {
+		if op.delete {
+			batch.Delete(op.key)
+		} else {
+			batch.Set(op.key, op.value)
+		}
+	}
+	cdb.pending = nil
+}
+
+// commitBatch collects the writes of a batch of a commitDB.
+type commitBatch struct {
+	cdb *commitDB
+	ops []commitOp
+}
+
+var _ dbm.Batch = (*commitBatch)(nil)
+
+// Implements Batch.
+func (b *commitBatch) Set(key, value []byte) {
+	b.ops = append(b.ops, commitOp{key: cp(key), value: cp(value)})
+}
+
+// Implements Batch.
+func (b *commitBatch) Delete(key []byte) {
+	b.ops = append(b.ops, commitOp{key: cp(key), delete: true})
+}
+
+// Implements Batch.
+func (b *commitBatch) Write() {
+	b.cdb.mtx.Lock()
+	defer b.cdb.mtx.Unlock()
+
+	b.cdb.pending = append(b.cdb.pending, b.ops...)
+	b.ops = nil
+}
+
+// Implements Batch.
+func (b *commitBatch) WriteSync() {
+	b.Write()
+}
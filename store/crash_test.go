@@ -0,0 +1,207 @@
+package store
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/tendermint/iavl"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var errCrash = errors.New("crash")
+
+// crashCounter counts down the writes left before a simulated crash, a
+// negative count never crashes.
+type crashCounter struct {
+	left int
+}
+
+func (c *crashCounter) write() {
+	if c.left == 0 {
+		panic(errCrash)
+	}
+	if c.left > 0 {
+		c.left--
+	}
+}
+
+// crashDB simulates a crash of the process after a number of writes: the
+// writes after the crash panic with errCrash and are dropped. The writes of a
+// batch count as one, as a batch is written atomically.
+type crashDB struct {
+	dbm.DB
+	writes *crashCounter
+}
+
+func (db crashDB) Set(key, value []byte) {
+	db.writes.write()
+	db.DB.Set(key, value)
+}
+
+func (db crashDB) SetSync(key, value []byte) {
+	db.writes.write()
+	db.DB.SetSync(key, value)
+}
+
+func (db crashDB) Delete(key []byte) {
+	db.writes.write()
+	db.DB.Delete(key)
+}
+
+func (db crashDB) DeleteSync(key []byte) {
+	db.writes.write()
+	db.DB.DeleteSync(key)
+}
+
+func (db crashDB) NewBatch() dbm.Batch {
+	return crashBatch{db.DB.NewBatch(), db.writes}
+}
+
+type crashBatch struct {
+	dbm.Batch
+	writes *crashCounter
+}
+
+func (b crashBatch) Write() {
+	b.writes.write()
+	b.Batch.Write()
+}
+
+// newCrashMultiStore mounts store1 and store2 with the root db, and store3
+// with its own db, both crashing after the writes counted by writes.
+func newCrashMultiStore(t *testing.T, db, db3 dbm.DB, writes *crashCounter) *rootMultiStore {
+	store := NewCommitMultiStore(crashDB{db, writes})
+	store.MountStoreWithDB(sdk.NewKVStoreKey("store1"), sdk.StoreTypeIAVL, nil)
+	store.MountStoreWithDB(sdk.NewKVStoreKey("store2"), sdk.StoreTypeIAVL, nil)
+	store.MountStoreWithDB(sdk.NewKVStoreKey("store3"), sdk.StoreTypeIAVL, crashDB{db3, writes})
+	store.SetPruning(sdk.NewPruningStrategy(0, 0, 1))
+	require.Nil(t, store.LoadLatestVersion())
+	return store
+}
+
+// commit the next version, with values depending on the seed
+func commitCrashVersion(store *rootMultiStore, seed string) CommitID {
+	version := store.LastCommitID().Version + 1
+	for _, name := range []string{"store1", "store2", "store3"} {
+		kv := store.getStoreByName(name).(KVStore)
+		for i := 0; i < 10; i++ {
+			kv.Set([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("%s-%d-%d", seed, version, i)))
+		}
+		kv.Delete([]byte(fmt.Sprintf("key%d", version)))
+	}
+	commitID := store.Commit()
+	store.waitPruning()
+	return commitID
+}
+
+// commitCrashing commits until the simulated crash, returning whether it
+// crashed.
+func commitCrashing(store *rootMultiStore, seed string) (crashed bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if r != errCrash {
+				panic(r)
+			}
+			crashed = true
+		}
+	}()
+	commitCrashVersion(store, seed)
+	return false
+}
+
+func TestCommitCrashRecovery(t *testing.T) {
+	// the store committing the given seeds without crashing
+	expected := func(seeds ...string) *rootMultiStore {
+		store := newCrashMultiStore(t, dbm.NewMemDB(), dbm.NewMemDB(), &crashCounter{left: -1})
+		for _, seed := range seeds {
+			commitCrashVersion(store, seed)
+		}
+		return store
+	}
+	version1 := expected("a").LastCommitID()
+
+	// The commit of version 2 writes the batch of store3, then the batch of the
+	// root db. Crash before each write.
+	for crashAfter := 0; crashAfter < 2; crashAfter++ {
+		db, db3 := dbm.NewMemDB(), dbm.NewMemDB()
+		writes := &crashCounter{left: -1}
+		store := newCrashMultiStore(t, db, db3, writes)
+		commitCrashVersion(store, "a")
+
+		writes.left = crashAfter
+		require.True(t, commitCrashing(store, "a"))
+
+		// the restarted store is at the last complete commit, version 2 being
+		// rolled back if store3 saved it before the crash
+		writes.left = -1
+		store = newCrashMultiStore(t, db, db3, writes)
+		commitID := store.LastCommitID()
+		require.Equal(t, version1, commitID, "crash after %d writes", crashAfter)
+		for _, name := range []string{"store1", "store2", "store3"} {
+			require.Equal(t, commitID.Version, store.getStoreByName(name).(CommitStore).LastCommitID().Version)
+		}
+
+		// and commits other values than the ones which were rolled back, the
+		// nodes of the pruned versions being released as if it never crashed
+		seeds := []string{"a", "b", "b"}
+		for v := commitID.Version; v < 3; v++ {
+			commitCrashVersion(store, seeds[v])
+		}
+		exp := expected(seeds...)
+		require.Equal(t, exp.LastCommitID(), store.LastCommitID(), "crash after %d writes", crashAfter)
+		for _, name := range []string{"store1", "store2", "store3"} {
+			require.Equal(t, sumKVPairs(exp.getStoreByName(name).(KVStore)), sumKVPairs(store.getStoreByName(name).(KVStore)))
+		}
+	}
+}
+
+func TestCommitDeferredWrites(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	require.Nil(t, store.LoadLatestVersion())
+	store.Commit()
+
+	// nothing is written if the root batch is not
+	writes := &crashCounter{left: 0}
+	store.db = crashDB{db, writes}
+	before := sumDB(db)
+	store.getStoreByName("store1").(KVStore).Set([]byte("key"), []byte("value"))
+	require.Panics(t, func() { store.Commit() })
+	require.Equal(t, before, sumDB(db))
+}
+
+func sumDB(db dbm.DB) map[string]string {
+	kvs := make(map[string]string)
+	iter := db.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		kvs[string(iter.Key())] = string(iter.Value())
+	}
+	return kvs
+}
+
+func TestRollbackIAVLVersions(t *testing.T) {
+	db := dbm.NewMemDB()
+	iavlStore := newIAVLStore(iavl.NewMutableTree(db, cacheSize), 0, 1)
+	for v := 1; v <= 3; v++ {
+		iavlStore.Set([]byte(fmt.Sprintf("key%d", v)), []byte("value"))
+		iavlStore.Set([]byte("key"), []byte(fmt.Sprintf("value%d", v)))
+		iavlStore.Commit()
+	}
+
+	require.False(t, rollbackIAVLVersions(db, 3))
+	require.True(t, rollbackIAVLVersions(db, 1))
+
+	store, err := LoadIAVLStore(db, CommitID{}, sdk.PruneNothing)
+	require.Nil(t, err)
+	require.Equal(t, int64(1), store.LastCommitID().Version)
+	require.Equal(t, map[string]string{"key": "value1", "key1": "value"}, sumKVPairs(store.(KVStore)))
+	require.False(t, db.Has(iavlRootKey(2)))
+	iter := db.Iterator(iavlOrphansKey(1), []byte{iavlOrphanPrefix + 1})
+	require.False(t, iter.Valid())
+	iter.Close()
+}
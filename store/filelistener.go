@@ -0,0 +1,61 @@
+package store
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// fileStateListener writes the change set of each block as JSON into a file
+// named after its height. The files are renamed into place once written, so
+// that a reader never sees a partial change set, and the file of a block
+// replayed after a restart is overwritten.
+type fileStateListener struct {
+	dir string
+}
+
+var _ sdk.StateListener = fileStateListener{}
+
+// NewFileStateListener returns a StateListener writing the change sets into
+// the given local directory, which is created if needed.
+func NewFileStateListener(dir string) sdk.StateListener {
+	return fileStateListener{dir: dir}
+}
+
+// ChangeSetFile returns the file of the change set of a height written by the
+// StateListener of NewFileStateListener.
+func ChangeSetFile(dir string, height int64) string {
+	return filepath.Join(dir, strconv.FormatInt(height, 10)+".json")
+}
+
+// Implements StateListener.
+func (fl fileStateListener) OnCommit(changeSet sdk.ChangeSet) error {
+	bz, err := cdc.MarshalJSON(changeSet)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(fl.dir, 0755)
+	if err != nil {
+		return err
+	}
+	file := ChangeSetFile(fl.dir, changeSet.Height)
+	err = ioutil.WriteFile(file+".tmp", bz, 0644)
+	if err != nil {
+		return err
+	}
+	return os.Rename(file+".tmp", file)
+}
+
+// LoadChangeSet reads the change set of a height written by the StateListener
+// of NewFileStateListener.
+func LoadChangeSet(dir string, height int64) (changeSet sdk.ChangeSet, err error) {
+	bz, err := ioutil.ReadFile(ChangeSetFile(dir, height))
+	if err != nil {
+		return changeSet, err
+	}
+	err = cdc.UnmarshalJSON(bz, &changeSet)
+	return changeSet, err
+}
@@ -56,7 +56,7 @@ func (gi *gasKVStore) Has(key []byte) bool {
 
 // Implements KVStore.
 func (gi *gasKVStore) Delete(key []byte) {
-	// No gas costs for deletion
+	gi.gasMeter.ConsumeGas(gi.gasConfig.DeleteCost, "Delete")
 	gi.parent.Delete(key)
 }
 
@@ -131,6 +131,7 @@ func (g *gasIterator) Valid() bool {
 
 // Implements Iterator.
 func (g *gasIterator) Next() {
+	g.gasMeter.ConsumeGas(g.gasConfig.IterNextCostFlat, "IterNextFlat")
 	g.parent.Next()
 }
 
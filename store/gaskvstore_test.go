@@ -52,6 +52,26 @@ func TestGasKVStoreIterator(t *testing.T) {
 	require.Equal(t, meter.GasConsumed(), sdk.Gas(356))
 }
 
+func TestGasKVStoreBreakdown(t *testing.T) {
+	mem := dbStoreAdapter{dbm.NewMemDB()}
+	meter := sdk.NewGasMeter(1000)
+	config := sdk.DefaultGasConfig()
+	config.DeleteCost = 3
+	config.IterNextCostFlat = 7
+	st := NewGasKVStore(meter, config, mem)
+	st.Set(keyFmt(1), valFmt(1))
+	st.Delete(keyFmt(1))
+	st.Set(keyFmt(2), valFmt(2))
+	iterator := st.Iterator(nil, nil)
+	iterator.Next()
+	require.Equal(t, []sdk.GasConsumption{
+		{Descriptor: "WriteFlat", Gas: 20},
+		{Descriptor: "WritePerByte", Gas: 260},
+		{Descriptor: "Delete", Gas: 3},
+		{Descriptor: "IterNextFlat", Gas: 7},
+	}, meter.GasBreakdown())
+}
+
 func TestGasKVStoreOutOfGasSet(t *testing.T) {
 	mem := dbStoreAdapter{dbm.NewMemDB()}
 	meter := sdk.NewGasMeter(0)
@@ -53,6 +53,13 @@ type iavlStore struct {
 	// By default this value should be set the same across all nodes,
 	// so that nodes can know the waypoints their peers store.
 	storeEvery int64
+
+	// Guards the versions of the tree, which the rootMultiStore deletes in
+	// the background while they are queried.
+	mtx sync.RWMutex
+
+	// Caches the values of the tree across blocks, nil if disabled.
+	cache *interBlockCache
 }
 
 // CONTRACT: tree should be fully loaded.
@@ -68,29 +75,48 @@ func newIAVLStore(tree *iavl.MutableTree, numRecent int64, storeEvery int64) *ia
 
 // Implements Committer.
 func (st *iavlStore) Commit() CommitID {
-	// Save a new version.
+	commitID := st.saveVersion()
+	st.pruneVersions(commitID.Version)
+	return commitID
+}
+
+// saveVersion saves a new version of the tree.
+func (st *iavlStore) saveVersion() CommitID {
 	hash, version, err := st.tree.SaveVersion()
 	if err != nil {
 		// TODO: Do we want to extend Commit to allow returning errors?
 		panic(err)
 	}
 
-	// Release an old version of history, if not a sync waypoint.
+	return CommitID{
+		Version: version,
+		Hash:    hash,
+	}
+}
+
+// pruneVersions releases an old version of history once the given version is
+// saved, if not a sync waypoint.
+func (st *iavlStore) pruneVersions(version int64) {
 	previous := version - 1
 	if st.numRecent < previous {
 		toRelease := previous - st.numRecent
 		if st.storeEvery == 0 || toRelease%st.storeEvery != 0 {
-			err := st.tree.DeleteVersion(toRelease)
-			if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
-				panic(err)
-			}
+			st.deleteVersion(toRelease)
 		}
 	}
+}
 
-	return CommitID{
-		Version: version,
-		Hash:    hash,
+// deleteVersion deletes an old version of the tree, if it still exists, and
+// returns whether it did.
+func (st *iavlStore) deleteVersion(version int64) bool {
+	st.mtx.Lock()
+	defer st.mtx.Unlock()
+
+	err := st.tree.DeleteVersion(version)
+	if err != nil && err.(cmn.Error).Data() != iavl.ErrVersionDoesNotExist {
+		panic(err)
 	}
+	return err == nil
 }
 
 // Implements Committer.
@@ -102,24 +128,36 @@ func (st *iavlStore) LastCommitID() CommitID {
 }
 
 // Implements Committer.
+// The store prunes on every commit when committed on its own, the interval of
+// the pruning only applies to the rootMultiStore.
 func (st *iavlStore) SetPruning(pruning sdk.PruningStrategy) {
-	switch pruning {
-	case sdk.PruneEverything:
-		st.numRecent = 0
-		st.storeEvery = 0
-	case sdk.PruneNothing:
-		st.storeEvery = 1
-	case sdk.PruneSyncable:
-		st.numRecent = 100
-		st.storeEvery = 10000
-	}
+	st.numRecent = pruning.KeepRecent
+	st.storeEvery = pruning.KeepEvery
 }
 
 // VersionExists returns whether or not a given version is stored.
 func (st *iavlStore) VersionExists(version int64) bool {
+	st.mtx.RLock()
+	defer st.mtx.RUnlock()
+
 	return st.tree.VersionExists(version)
 }
 
+// getImmutable returns a read-only store of a saved version of the tree.
+func (st *iavlStore) getImmutable(version int64) (*immutableIAVLStore, error) {
+	st.mtx.RLock()
+	defer st.mtx.RUnlock()
+
+	if !st.tree.VersionExists(version) {
+		return nil, fmt.Errorf("version %d does not exist, it may be pruned", version)
+	}
+	tree, err := st.tree.GetImmutable(version)
+	if err != nil {
+		return nil, err
+	}
+	return &immutableIAVLStore{tree}, nil
+}
+
 // Implements Store.
 func (st *iavlStore) GetStoreType() StoreType {
 	return sdk.StoreTypeIAVL
@@ -135,25 +173,48 @@ func (st *iavlStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap
 	return NewCacheKVStore(NewTraceKVStore(st, w, tc))
 }
 
+// setInterBlockCache sets the cache of the values of the tree, nil disabling
+// the cache.
+func (st *iavlStore) setInterBlockCache(cache *interBlockCache) {
+	st.cache = cache
+}
+
 // Implements KVStore.
 func (st *iavlStore) Set(key, value []byte) {
 	st.tree.Set(key, value)
+	if st.cache != nil {
+		st.cache.set(key, value)
+	}
 }
 
 // Implements KVStore.
 func (st *iavlStore) Get(key []byte) (value []byte) {
+	if st.cache != nil {
+		if value, ok := st.cache.get(key); ok {
+			return value
+		}
+	}
 	_, v := st.tree.Get(key)
+	if st.cache != nil {
+		st.cache.set(key, v)
+	}
 	return v
 }
 
 // Implements KVStore.
 func (st *iavlStore) Has(key []byte) (exists bool) {
+	if st.cache != nil {
+		return st.Get(key) != nil
+	}
 	return st.tree.Has(key)
 }
 
 // Implements KVStore.
 func (st *iavlStore) Delete(key []byte) {
 	st.tree.Remove(key)
+	if st.cache != nil {
+		st.cache.set(key, nil)
+	}
 }
 
 // Implements KVStore
@@ -203,6 +264,9 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 		return sdk.ErrTxDecode(msg).QueryResult()
 	}
 
+	st.mtx.RLock()
+	defer st.mtx.RUnlock()
+
 	tree := st.tree
 
 	// store the height we chose in the response, with 0 being changed to the
@@ -213,7 +277,7 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 	case "/store", "/key": // Get by key
 		key := req.Data // Data holds the key bytes
 		res.Key = key
-		if !st.VersionExists(res.Height) {
+		if !tree.VersionExists(res.Height) {
 			res.Log = cmn.ErrorWrap(iavl.ErrVersionDoesNotExist, "").Error()
 			break
 		}
@@ -224,6 +288,10 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 				break
 			}
 			res.Value = value
+			// an empty tree has no proof, its root hash proves the absence
+			if proof == nil {
+				break
+			}
 			cdc := amino.NewCodec()
 			p, err := cdc.MarshalBinary(proof)
 			if err != nil {
@@ -237,13 +305,32 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 	case "/subspace":
 		subspace := req.Data
 		res.Key = subspace
+		immutable, err := tree.GetImmutable(res.Height)
+		if err != nil {
+			res.Log = err.Error()
+			break
+		}
 		var KVs []KVPair
-		iterator := sdk.KVStorePrefixIterator(st, subspace)
+		iterator := newIAVLIterator(immutable, subspace, sdk.PrefixEndBytes(subspace), true)
 		for ; iterator.Valid(); iterator.Next() {
 			KVs = append(KVs, KVPair{iterator.Key(), iterator.Value()})
 		}
 		iterator.Close()
 		res.Value = cdc.MustMarshalBinary(KVs)
+		if req.Prove {
+			// Besides the leaves of the subspace, the proof covers the leaf
+			// before and the leaf after them, which prove that no other leaf is
+			// within the subspace. The end of the range is left open as the
+			// range proofs of IAVL may stop before the end of the subspace.
+			_, _, proof, err := immutable.GetRangeWithProof(subspace, nil, len(KVs)+2)
+			if err != nil {
+				res.Log = err.Error()
+				break
+			}
+			if proof != nil {
+				res.Proof = cdc.MustMarshalBinary(proof)
+			}
+		}
 	default:
 		msg := fmt.Sprintf("Unexpected Query path: %v", req.Path)
 		return sdk.ErrUnknownRequest(msg).QueryResult()
@@ -253,7 +340,84 @@ func (st *iavlStore) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
 
 //----------------------------------------
 
+var _ KVStore = (*immutableIAVLStore)(nil)
+
+// immutableIAVLStore is a read-only KVStore of a saved version of an IAVL tree,
+// cache-wrapped to query the state at the version.
+type immutableIAVLStore struct {
+	tree *iavl.ImmutableTree
+}
+
+// Implements Store.
+func (st *immutableIAVLStore) GetStoreType() StoreType {
+	return sdk.StoreTypeIAVL
+}
+
+// Implements Store.
+func (st *immutableIAVLStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(st)
+}
+
+// CacheWrapWithTrace implements the Store interface.
+func (st *immutableIAVLStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(st, w, tc))
+}
+
+// Implements KVStore.
+func (st *immutableIAVLStore) Set(key, value []byte) {
+	panic("cannot write to a saved version of an IAVL store")
+}
+
+// Implements KVStore.
+func (st *immutableIAVLStore) Get(key []byte) (value []byte) {
+	_, v := st.tree.Get(key)
+	return v
+}
+
+// Implements KVStore.
+func (st *immutableIAVLStore) Has(key []byte) (exists bool) {
+	return st.tree.Has(key)
+}
+
+// Implements KVStore.
+func (st *immutableIAVLStore) Delete(key []byte) {
+	panic("cannot write to a saved version of an IAVL store")
+}
+
+// Implements KVStore
+func (st *immutableIAVLStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{st, prefix}
+}
+
+// Implements KVStore
+func (st *immutableIAVLStore) Gas(meter GasMeter, config GasConfig) KVStore {
+	return NewGasKVStore(meter, config, st)
+}
+
+// Implements KVStore.
+func (st *immutableIAVLStore) Iterator(start, end []byte) Iterator {
+	return newIAVLIterator(st.tree, start, end, true)
+}
+
+// Implements KVStore.
+func (st *immutableIAVLStore) ReverseIterator(start, end []byte) Iterator {
+	return newIAVLIterator(st.tree, start, end, false)
+}
+
+//----------------------------------------
+
+const (
+	// number of items fetched from the tree by the first lookup of an
+	// iavlIterator, each lookup fetching twice as many up to the maximum
+	iavlIteratorMinBatch = 8
+	iavlIteratorMaxBatch = 1024
+)
+
 // Implements Iterator.
+// The items are fetched from the tree in batches, each batch being looked up
+// from the cursor, the last key fetched. The iteration is synchronous and the
+// iterator holds no resources, so it does not need to be closed, and modifying
+// the tree between two lookups does not break the iteration.
 type iavlIterator struct {
 	// Underlying store
 	tree *iavl.ImmutableTree
@@ -264,64 +428,62 @@ type iavlIterator struct {
 	// Iteration order
 	ascending bool
 
-	// Channel to push iteration values.
-	iterCh chan cmn.KVPair
-
-	// Close this to release goroutine.
-	quitCh chan struct{}
+	// Items fetched by the last lookup, and the current one
+	batch    []cmn.KVPair
+	position int
 
-	// Close this to signal that state is initialized.
-	initCh chan struct{}
+	// The last key fetched, nil before the first lookup
+	cursor []byte
 
-	//----------------------------------------
-	// What follows are mutable state.
-	mtx sync.Mutex
+	// Size of the next lookup
+	batchSize int
 
-	invalid bool   // True once, true forever
-	key     []byte // The current key
-	value   []byte // The current value
+	// True once the tree has no more items in the domain
+	exhausted bool
 }
 
 var _ Iterator = (*iavlIterator)(nil)
 
 // newIAVLIterator will create a new iavlIterator.
-// CONTRACT: Caller must release the iavlIterator, as each one creates a new
-// goroutine.
 func newIAVLIterator(tree *iavl.ImmutableTree, start, end []byte, ascending bool) *iavlIterator {
 	iter := &iavlIterator{
 		tree:      tree,
 		start:     cp(start),
 		end:       cp(end),
 		ascending: ascending,
-		iterCh:    make(chan cmn.KVPair, 0), // Set capacity > 0?
-		quitCh:    make(chan struct{}),
-		initCh:    make(chan struct{}),
+		batchSize: iavlIteratorMinBatch,
 	}
-	go iter.iterateRoutine()
-	go iter.initRoutine()
+	iter.fetch()
 	return iter
 }
 
-// Run this to funnel items from the tree to iterCh.
-func (iter *iavlIterator) iterateRoutine() {
-	iter.tree.IterateRange(
-		iter.start, iter.end, iter.ascending,
-		func(key, value []byte) bool {
-			select {
-			case <-iter.quitCh:
-				return true // done with iteration.
-			case iter.iterCh <- cmn.KVPair{key, value}:
-				return false // yay.
-			}
-		},
-	)
-	close(iter.iterCh) // done.
-}
+// fetch the next batch of items from the cursor
+func (iter *iavlIterator) fetch() {
+	start, end := iter.start, iter.end
+	if iter.cursor != nil {
+		if iter.ascending {
+			// the smallest key after the cursor
+			start = append(cp(iter.cursor), 0)
+		} else {
+			end = iter.cursor
+		}
+	}
+
+	batch := make([]cmn.KVPair, 0, iter.batchSize)
+	iter.tree.IterateRange(start, end, iter.ascending, func(key, value []byte) bool {
+		batch = append(batch, cmn.KVPair{Key: key, Value: value})
+		return len(batch) == iter.batchSize
+	})
 
-// Run this to fetch the first item.
-func (iter *iavlIterator) initRoutine() {
-	iter.receiveNext()
-	close(iter.initCh)
+	iter.exhausted = len(batch) < iter.batchSize
+	if len(batch) > 0 {
+		iter.cursor = batch[len(batch)-1].Key
+	}
+	iter.batch = batch
+	iter.position = 0
+	if iter.batchSize < iavlIteratorMaxBatch {
+		iter.batchSize *= 2
+	}
 }
 
 // Implements Iterator.
@@ -331,87 +493,40 @@ func (iter *iavlIterator) Domain() (start, end []byte) {
 
 // Implements Iterator.
 func (iter *iavlIterator) Valid() bool {
-	iter.waitInit()
-	iter.mtx.Lock()
-
-	validity := !iter.invalid
-	iter.mtx.Unlock()
-	return validity
+	return iter.position < len(iter.batch)
 }
 
 // Implements Iterator.
 func (iter *iavlIterator) Next() {
-	iter.waitInit()
-	iter.mtx.Lock()
-	iter.assertIsValid(true)
+	iter.assertIsValid()
 
-	iter.receiveNext()
-	iter.mtx.Unlock()
+	iter.position++
+	if iter.position == len(iter.batch) && !iter.exhausted {
+		iter.fetch()
+	}
 }
 
 // Implements Iterator.
 func (iter *iavlIterator) Key() []byte {
-	iter.waitInit()
-	iter.mtx.Lock()
-	iter.assertIsValid(true)
-
-	key := iter.key
-	iter.mtx.Unlock()
-	return key
+	iter.assertIsValid()
+	return iter.batch[iter.position].Key
 }
 
 // Implements Iterator.
 func (iter *iavlIterator) Value() []byte {
-	iter.waitInit()
-	iter.mtx.Lock()
-	iter.assertIsValid(true)
-
-	val := iter.value
-	iter.mtx.Unlock()
-	return val
+	iter.assertIsValid()
+	return iter.batch[iter.position].Value
 }
 
 // Implements Iterator.
 func (iter *iavlIterator) Close() {
-	close(iter.quitCh)
-}
-
-//----------------------------------------
-
-func (iter *iavlIterator) setNext(key, value []byte) {
-	iter.assertIsValid(false)
-
-	iter.key = key
-	iter.value = value
+	iter.batch = nil
+	iter.exhausted = true
 }
 
-func (iter *iavlIterator) setInvalid() {
-	iter.assertIsValid(false)
-
-	iter.invalid = true
-}
-
-func (iter *iavlIterator) waitInit() {
-	<-iter.initCh
-}
-
-func (iter *iavlIterator) receiveNext() {
-	kvPair, ok := <-iter.iterCh
-	if ok {
-		iter.setNext(kvPair.Key, kvPair.Value)
-	} else {
-		iter.setInvalid()
-	}
-}
-
-// assertIsValid panics if the iterator is invalid. If unlockMutex is true,
-// it also unlocks the mutex before panicing, to prevent deadlocks in code that
-// recovers from panics
-func (iter *iavlIterator) assertIsValid(unlockMutex bool) {
-	if iter.invalid {
-		if unlockMutex {
-			iter.mtx.Unlock()
-		}
+// assertIsValid panics if the iterator is invalid
+func (iter *iavlIterator) assertIsValid() {
+	if !iter.Valid() {
 		panic("invalid iterator")
 	}
 }
@@ -142,6 +142,50 @@ func TestIAVLIterator(t *testing.T) {
 	require.Equal(t, len(expected), i)
 }
 
+func TestIAVLIteratorBatches(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+
+	// more items than several lookups fetch
+	numItems := 3 * iavlIteratorMaxBatch
+	for i := 0; i < numItems; i++ {
+		iavlStore.Set([]byte(fmt.Sprintf("key%06d", i)), []byte(fmt.Sprintf("value%06d", i)))
+	}
+	nextVersion(iavlStore)
+
+	iter := iavlStore.Iterator(nil, nil)
+	for i := 0; i < numItems; i++ {
+		require.True(t, iter.Valid())
+		require.Equal(t, []byte(fmt.Sprintf("key%06d", i)), iter.Key())
+		require.Equal(t, []byte(fmt.Sprintf("value%06d", i)), iter.Value())
+		iter.Next()
+	}
+	require.False(t, iter.Valid())
+	require.Panics(t, func() { iter.Next() })
+	iter.Close()
+
+	iter = iavlStore.ReverseIterator([]byte("key000010"), []byte("key002010"))
+	for i := 2009; i >= 10; i-- {
+		require.True(t, iter.Valid())
+		require.Equal(t, []byte(fmt.Sprintf("key%06d", i)), iter.Key())
+		iter.Next()
+	}
+	require.False(t, iter.Valid())
+	iter.Close()
+
+	// the iteration resumes from the last key fetched when the tree is modified
+	iter = iavlStore.Iterator(nil, nil)
+	for i := 0; i < iavlIteratorMinBatch; i++ {
+		iavlStore.Delete([]byte(fmt.Sprintf("key%06d", i)))
+		iter.Next()
+	}
+	require.True(t, iter.Valid())
+	require.Equal(t, []byte(fmt.Sprintf("key%06d", iavlIteratorMinBatch)), iter.Key())
+	iter.Close()
+	require.False(t, iter.Valid())
+}
+
 func TestIAVLSubspaceIterator(t *testing.T) {
 	db := dbm.NewMemDB()
 	tree, _ := newTree(t, db)
@@ -432,6 +476,10 @@ func TestIAVLStoreQuery(t *testing.T) {
 	// and for the subspace
 	qres = iavlStore.Query(querySub)
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	require.Equal(t, valExpSubEmpty, qres.Value)
+	querySub.Height = cid.Version
+	qres = iavlStore.Query(querySub)
+	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, valExpSub1, qres.Value)
 
 	// modify
@@ -454,6 +502,7 @@ func TestIAVLStoreQuery(t *testing.T) {
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, v2, qres.Value)
 	// and for the subspace
+	querySub.Height = cid.Version
 	qres = iavlStore.Query(querySub)
 	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
 	require.Equal(t, valExpSub2, qres.Value)
@@ -465,6 +514,52 @@ func TestIAVLStoreQuery(t *testing.T) {
 	require.Equal(t, v1, qres.Value)
 }
 
+func TestIAVLStoreQueryProof(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	iavlStore := newIAVLStore(tree, numRecent, storeEvery)
+
+	// an empty store has no proof
+	cid := iavlStore.Commit()
+	qres := iavlStore.Query(abci.RequestQuery{Path: "/key", Data: []byte("key"), Height: cid.Version, Prove: true})
+	require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+	require.Nil(t, qres.Proof)
+
+	kvs := []KVPair{
+		{[]byte("a"), []byte("1")},
+		{[]byte("key1"), []byte("2")},
+		{[]byte("key1\xff"), []byte("3")},
+		{[]byte("key1\xff\x01"), []byte("4")},
+		{[]byte("key2"), []byte("5")},
+		{[]byte("z"), []byte("6")},
+	}
+	for _, kv := range kvs {
+		iavlStore.Set(kv.Key, kv.Value)
+	}
+	cid = iavlStore.Commit()
+
+	for _, tc := range []struct {
+		subspace []byte
+		expected []KVPair
+	}{
+		{[]byte("key1"), kvs[1:4]},
+		{[]byte("key"), kvs[1:5]},
+		{[]byte("b"), nil},
+		{[]byte("a"), kvs[:1]},
+		{[]byte("z"), kvs[5:]},
+		{[]byte("zz"), nil},
+		{[]byte{}, kvs},
+	} {
+		qres = iavlStore.Query(abci.RequestQuery{Path: "/subspace", Data: tc.subspace, Height: cid.Version, Prove: true})
+		require.Equal(t, uint32(sdk.CodeOK), qres.Code)
+		require.Equal(t, cdc.MustMarshalBinary(tc.expected), qres.Value, "subspace %q", tc.subspace)
+
+		var proof iavl.RangeProof
+		cdc.MustUnmarshalBinary(qres.Proof, &proof)
+		require.Nil(t, VerifySubspaceProof(tc.subspace, tc.expected, cid.Hash, &proof), "subspace %q", tc.subspace)
+	}
+}
+
 func BenchmarkIAVLIteratorNext(b *testing.B) {
 	db := dbm.NewMemDB()
 	treeSize := 1000
@@ -487,3 +582,109 @@ func BenchmarkIAVLIteratorNext(b *testing.B) {
 		}
 	}
 }
+
+// The following benchmarks compare the iavlIterator to goroutineIAVLIterator,
+// the previous iterator which pushed the items of the tree from a goroutine
+// through a channel.
+
+func newBenchmarkIAVLStore(treeSize int) *iavlStore {
+	db := dbm.NewMemDB()
+	tree := iavl.NewMutableTree(db, cacheSize)
+	for i := 0; i < treeSize; i++ {
+		key := cmn.RandBytes(4)
+		value := cmn.RandBytes(50)
+		tree.Set(key, value)
+	}
+	_, _, err := tree.SaveVersion()
+	if err != nil {
+		panic(err)
+	}
+	return newIAVLStore(tree, numRecent, storeEvery)
+}
+
+func benchmarkIAVLIteration(b *testing.B, treeSize int, maxItems int, newIterator func(*iavlStore) Iterator) {
+	iavlStore := newBenchmarkIAVLStore(treeSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		iter := newIterator(iavlStore)
+		for j := 0; j < maxItems && iter.Valid(); j++ {
+			_, _ = iter.Key(), iter.Value()
+			iter.Next()
+		}
+		iter.Close()
+	}
+}
+
+func cursorIAVLIterator(st *iavlStore) Iterator {
+	return newIAVLIterator(st.tree.ImmutableTree, nil, nil, true)
+}
+
+func goroutineIAVLIterator(st *iavlStore) Iterator {
+	return newGoroutineIAVLIterator(st.tree.ImmutableTree, nil, nil, true)
+}
+
+func BenchmarkIAVLIteratorScanCursor(b *testing.B) {
+	benchmarkIAVLIteration(b, 10000, 10000, cursorIAVLIterator)
+}
+
+func BenchmarkIAVLIteratorScanGoroutine(b *testing.B) {
+	benchmarkIAVLIteration(b, 10000, 10000, goroutineIAVLIterator)
+}
+
+func BenchmarkIAVLIteratorFirstCursor(b *testing.B) {
+	benchmarkIAVLIteration(b, 10000, 1, cursorIAVLIterator)
+}
+
+func BenchmarkIAVLIteratorFirstGoroutine(b *testing.B) {
+	benchmarkIAVLIteration(b, 10000, 1, goroutineIAVLIterator)
+}
+
+func BenchmarkIAVLIteratorPageCursor(b *testing.B) {
+	benchmarkIAVLIteration(b, 10000, 100, cursorIAVLIterator)
+}
+
+func BenchmarkIAVLIteratorPageGoroutine(b *testing.B) {
+	benchmarkIAVLIteration(b, 10000, 100, goroutineIAVLIterator)
+}
+
+// goroutineIAVLIterator is the previous design of the iavlIterator, kept for
+// the benchmarks.
+type goroutineIAVLIterator struct {
+	start, end []byte
+	iterCh     chan cmn.KVPair
+	quitCh     chan struct{}
+	valid      bool
+	key, value []byte
+}
+
+func newGoroutineIAVLIterator(tree *iavl.ImmutableTree, start, end []byte, ascending bool) *goroutineIAVLIterator {
+	iter := &goroutineIAVLIterator{
+		start:  start,
+		end:    end,
+		iterCh: make(chan cmn.KVPair),
+		quitCh: make(chan struct{}),
+	}
+	go func() {
+		tree.IterateRange(start, end, ascending, func(key, value []byte) bool {
+			select {
+			case <-iter.quitCh:
+				return true
+			case iter.iterCh <- cmn.KVPair{Key: key, Value: value}:
+				return false
+			}
+		})
+		close(iter.iterCh)
+	}()
+	iter.Next()
+	return iter
+}
+
+func (iter *goroutineIAVLIterator) Domain() ([]byte, []byte) { return iter.start, iter.end }
+func (iter *goroutineIAVLIterator) Valid() bool              { return iter.valid }
+func (iter *goroutineIAVLIterator) Key() []byte              { return iter.key }
+func (iter *goroutineIAVLIterator) Value() []byte            { return iter.value }
+func (iter *goroutineIAVLIterator) Close()                   { close(iter.quitCh) }
+func (iter *goroutineIAVLIterator) Next() {
+	kvPair, ok := <-iter.iterCh
+	iter.key, iter.value, iter.valid = kvPair.Key, kvPair.Value, ok
+}
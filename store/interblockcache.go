@@ -0,0 +1,84 @@
+package store
+
+import (
+	"container/list"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// interBlockCache is a size-bounded cache of the values of a store, including
+// the absence of keys, which survives the commits of the store. The least
+// recently used keys are evicted first. The store updates the cache on each
+// write, so that the cache never serves a stale value.
+type interBlockCache struct {
+	mtx     sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	lru     *list.List // of *interBlockCacheEntry, the most recently used first
+
+	hits   int64
+	misses int64
+}
+
+type interBlockCacheEntry struct {
+	key   string
+	value []byte // nil if the key is absent
+}
+
+func newInterBlockCache(size int) *interBlockCache {
+	if size <= 0 {
+		panic("inter-block cache size must be positive")
+	}
+	return &interBlockCache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+}
+
+// get returns the cached value of a key, nil if the key is cached as absent,
+// and whether the key is cached.
+func (c *interBlockCache) get(key []byte) (value []byte, ok bool) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	elem, ok := c.entries[string(key)]
+	if !ok {
+		c.misses++
+		return nil, false
+	}
+	c.hits++
+	c.lru.MoveToFront(elem)
+	return elem.Value.(*interBlockCacheEntry).value, true
+}
+
+// set caches the value of a key, a nil value caching the absence of the key.
+func (c *interBlockCache) set(key, value []byte) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if elem, ok := c.entries[string(key)]; ok {
+		elem.Value.(*interBlockCacheEntry).value = value
+		c.lru.MoveToFront(elem)
+		return
+	}
+	entry := &interBlockCacheEntry{key: string(key), value: value}
+	c.entries[entry.key] = c.lru.PushFront(entry)
+	if c.lru.Len() > c.size {
+		oldest := c.lru.Remove(c.lru.Back()).(*interBlockCacheEntry)
+		delete(c.entries, oldest.key)
+	}
+}
+
+// metrics returns the hits and misses counted since the cache was created.
+func (c *interBlockCache) metrics() sdk.InterBlockCacheMetrics {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	return sdk.InterBlockCacheMetrics{
+		Hits:   c.hits,
+		Misses: c.misses,
+		Size:   c.lru.Len(),
+	}
+}
@@ -0,0 +1,82 @@
+package store
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestInterBlockCacheEviction(t *testing.T) {
+	cache := newInterBlockCache(2)
+	cache.set([]byte("a"), []byte("1"))
+	cache.set([]byte("b"), nil)
+
+	value, ok := cache.get([]byte("b"))
+	require.True(t, ok)
+	require.Nil(t, value)
+
+	// a was used least recently, the update of b keeps it the most recent
+	cache.set([]byte("b"), []byte("2"))
+	cache.set([]byte("c"), []byte("3"))
+	_, ok = cache.get([]byte("a"))
+	require.False(t, ok)
+	value, ok = cache.get([]byte("b"))
+	require.True(t, ok)
+	require.Equal(t, []byte("2"), value)
+
+	require.Equal(t, sdk.InterBlockCacheMetrics{Hits: 2, Misses: 1, Size: 2}, cache.metrics())
+	require.Panics(t, func() { newInterBlockCache(0) })
+}
+
+func TestMultistoreInterBlockCache(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	key1, key2 := store.keysByName["store1"], store.keysByName["store2"]
+	store.SetInterBlockCache(key1, 10)
+	require.Nil(t, store.LoadLatestVersion())
+	require.Equal(t, map[string]sdk.InterBlockCacheMetrics{"store1": {}}, store.InterBlockCacheMetrics())
+
+	// the block caches read through the inter-block cache, the absence of a
+	// key being cached as well
+	block := store.CacheMultiStore()
+	block.GetKVStore(key1).Set([]byte("a"), []byte("1"))
+	require.Nil(t, block.GetKVStore(key1).Get([]byte("b")))
+	block.Write()
+	store.Commit()
+
+	block = store.CacheMultiStore()
+	require.Equal(t, []byte("1"), block.GetKVStore(key1).Get([]byte("a")))
+	require.False(t, block.GetKVStore(key1).Has([]byte("b")))
+	require.Equal(t, sdk.InterBlockCacheMetrics{Hits: 2, Misses: 1, Size: 2}, store.InterBlockCacheMetrics()["store1"])
+
+	// the writes update the cache
+	block.GetKVStore(key1).Set([]byte("b"), []byte("2"))
+	block.GetKVStore(key1).Delete([]byte("a"))
+	block.Write()
+	store.Commit()
+
+	block = store.CacheMultiStore()
+	require.Equal(t, []byte("2"), block.GetKVStore(key1).Get([]byte("b")))
+	require.Nil(t, block.GetKVStore(key1).Get([]byte("a")))
+	require.Equal(t, sdk.InterBlockCacheMetrics{Hits: 4, Misses: 1, Size: 2}, store.InterBlockCacheMetrics()["store1"])
+
+	// the cached state is the one committed
+	reloaded := newMultiStoreWithMounts(db)
+	require.Nil(t, reloaded.LoadLatestVersion())
+	require.Equal(t, store.LastCommitID(), reloaded.LastCommitID())
+	require.Equal(t, sumKVPairs(store.GetKVStore(key1)), sumKVPairs(reloaded.GetKVStore(reloaded.keysByName["store1"])))
+
+	// the cache of a loaded store can be enabled and disabled
+	store.SetInterBlockCache(key2, 10)
+	require.Len(t, store.InterBlockCacheMetrics(), 2)
+	store.SetInterBlockCache(key1, 0)
+	require.Len(t, store.InterBlockCacheMetrics(), 1)
+	require.Nil(t, store.LoadLatestVersion())
+	require.Equal(t, map[string]sdk.InterBlockCacheMetrics{"store2": {}}, store.InterBlockCacheMetrics())
+
+	require.Panics(t, func() { store.SetInterBlockCache(sdk.NewKVStoreKey("store4"), 10) })
+	require.Panics(t, func() { store.SetInterBlockCache(key1, -1) })
+}
@@ -0,0 +1,111 @@
+package store
+
+import (
+	"io"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// changeRecorder records the changes written into the stores of a
+// cacheMultiStore, for the listeners of the rootMultiStore.
+type changeRecorder struct {
+	changes []sdk.StoreChange
+}
+
+func newChangeRecorder() *changeRecorder {
+	return &changeRecorder{}
+}
+
+// record a change, annotated with the tx hash of the tracing context
+func (cr *changeRecorder) record(storeName string, tc TraceContext, key, value []byte, delete bool) {
+	txHash, _ := tc["txHash"].(string)
+	cr.changes = append(cr.changes, sdk.StoreChange{
+		StoreName: storeName,
+		TxHash:    txHash,
+		Key:       cp(key),
+		Value:     cp(value),
+		Delete:    delete,
+	})
+}
+
+// flush returns the recorded changes and forgets them
+func (cr *changeRecorder) flush() []sdk.StoreChange {
+	changes := cr.changes
+	cr.changes = nil
+	return changes
+}
+
+//----------------------------------------
+
+// listenKVStore records the writes and deletes delegated to its parent
+// KVStore. Implements KVStore.
+type listenKVStore struct {
+	parent    KVStore
+	storeName string
+	recorder  *changeRecorder
+	context   TraceContext
+}
+
+var _ KVStore = (*listenKVStore)(nil)
+
+func newListenKVStore(parent KVStore, storeName string, recorder *changeRecorder, tc TraceContext) *listenKVStore {
+	return &listenKVStore{parent: parent, storeName: storeName, recorder: recorder, context: tc}
+}
+
+// Implements Store.
+func (lkv *listenKVStore) GetStoreType() StoreType {
+	return lkv.parent.GetStoreType()
+}
+
+// Implements KVStore.
+func (lkv *listenKVStore) Get(key []byte) []byte {
+	return lkv.parent.Get(key)
+}
+
+// Implements KVStore.
+func (lkv *listenKVStore) Has(key []byte) bool {
+	return lkv.parent.Has(key)
+}
+
+// Implements KVStore.
+func (lkv *listenKVStore) Set(key []byte, value []byte) {
+	lkv.recorder.record(lkv.storeName, lkv.context, key, value, false)
+	lkv.parent.Set(key, value)
+}
+
+// Implements KVStore.
+func (lkv *listenKVStore) Delete(key []byte) {
+	lkv.recorder.record(lkv.storeName, lkv.context, key, nil, true)
+	lkv.parent.Delete(key)
+}
+
+// Implements KVStore.
+func (lkv *listenKVStore) Prefix(prefix []byte) KVStore {
+	return prefixStore{lkv, prefix}
+}
+
+// Implements KVStore.
+// Unlike TraceKVStore, the writes through the gas store are still recorded.
+func (lkv *listenKVStore) Gas(meter GasMeter, config GasConfig) KVStore {
+	return NewGasKVStore(meter, config, lkv)
+}
+
+// Implements KVStore.
+func (lkv *listenKVStore) Iterator(start, end []byte) Iterator {
+	return lkv.parent.Iterator(start, end)
+}
+
+// Implements KVStore.
+func (lkv *listenKVStore) ReverseIterator(start, end []byte) Iterator {
+	return lkv.parent.ReverseIterator(start, end)
+}
+
+// Implements CacheWrapper.
+func (lkv *listenKVStore) CacheWrap() CacheWrap {
+	return NewCacheKVStore(lkv)
+}
+
+// Implements CacheWrapper.
+func (lkv *listenKVStore) CacheWrapWithTrace(w io.Writer, tc TraceContext) CacheWrap {
+	return NewCacheKVStore(NewTraceKVStore(lkv, w, tc))
+}
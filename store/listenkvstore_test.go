@@ -0,0 +1,114 @@
+package store
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type testStateListener struct {
+	changeSets []sdk.ChangeSet
+	err        error
+}
+
+func (l *testStateListener) OnCommit(changeSet sdk.ChangeSet) error {
+	l.changeSets = append(l.changeSets, changeSet)
+	return l.err
+}
+
+func TestMultistoreListening(t *testing.T) {
+	db := dbm.NewMemDB()
+	store := newMultiStoreWithMounts(db)
+	listener := &testStateListener{}
+	store.AddListener(listener)
+	require.Nil(t, store.LoadLatestVersion())
+	key1, key2 := store.keysByName["store1"], store.keysByName["store2"]
+
+	// the writes into the block cache and the tx caches written into it
+	blockCache := store.CacheMultiStore()
+	require.True(t, blockCache.ListeningEnabled())
+	blockCache.GetKVStore(key1).Set([]byte("a"), []byte("1"))
+
+	txCache := blockCache.CacheMultiStore().WithTracingContext(sdk.TraceContext{"txHash": "AB"}).(CacheMultiStore)
+	require.True(t, txCache.ListeningEnabled())
+	txCache.GetKVStore(key2).Set([]byte("b"), []byte("2"))
+	txCache.GetKVStore(key1).Delete([]byte("a"))
+	txCache.Write()
+
+	failedTxCache := blockCache.CacheMultiStore().WithTracingContext(sdk.TraceContext{"txHash": "CD"}).(CacheMultiStore)
+	failedTxCache.GetKVStore(key2).Set([]byte("c"), []byte("3"))
+
+	blockCache.GetKVStore(key2).Prefix([]byte("p")).Set([]byte("d"), []byte("4"))
+
+	// the writes of a cache not written into the store are dropped
+	checkCache := store.CacheMultiStore()
+	checkCache.GetKVStore(key1).Set([]byte("e"), []byte("5"))
+
+	blockCache.Write()
+	commitID := store.Commit()
+
+	require.Equal(t, []sdk.ChangeSet{{
+		Height: commitID.Version,
+		Changes: []sdk.StoreChange{
+			{StoreName: "store1", Key: []byte("a"), Value: []byte("1")},
+			{StoreName: "store1", TxHash: "AB", Key: []byte("a"), Delete: true},
+			{StoreName: "store2", TxHash: "AB", Key: []byte("b"), Value: []byte("2")},
+			{StoreName: "store2", Key: []byte("pd"), Value: []byte("4")},
+		},
+	}}, listener.changeSets)
+
+	// a failing listener halts the node once the version is persisted
+	listener.err = errors.New("listener failure")
+	store.GetKVStore(key1).Set([]byte("f"), []byte("6"))
+	require.Panics(t, func() { store.Commit() })
+	require.Equal(t, commitID.Version+1, getLatestVersion(db))
+	require.Equal(t, []sdk.StoreChange{{StoreName: "store1", Key: []byte("f"), Value: []byte("6")}},
+		listener.changeSets[1].Changes)
+
+	// the listeners are notified again of the changes once the store is
+	// reloaded, and only once
+	listener.err = nil
+	for i := 0; i < 2; i++ {
+		store = newMultiStoreWithMounts(db)
+		store.AddListener(listener)
+		require.Nil(t, store.LoadLatestVersion())
+	}
+	require.Len(t, listener.changeSets, 3)
+	require.Equal(t, listener.changeSets[1], listener.changeSets[2])
+}
+
+func TestFileStateListener(t *testing.T) {
+	dir, err := ioutil.TempDir("", "changesets")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	listener := NewFileStateListener(dir)
+	changeSet := sdk.ChangeSet{
+		Height: 3,
+		Changes: []sdk.StoreChange{
+			{StoreName: "store1", TxHash: "AB", Key: []byte("a"), Value: []byte("1")},
+			{StoreName: "store2", Key: []byte("b"), Delete: true},
+		},
+	}
+	require.Nil(t, listener.OnCommit(changeSet))
+
+	loaded, err := LoadChangeSet(dir, 3)
+	require.Nil(t, err)
+	require.Equal(t, changeSet, loaded)
+
+	// the change set of a replayed block overwrites the previous one
+	changeSet.Changes = changeSet.Changes[1:]
+	require.Nil(t, listener.OnCommit(changeSet))
+	loaded, err = LoadChangeSet(dir, 3)
+	require.Nil(t, err)
+	require.Equal(t, changeSet, loaded)
+
+	_, err = LoadChangeSet(dir, 4)
+	require.True(t, os.IsNotExist(err))
+}
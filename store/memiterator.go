@@ -2,32 +2,43 @@ package store
 
 import (
 	"bytes"
-
-	cmn "github.com/tendermint/tendermint/libs/common"
-	dbm "github.com/tendermint/tendermint/libs/db"
 )
 
-// Iterates over iterKVCache items.
-// if key is nil, means it was deleted.
+// Iterates over a snapshot of the items of a sortedTree within [start, end).
+// if value is nil, means it was deleted.
 // Implements Iterator.
 type memIterator struct {
 	start, end []byte
-	items      []cmn.KVPair
+	ascending  bool
+
+	// The current node on top, below it the nodes whose subtree on the side
+	// of the iteration is yet to be iterated over
+	stack []*sortedNode
 }
 
-func newMemIterator(start, end []byte, items []cmn.KVPair) *memIterator {
-	itemsInDomain := make([]cmn.KVPair, 0)
-	for _, item := range items {
-		ascending := keyCompare(start, end) < 0
-		if dbm.IsKeyInDomain(item.Key, start, end, !ascending) {
-			itemsInDomain = append(itemsInDomain, item)
-		}
+func newMemIterator(start, end []byte, root *sortedNode, ascending bool) *memIterator {
+	mi := &memIterator{
+		start:     start,
+		end:       end,
+		ascending: ascending,
 	}
-	return &memIterator{
-		start: start,
-		end:   end,
-		items: itemsInDomain,
+
+	// push the path to the first node within the domain
+	for node := root; node != nil; {
+		switch {
+		case ascending && start != nil && node.key < string(start):
+			node = node.right
+		case !ascending && end != nil && node.key >= string(end):
+			node = node.left
+		case ascending:
+			mi.stack = append(mi.stack, node)
+			node = node.left
+		default:
+			mi.stack = append(mi.stack, node)
+			node = node.right
+		}
 	}
+	return mi
 }
 
 func (mi *memIterator) Domain() ([]byte, []byte) {
@@ -35,7 +46,14 @@ func (mi *memIterator) Domain() ([]byte, []byte) {
 }
 
 func (mi *memIterator) Valid() bool {
-	return len(mi.items) > 0
+	if len(mi.stack) == 0 {
+		return false
+	}
+	key := mi.stack[len(mi.stack)-1].key
+	if mi.ascending {
+		return mi.end == nil || key < string(mi.end)
+	}
+	return mi.start == nil || key >= string(mi.start)
 }
 
 func (mi *memIterator) assertValid() {
@@ -46,23 +64,36 @@ func (mi *memIterator) assertValid() {
 
 func (mi *memIterator) Next() {
 	mi.assertValid()
-	mi.items = mi.items[1:]
+	node := mi.stack[len(mi.stack)-1]
+	mi.stack = mi.stack[:len(mi.stack)-1]
+
+	// push the path to the next node within the subtree on the side of the
+	// iteration
+	if mi.ascending {
+		for node = node.right; node != nil; node = node.left {
+			mi.stack = append(mi.stack, node)
+		}
+	} else {
+		for node = node.left; node != nil; node = node.right {
+			mi.stack = append(mi.stack, node)
+		}
+	}
 }
 
 func (mi *memIterator) Key() []byte {
 	mi.assertValid()
-	return mi.items[0].Key
+	return []byte(mi.stack[len(mi.stack)-1].key)
 }
 
 func (mi *memIterator) Value() []byte {
 	mi.assertValid()
-	return mi.items[0].Value
+	return mi.stack[len(mi.stack)-1].value
 }
 
 func (mi *memIterator) Close() {
 	mi.start = nil
 	mi.end = nil
-	mi.items = nil
+	mi.stack = nil
 }
 
 //----------------------------------------
@@ -6,6 +6,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/tendermint/iavl"
 	cmn "github.com/tendermint/tendermint/libs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // MultiStoreProof defines a collection of store proofs in a multi-store
@@ -15,10 +17,13 @@ type MultiStoreProof struct {
 	RangeProof iavl.RangeProof
 }
 
-// buildMultiStoreProof build MultiStoreProof based on iavl proof and storeInfos
+// buildMultiStoreProof build MultiStoreProof based on iavl proof and storeInfos,
+// the iavl proof being empty if the store is empty
 func buildMultiStoreProof(iavlProof []byte, storeName string, storeInfos []storeInfo) []byte {
 	var rangeProof iavl.RangeProof
-	cdc.MustUnmarshalBinary(iavlProof, &rangeProof)
+	if len(iavlProof) != 0 {
+		cdc.MustUnmarshalBinary(iavlProof, &rangeProof)
+	}
 
 	msp := MultiStoreProof{
 		StoreInfos: storeInfos,
@@ -30,17 +35,20 @@ func buildMultiStoreProof(iavlProof []byte, storeName string, storeInfos []store
 	return proof
 }
 
-// VerifyMultiStoreCommitInfo verify multiStoreCommitInfo against appHash
+// VerifyMultiStoreCommitInfo verify multiStoreCommitInfo against appHash.
+// The returned substore commit hash is empty if the substore is empty.
 func VerifyMultiStoreCommitInfo(storeName string, storeInfos []storeInfo, appHash []byte) ([]byte, error) {
 	var substoreCommitHash []byte
 	var height int64
+	found := false
 	for _, storeInfo := range storeInfos {
 		if storeInfo.Name == storeName {
 			substoreCommitHash = storeInfo.Core.CommitID.Hash
 			height = storeInfo.Core.CommitID.Version
+			found = true
 		}
 	}
-	if len(substoreCommitHash) == 0 {
+	if !found {
 		return nil, cmn.NewError("failed to get substore root commit hash by store name")
 	}
 
@@ -56,6 +64,13 @@ func VerifyMultiStoreCommitInfo(storeName string, storeInfos []storeInfo, appHas
 
 // VerifyRangeProof verify iavl RangeProof
 func VerifyRangeProof(key, value []byte, substoreCommitHash []byte, rangeProof *iavl.RangeProof) error {
+	// an empty substore proves the absence of any key
+	if len(substoreCommitHash) == 0 {
+		if len(value) != 0 {
+			return cmn.NewError("failed in existence verification: the substore is empty")
+		}
+		return nil
+	}
 
 	// verify the proof to ensure data integrity.
 	err := rangeProof.Verify(substoreCommitHash)
@@ -80,11 +95,69 @@ func VerifyRangeProof(key, value []byte, substoreCommitHash []byte, rangeProof *
 	return nil
 }
 
+// VerifySubspaceProof verify that the iavl RangeProof proves the key-value
+// pairs are all the pairs whose key has the subspace as prefix
+func VerifySubspaceProof(subspace []byte, kvs []KVPair, substoreCommitHash []byte, rangeProof *iavl.RangeProof) error {
+	// an empty substore proves the absence of any pair
+	if len(substoreCommitHash) == 0 {
+		if len(kvs) != 0 {
+			return cmn.NewError("failed in subspace verification: the substore is empty")
+		}
+		return nil
+	}
+
+	err := rangeProof.Verify(substoreCommitHash)
+	if err != nil {
+		return errors.Wrap(err, "proof root hash doesn't equal to substore commit root hash")
+	}
+
+	// the pairs are all the leaves of the proof within the subspace
+	end := sdk.PrefixEndBytes(subspace)
+	var keys [][]byte
+	for _, key := range rangeProof.Keys() {
+		if bytes.Compare(key, subspace) >= 0 && (end == nil || bytes.Compare(key, end) < 0) {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) != len(kvs) {
+		return cmn.NewError("failed in subspace verification: expected %d pairs, got %d", len(keys), len(kvs))
+	}
+	for i, kv := range kvs {
+		if !bytes.Equal(kv.Key, keys[i]) {
+			return cmn.NewError("failed in subspace verification: unexpected key %X", kv.Key)
+		}
+		err = rangeProof.VerifyItem(kv.Key, kv.Value)
+		if err != nil {
+			return errors.Wrap(err, "failed in existence verification")
+		}
+	}
+
+	// and the leaves of the proof are adjacent to the subspace on both sides,
+	// leaving no other leaf out of the proof
+	leaves := rangeProof.Keys()
+	if bytes.Compare(leaves[0], subspace) > 0 {
+		err = rangeProof.VerifyAbsence(subspace)
+		if err != nil {
+			return errors.Wrap(err, "failed in the verification of the start of the subspace")
+		}
+	}
+	last := leaves[len(leaves)-1]
+	if end == nil || bytes.Compare(last, end) < 0 {
+		err = rangeProof.VerifyAbsence(sdk.InclusiveEndBytes(cp(last)))
+		if err != nil {
+			return errors.Wrap(err, "failed in the verification of the end of the subspace")
+		}
+	}
+
+	return nil
+}
+
 // RequireProof return whether proof is require for the subpath
 func RequireProof(subpath string) bool {
-	// Currently, only when query subpath is "/store" or "/key", will proof be included in response.
-	// If there are some changes about proof building in iavlstore.go, we must change code here to keep consistency with iavlstore.go:212
-	if subpath == "/store" || subpath == "/key" {
+	// Currently, only when query subpath is "/store", "/key" or "/subspace", will proof be included in response.
+	// If there are some changes about proof building in iavlstore.go, we must change code here to keep consistency with iavlStore.Query
+	switch subpath {
+	case "/store", "/key", "/subspace":
 		return true
 	}
 	return false
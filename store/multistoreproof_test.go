@@ -121,3 +121,42 @@ func TestVerifyRangeProof(t *testing.T) {
 	err = VerifyRangeProof(key, val, root, proof)
 	assert.Nil(t, err)
 }
+
+func TestVerifySubspaceProof(t *testing.T) {
+	tree := iavl.NewMutableTree(db.NewMemDB(), 0)
+	for _, key := range []string{"a", "b1", "b2", "b3", "c"} {
+		tree.Set([]byte(key), []byte("value-"+key))
+	}
+	root := tree.WorkingHash()
+
+	subspace := []byte("b")
+	kvs := []KVPair{
+		{[]byte("b1"), []byte("value-b1")},
+		{[]byte("b2"), []byte("value-b2")},
+		{[]byte("b3"), []byte("value-b3")},
+	}
+	_, _, proof, err := tree.GetRangeWithProof(subspace, nil, len(kvs)+2)
+	require.Nil(t, err)
+	require.Nil(t, VerifySubspaceProof(subspace, kvs, root, proof))
+
+	// the pairs must be all the pairs of the subspace, with their values
+	require.NotNil(t, VerifySubspaceProof(subspace, kvs[1:], root, proof))
+	require.NotNil(t, VerifySubspaceProof(subspace, kvs[:2], root, proof))
+	require.NotNil(t, VerifySubspaceProof(subspace, []KVPair{kvs[0], kvs[2], kvs[1]}, root, proof))
+	tampered := []KVPair{kvs[0], {[]byte("b2"), []byte("tampered")}, kvs[2]}
+	require.NotNil(t, VerifySubspaceProof(subspace, tampered, root, proof))
+
+	// and the proof must cover the whole subspace
+	_, _, proof, err = tree.GetRangeWithProof(subspace, nil, 3)
+	require.Nil(t, err)
+	require.NotNil(t, VerifySubspaceProof(subspace, kvs[:2], root, proof))
+	_, _, proof, err = tree.GetRangeWithProof([]byte("b2"), nil, 3)
+	require.Nil(t, err)
+	require.NotNil(t, VerifySubspaceProof(subspace, kvs[1:], root, proof))
+
+	// an empty store proves the absence of any pair
+	require.Nil(t, VerifySubspaceProof(subspace, nil, nil, &iavl.RangeProof{}))
+	require.NotNil(t, VerifySubspaceProof(subspace, kvs, nil, &iavl.RangeProof{}))
+	require.Nil(t, VerifyRangeProof([]byte("b1"), nil, nil, &iavl.RangeProof{}))
+	require.NotNil(t, VerifyRangeProof([]byte("b1"), []byte("value-b1"), nil, &iavl.RangeProof{}))
+}
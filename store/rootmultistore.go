@@ -14,8 +14,9 @@ import (
 )
 
 const (
-	latestVersionKey = "s/latest"
-	commitInfoKeyFmt = "s/%d" // s/<version>
+	latestVersionKey    = "s/latest"
+	commitInfoKeyFmt    = "s/%d"      // s/<version>
+	pendingChangeSetKey = "s/changes" // the change set of the latest version until the listeners are notified
 )
 
 // rootMultiStore is composed of many CommitStores. Name contrasts with
@@ -23,14 +24,20 @@ const (
 // the CommitMultiStore interface.
 type rootMultiStore struct {
 	db           dbm.DB
+	commitDB     *commitDB // defers the writes of the stores mounted with db
 	lastCommitID CommitID
 	pruning      sdk.PruningStrategy
+	pruned       int64         // the versions up to pruned are pruned
+	pruningDone  chan struct{} // closed once the background pruning is done
 	storesParams map[StoreKey]storeParams
 	stores       map[StoreKey]CommitStore
 	keysByName   map[string]StoreKey
 
 	traceWriter  io.Writer
 	traceContext TraceContext
+
+	listeners []sdk.StateListener
+	recorder  *changeRecorder // the changes of the next version
 }
 
 var _ CommitMultiStore = (*rootMultiStore)(nil)
@@ -40,9 +47,12 @@ var _ Queryable = (*rootMultiStore)(nil)
 func NewCommitMultiStore(db dbm.DB) *rootMultiStore {
 	return &rootMultiStore{
 		db:           db,
+		commitDB:     newCommitDB(db),
+		pruning:      sdk.PruneSyncable,
 		storesParams: make(map[StoreKey]storeParams),
 		stores:       make(map[StoreKey]CommitStore),
 		keysByName:   make(map[string]StoreKey),
+		recorder:     newChangeRecorder(),
 	}
 }
 
@@ -70,14 +80,54 @@ func (rs *rootMultiStore) MountStoreWithDB(key StoreKey, typ StoreType, db dbm.D
 	if _, ok := rs.keysByName[key.Name()]; ok {
 		panic(fmt.Sprintf("rootMultiStore duplicate store key name %v", key))
 	}
-	rs.storesParams[key] = storeParams{
+	params := storeParams{
 		key: key,
 		typ: typ,
 		db:  db,
 	}
+	if db != nil {
+		params.commitDB = newCommitDB(db)
+	}
+	rs.storesParams[key] = params
 	rs.keysByName[key.Name()] = key
 }
 
+// Implements CommitMultiStore.
+func (rs *rootMultiStore) SetInterBlockCache(key StoreKey, size int) {
+	params, ok := rs.storesParams[key]
+	if !ok {
+		panic(fmt.Sprintf("rootMultiStore has no store mounted with key %v", key))
+	}
+	if params.typ != sdk.StoreTypeIAVL {
+		panic(fmt.Sprintf("inter-block cache of store %v: only IAVL stores can be cached", key))
+	}
+	if size < 0 {
+		panic(fmt.Sprintf("invalid inter-block cache size %d", size))
+	}
+	params.interBlockCacheSize = size
+	rs.storesParams[key] = params
+
+	// a store already loaded starts caching from now on
+	if store, ok := rs.stores[key].(*iavlStore); ok {
+		var cache *interBlockCache
+		if size > 0 {
+			cache = newInterBlockCache(size)
+		}
+		store.setInterBlockCache(cache)
+	}
+}
+
+// Implements CommitMultiStore.
+func (rs *rootMultiStore) InterBlockCacheMetrics() map[string]sdk.InterBlockCacheMetrics {
+	metrics := make(map[string]sdk.InterBlockCacheMetrics)
+	for key, store := range rs.stores {
+		if st, ok := store.(*iavlStore); ok && st.cache != nil {
+			metrics[key.Name()] = st.cache.metrics()
+		}
+	}
+	return metrics
+}
+
 // Implements CommitMultiStore.
 func (rs *rootMultiStore) GetCommitStore(key StoreKey) CommitStore {
 	return rs.stores[key]
@@ -89,13 +139,38 @@ func (rs *rootMultiStore) GetCommitKVStore(key StoreKey) CommitKVStore {
 }
 
 // Implements CommitMultiStore.
+// The IAVL stores which saved versions after the latest commit info, as a crash
+// in the middle of a commit can leave the stores mounted with their own db, are
+// rolled back to the latest commit info first.
 func (rs *rootMultiStore) LoadLatestVersion() error {
+	rs.waitPruning()
 	ver := getLatestVersion(rs.db)
-	return rs.LoadVersion(ver)
+	for _, storeParams := range rs.storesParams {
+		if storeParams.typ == sdk.StoreTypeIAVL {
+			rollbackIAVLVersions(rs.storeDB(storeParams), ver)
+		}
+	}
+	err := rs.LoadVersion(ver)
+	if err != nil {
+		return err
+	}
+
+	// The listeners are notified of the changes of the latest version again if
+	// they were not notified before the node stopped.
+	if rs.ListeningEnabled() {
+		changeSet, ok := getPendingChangeSet(rs.db)
+		if ok && changeSet.Height == ver {
+			rs.notifyListeners(changeSet)
+		}
+	}
+	return nil
 }
 
 // Implements CommitMultiStore.
 func (rs *rootMultiStore) LoadVersion(ver int64) error {
+	rs.waitPruning()
+	rs.pruned = 0
+	rs.recorder.flush()
 
 	// Special logic for version 0
 	if ver == 0 {
@@ -181,6 +256,29 @@ func (rs *rootMultiStore) ResetTraceContext() MultiStore {
 	return rs
 }
 
+// Implements CommitMultiStore.
+func (rs *rootMultiStore) AddListener(listener sdk.StateListener) {
+	rs.listeners = append(rs.listeners, listener)
+}
+
+// ListeningEnabled returns if the rootMultiStore has listeners.
+func (rs *rootMultiStore) ListeningEnabled() bool {
+	return len(rs.listeners) != 0
+}
+
+// notifyListeners notifies the listeners of the changes of a persisted
+// version, and then deletes the pending change set. It panics if a listener
+// fails, the change set being notified again once the store is reloaded.
+func (rs *rootMultiStore) notifyListeners(changeSet sdk.ChangeSet) {
+	for _, listener := range rs.listeners {
+		err := listener.OnCommit(changeSet)
+		if err != nil {
+			panic(fmt.Sprintf("failed to notify the changes of version %d: %v", changeSet.Height, err))
+		}
+	}
+	rs.db.Delete([]byte(pendingChangeSetKey))
+}
+
 //----------------------------------------
 // +CommitStore
 
@@ -191,16 +289,41 @@ func (rs *rootMultiStore) LastCommitID() CommitID {
 
 // Implements Committer/CommitStore.
 func (rs *rootMultiStore) Commit() CommitID {
+	rs.waitPruning()
 
-	// Commit stores.
 	version := rs.lastCommitID.Version + 1
+	var changeSet sdk.ChangeSet
+	if rs.ListeningEnabled() {
+		changeSet = sdk.ChangeSet{
+			Height:  version,
+			Changes: rs.recorder.flush(),
+		}
+	}
+
+	// Commit stores.
 	commitInfo := commitStores(version, rs.stores)
 
-	// Need to update atomically.
+	// Need to update atomically, the writes of the stores are deferred until
+	// they are written along with the commit info.
 	batch := rs.db.NewBatch()
+	rs.writeStores(batch)
 	setCommitInfo(batch, version, commitInfo)
 	setLatestVersion(batch, version)
+	if rs.ListeningEnabled() {
+		// the listeners are only notified of the persisted changes
+		setPendingChangeSet(batch, changeSet)
+	}
 	batch.Write()
+	if rs.ListeningEnabled() {
+		rs.notifyListeners(changeSet)
+	}
+
+	// Prune the stores every interval once the new version is written, as
+	// the versions they release are pruned according to what the later
+	// versions recorded.
+	if rs.pruning.Prunes() && version%rs.pruning.Interval == 0 {
+		rs.startPruning(version)
+	}
 
 	// Prepare for next version.
 	commitID := CommitID{
@@ -211,6 +334,75 @@ func (rs *rootMultiStore) Commit() CommitID {
 	return commitID
 }
 
+// startPruning deletes in the background the versions of the stores which are
+// released since the previous pruning, one version after the other. Commit and
+// LoadVersion wait for it to finish, while queries of the versions are guarded
+// by the stores.
+func (rs *rootMultiStore) startPruning(latest int64) {
+	var versions []int64
+	for version := rs.pruned + 1; version < latest-rs.pruning.KeepRecent; version++ {
+		if !rs.pruning.Keeps(version, latest) {
+			versions = append(versions, version)
+		}
+		rs.pruned = version
+	}
+	if len(versions) == 0 {
+		return
+	}
+
+	var stores []prunableStore
+	for _, store := range rs.stores {
+		if prunable, ok := store.(prunableStore); ok {
+			stores = append(stores, prunable)
+		}
+	}
+
+	done := make(chan struct{})
+	rs.pruningDone = done
+	go func() {
+		defer close(done)
+		for _, version := range versions {
+			deleted := false
+			for _, store := range stores {
+				if store.deleteVersion(version) {
+					deleted = true
+				}
+			}
+			if !deleted {
+				continue
+			}
+			// the deletion of a version must be written before the next
+			// version is deleted, which reads what it left
+			batch := rs.db.NewBatch()
+			rs.writeStores(batch)
+			batch.Write()
+		}
+	}()
+}
+
+// waitPruning waits for the background pruning to finish, if any runs.
+func (rs *rootMultiStore) waitPruning() {
+	if rs.pruningDone != nil {
+		<-rs.pruningDone
+		rs.pruningDone = nil
+	}
+}
+
+// writeStores writes the deferred writes of the stores. The writes of the
+// stores mounted with the root db are added to the batch, while the stores
+// mounted with their own db are written first in their own batch.
+func (rs *rootMultiStore) writeStores(batch dbm.Batch) {
+	for _, storeParams := range rs.storesParams {
+		if storeParams.commitDB == nil {
+			continue
+		}
+		storeBatch := storeParams.db.NewBatch()
+		storeParams.commitDB.writePending(storeBatch)
+		storeBatch.Write()
+	}
+	rs.commitDB.writePending(batch)
+}
+
 // Implements CacheWrapper/Store/CommitStore.
 func (rs *rootMultiStore) CacheWrap() CacheWrap {
 	return rs.CacheMultiStore().(CacheWrap)
@@ -229,6 +421,35 @@ func (rs *rootMultiStore) CacheMultiStore() CacheMultiStore {
 	return newCacheMultiStoreFromRMS(rs)
 }
 
+// Implements CommitMultiStore.
+// The IAVL stores are cache-wrapped at the version, the other stores are not
+// versioned and are cache-wrapped as they are.
+func (rs *rootMultiStore) CacheMultiStoreWithVersion(version int64) (CacheMultiStore, error) {
+	latest := rs.lastCommitID.Version
+	if version <= 0 || version > latest {
+		return nil, fmt.Errorf("version %d is not committed, the latest version is %d", version, latest)
+	}
+	// the version may be being deleted by the pruning running in the background
+	if version <= rs.pruned && !rs.pruning.Keeps(version, latest) {
+		return nil, fmt.Errorf("version %d is pruned, the latest version is %d", version, latest)
+	}
+
+	stores := make(map[StoreKey]CacheWrapper, len(rs.stores))
+	for key, store := range rs.stores {
+		iavlStore, ok := store.(*iavlStore)
+		if !ok {
+			stores[key] = store
+			continue
+		}
+		immutable, err := iavlStore.getImmutable(version)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load store %s: %v", key.Name(), err)
+		}
+		stores[key] = immutable
+	}
+	return newCacheMultiStore(rs, stores), nil
+}
+
 // Implements MultiStore.
 func (rs *rootMultiStore) GetStore(key StoreKey) Store {
 	return rs.stores[key]
@@ -243,6 +464,9 @@ func (rs *rootMultiStore) GetKVStore(key StoreKey) KVStore {
 	if rs.TracingEnabled() {
 		store = NewTraceKVStore(store, rs.traceWriter, rs.traceContext)
 	}
+	if rs.ListeningEnabled() {
+		store = newListenKVStore(store, key.Name(), rs.recorder, rs.traceContext)
+	}
 
 	return store
 }
@@ -324,12 +548,7 @@ func parsePath(path string) (storeName string, subpath string, err sdk.Error) {
 //----------------------------------------
 
 func (rs *rootMultiStore) loadCommitStoreFromParams(key sdk.StoreKey, id CommitID, params storeParams) (store CommitStore, err error) {
-	var db dbm.DB
-	if params.db != nil {
-		db = dbm.NewPrefixDB(params.db, []byte("s/_/"))
-	} else {
-		db = dbm.NewPrefixDB(rs.db, []byte("s/k:"+params.key.Name()+"/"))
-	}
+	db := rs.commitStoreDB(params)
 	switch params.typ {
 	case sdk.StoreTypeMulti:
 		panic("recursive MultiStores not yet supported")
@@ -337,6 +556,9 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(key sdk.StoreKey, id CommitI
 		// return NewCommitMultiStore(db, id)
 	case sdk.StoreTypeIAVL:
 		store, err = LoadIAVLStore(db, id, rs.pruning)
+		if err == nil && params.interBlockCacheSize > 0 {
+			store.(*iavlStore).setInterBlockCache(newInterBlockCache(params.interBlockCacheSize))
+		}
 		return
 	case sdk.StoreTypeDB:
 		panic("dbm.DB is not a CommitStore")
@@ -353,6 +575,30 @@ func (rs *rootMultiStore) loadCommitStoreFromParams(key sdk.StoreKey, id CommitI
 	}
 }
 
+// storeDB returns the db of a substore, prefixed within the db it is mounted with
+func (rs *rootMultiStore) storeDB(params storeParams) dbm.DB {
+	if params.db != nil {
+		return dbm.NewPrefixDB(params.db, storePrefix(params))
+	}
+	return dbm.NewPrefixDB(rs.db, storePrefix(params))
+}
+
+// commitStoreDB returns the db of a substore whose writes are deferred until
+// the rootMultiStore writes them on commit
+func (rs *rootMultiStore) commitStoreDB(params storeParams) dbm.DB {
+	if params.db != nil {
+		return dbm.NewPrefixDB(params.commitDB, storePrefix(params))
+	}
+	return dbm.NewPrefixDB(rs.commitDB, storePrefix(params))
+}
+
+func storePrefix(params storeParams) []byte {
+	if params.db != nil {
+		return []byte("s/_/")
+	}
+	return []byte("s/k:" + params.key.Name() + "/")
+}
+
 func (rs *rootMultiStore) nameToKey(name string) StoreKey {
 	for key := range rs.storesParams {
 		if key.Name() == name {
@@ -366,9 +612,13 @@ func (rs *rootMultiStore) nameToKey(name string) StoreKey {
 // storeParams
 
 type storeParams struct {
-	key StoreKey
-	db  dbm.DB
-	typ StoreType
+	key      StoreKey
+	db       dbm.DB
+	commitDB *commitDB // defers the writes of the store when mounted with db
+	typ      StoreType
+
+	// number of keys cached across blocks, 0 if not cached
+	interBlockCacheSize int
 }
 
 //----------------------------------------
@@ -454,13 +704,41 @@ func setLatestVersion(batch dbm.Batch, version int64) {
 	batch.Set([]byte(latestVersionKey), latestBytes)
 }
 
+// Gets the change set persisted until the listeners are notified of it.
+func getPendingChangeSet(db dbm.DB) (changeSet sdk.ChangeSet, ok bool) {
+	bz := db.Get([]byte(pendingChangeSetKey))
+	if bz == nil {
+		return changeSet, false
+	}
+	err := cdc.UnmarshalBinary(bz, &changeSet)
+	if err != nil {
+		panic(err)
+	}
+	return changeSet, true
+}
+
+// Set the change set persisted until the listeners are notified of it.
+func setPendingChangeSet(batch dbm.Batch, changeSet sdk.ChangeSet) {
+	bz, err := cdc.MarshalBinary(changeSet)
+	if err != nil {
+		panic(err)
+	}
+	batch.Set([]byte(pendingChangeSetKey), bz)
+}
+
 // Commits each store and returns a new commitInfo.
 func commitStores(version int64, storeMap map[StoreKey]CommitStore) commitInfo {
 	storeInfos := make([]storeInfo, 0, len(storeMap))
 
 	for key, store := range storeMap {
-		// Commit
-		commitID := store.Commit()
+		// Commit, the stores pruned by the rootMultiStore only save the new
+		// version
+		var commitID CommitID
+		if prunable, ok := store.(prunableStore); ok {
+			commitID = prunable.saveVersion()
+		} else {
+			commitID = store.Commit()
+		}
 
 		if store.GetStoreType() == sdk.StoreTypeTransient {
 			continue
@@ -481,6 +759,14 @@ func commitStores(version int64, storeMap map[StoreKey]CommitStore) commitInfo {
 	return ci
 }
 
+// prunableStore is implemented by the CommitStores which delete their old
+// versions apart from saving a new one, so that the rootMultiStore prunes them
+// in the background according to its pruning strategy.
+type prunableStore interface {
+	saveVersion() CommitID
+	deleteVersion(version int64) bool
+}
+
 // Gets commitInfo from disk.
 func getCommitInfo(db dbm.DB, ver int64) (commitInfo, error) {
 
@@ -509,3 +795,35 @@ func setCommitInfo(batch dbm.Batch, version int64, cInfo commitInfo) {
 	cInfoKey := fmt.Sprintf(commitInfoKeyFmt, version)
 	batch.Set([]byte(cInfoKey), cInfoBytes)
 }
+
+// rollbackIAVLVersions deletes the versions of an IAVL tree saved after the
+// given version, which the latest commit info does not include: their roots,
+// and the orphans they recorded, which would otherwise release nodes of the
+// given version once it is pruned. The nodes they created are left unreachable.
+// It returns whether any version was rolled back.
+func rollbackIAVLVersions(db dbm.DB, version int64) bool {
+	var keys [][]byte
+	iter := db.Iterator(iavlRootKey(version+1), []byte{iavlRootPrefix + 1})
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, cp(iter.Key()))
+	}
+	iter.Close()
+	if len(keys) == 0 {
+		return false
+	}
+
+	// The version after the given one records the nodes it orphans as last
+	// living at the given version.
+	iter = db.Iterator(iavlOrphansKey(version), []byte{iavlOrphanPrefix + 1})
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, cp(iter.Key()))
+	}
+	iter.Close()
+
+	batch := db.NewBatch()
+	for _, key := range keys {
+		batch.Delete(key)
+	}
+	batch.Write()
+	return true
+}
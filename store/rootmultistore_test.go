@@ -1,6 +1,7 @@
 package store
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -99,6 +100,53 @@ func TestMultistoreCommitLoad(t *testing.T) {
 	checkStore(t, store, commitID, commitID)
 }
 
+func TestMultistorePruning(t *testing.T) {
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	store.SetPruning(sdk.NewPruningStrategy(2, 3, 4))
+	require.Nil(t, store.LoadLatestVersion())
+
+	s1 := store.getStoreByName("store1").(*iavlStore)
+	for i := 0; i < 13; i++ {
+		s1.Set([]byte("key"), []byte(fmt.Sprintf("value%d", i)))
+		store.Commit()
+	}
+	store.waitPruning()
+
+	// the last pruning ran at version 12, keeping the 2 versions before it
+	// and every 3rd version
+	for version := int64(1); version <= 13; version++ {
+		kept := version >= 10 || version%3 == 0
+		require.Equal(t, kept, s1.VersionExists(version), "version %d", version)
+	}
+}
+
+func TestMultistoreCacheWithVersion(t *testing.T) {
+	store := newMultiStoreWithMounts(dbm.NewMemDB())
+	store.SetPruning(sdk.NewPruningStrategy(1, 0, 1))
+	require.Nil(t, store.LoadLatestVersion())
+
+	key := store.keysByName["store1"]
+	for i := 1; i <= 4; i++ {
+		store.GetKVStore(key).Set([]byte("key"), []byte(fmt.Sprintf("value%d", i)))
+		store.Commit()
+	}
+
+	cacheMS, err := store.CacheMultiStoreWithVersion(3)
+	require.Nil(t, err)
+	kv := cacheMS.GetKVStore(key)
+	require.Equal(t, []byte("value3"), kv.Get([]byte("key")))
+
+	// the saved version cannot be written, even through the cache
+	kv.Set([]byte("key"), []byte("value"))
+	require.Panics(t, func() { cacheMS.Write() })
+
+	// the versions released by the pruning and not committed are not found
+	for _, version := range []int64{0, 1, 2, 5} {
+		_, err = store.CacheMultiStoreWithVersion(version)
+		require.NotNil(t, err, "version %d", version)
+	}
+}
+
 func TestParsePath(t *testing.T) {
 	_, _, err := parsePath("foo")
 	require.Error(t, err)
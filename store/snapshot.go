@@ -0,0 +1,387 @@
+package store
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/tendermint/go-amino"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// SnapshotFormat is the format of the snapshot chunks produced by this
+// version of the store, snapshots of other formats cannot be restored.
+const SnapshotFormat uint32 = 1
+
+// Snapshot describes the state of the rootMultiStore at a committed height.
+// The state is split into chunks, which are persisted apart from the snapshot
+// and verified against their hashes when the snapshot is restored.
+type Snapshot struct {
+	Height      int64       `json:"height"`
+	Format      uint32      `json:"format"`
+	ChunkHashes [][]byte    `json:"chunk_hashes"` // sha256 hash of each chunk
+	StoreInfos  []storeInfo `json:"store_infos"`  // commit info at the height
+}
+
+// CommitID returns the commit id of the rootMultiStore at the snapshot height,
+// the app hash of the restored state.
+func (s Snapshot) CommitID() CommitID {
+	return commitInfo{
+		Version:    s.Height,
+		StoreInfos: s.StoreInfos,
+	}.CommitID()
+}
+
+// Snapshotter is implemented by the multistores which can snapshot their
+// committed state and restore it.
+type Snapshotter interface {
+	// Snapshot exports the state at a committed height, passing chunks of about
+	// chunkSize bytes in order to saveChunk.
+	Snapshot(height int64, chunkSize int, saveChunk func(index uint32, chunk []byte) error) (Snapshot, error)
+
+	// Restore imports a snapshot into an empty store, loading its chunks with
+	// loadChunk, and loads the restored version.
+	Restore(snapshot Snapshot, loadChunk func(index uint32) ([]byte, error)) error
+}
+
+var _ Snapshotter = (*rootMultiStore)(nil)
+
+// A chunk is the amino encoding of a list of snapshot items, each of them a
+// node of the IAVL tree of a store.
+type snapshotChunk struct {
+	Items []snapshotItem
+}
+
+type snapshotItem struct {
+	Store string
+	Node  []byte
+}
+
+// Implements Snapshotter.
+// Each IAVL store is exported as the nodes reachable from its root at the
+// height, stores being sorted by name and nodes in pre-order. Transient stores
+// are not committed, so they are not part of the snapshot.
+func (rs *rootMultiStore) Snapshot(height int64, chunkSize int, saveChunk func(index uint32, chunk []byte) error) (Snapshot, error) {
+	// the height must not be pruned while it is exported
+	rs.waitPruning()
+
+	if height <= 0 || height > rs.lastCommitID.Version {
+		return Snapshot{}, fmt.Errorf("cannot snapshot uncommitted height %d", height)
+	}
+	cInfo, err := getCommitInfo(rs.db, height)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	storeInfos := make([]storeInfo, len(cInfo.StoreInfos))
+	copy(storeInfos, cInfo.StoreInfos)
+	sort.Slice(storeInfos, func(i, j int) bool { return storeInfos[i].Name < storeInfos[j].Name })
+
+	snapshot := Snapshot{
+		Height:     height,
+		Format:     SnapshotFormat,
+		StoreInfos: storeInfos,
+	}
+	var items []snapshotItem
+	var size int
+	flush := func() error {
+		chunk, err := cdc.MarshalBinary(snapshotChunk{Items: items})
+		if err != nil {
+			return err
+		}
+		hash := sha256.Sum256(chunk)
+		err = saveChunk(uint32(len(snapshot.ChunkHashes)), chunk)
+		if err != nil {
+			return err
+		}
+		snapshot.ChunkHashes = append(snapshot.ChunkHashes, hash[:])
+		items, size = nil, 0
+		return nil
+	}
+
+	for _, info := range storeInfos {
+		params, err := rs.snapshotStoreParams(info.Name)
+		if err != nil {
+			return Snapshot{}, err
+		}
+		db := rs.storeDB(params)
+		if !db.Has(iavlRootKey(height)) {
+			return Snapshot{}, fmt.Errorf("version %d of store %s was pruned", height, info.Name)
+		}
+
+		// walk the tree from its root, an empty tree has no nodes
+		var stack [][]byte
+		if root := info.Core.CommitID.Hash; len(root) > 0 {
+			stack = append(stack, root)
+		}
+		for len(stack) > 0 {
+			hash := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+
+			bz := db.Get(iavlNodeKey(hash))
+			if bz == nil {
+				return Snapshot{}, fmt.Errorf("node %X of store %s not found", hash, info.Name)
+			}
+			node, err := decodeIAVLNode(bz)
+			if err != nil {
+				return Snapshot{}, err
+			}
+			if node.height > 0 {
+				stack = append(stack, node.rightHash, node.leftHash)
+			}
+
+			items = append(items, snapshotItem{Store: info.Name, Node: bz})
+			size += len(bz)
+			if size >= chunkSize {
+				if err := flush(); err != nil {
+					return Snapshot{}, err
+				}
+			}
+		}
+	}
+	if len(items) > 0 || len(snapshot.ChunkHashes) == 0 {
+		if err := flush(); err != nil {
+			return Snapshot{}, err
+		}
+	}
+	return snapshot, nil
+}
+
+// Implements Snapshotter.
+// Every node is verified against the hash its parent refers to, so that the
+// restored trees are verified against the commit info of the snapshot. The
+// commit info is written last, a store whose restore failed is not loaded.
+func (rs *rootMultiStore) Restore(snapshot Snapshot, loadChunk func(index uint32) ([]byte, error)) error {
+	if snapshot.Format != SnapshotFormat {
+		return fmt.Errorf("unknown snapshot format %d", snapshot.Format)
+	}
+	if snapshot.Height <= 0 {
+		return fmt.Errorf("invalid snapshot height %d", snapshot.Height)
+	}
+	if getLatestVersion(rs.db) != 0 {
+		return fmt.Errorf("cannot restore a snapshot into a non-empty store")
+	}
+
+	// hashes of the nodes which are yet to be restored, by store
+	pending := make(map[string]map[string]bool, len(snapshot.StoreInfos))
+	dbs := make(map[string]dbm.DB, len(snapshot.StoreInfos))
+	for _, info := range snapshot.StoreInfos {
+		params, err := rs.snapshotStoreParams(info.Name)
+		if err != nil {
+			return err
+		}
+		if _, ok := dbs[info.Name]; ok {
+			return fmt.Errorf("duplicate store %s in snapshot", info.Name)
+		}
+		dbs[info.Name] = rs.storeDB(params)
+		pending[info.Name] = make(map[string]bool)
+		if root := info.Core.CommitID.Hash; len(root) > 0 {
+			pending[info.Name][string(root)] = true
+		}
+	}
+
+	for i, chunkHash := range snapshot.ChunkHashes {
+		chunk, err := loadChunk(uint32(i))
+		if err != nil {
+			return err
+		}
+		hash := sha256.Sum256(chunk)
+		if !bytes.Equal(hash[:], chunkHash) {
+			return fmt.Errorf("chunk %d does not match its hash", i)
+		}
+		var decoded snapshotChunk
+		err = cdc.UnmarshalBinary(chunk, &decoded)
+		if err != nil {
+			return fmt.Errorf("failed to decode chunk %d: %v", i, err)
+		}
+
+		batches := make(map[string]dbm.Batch)
+		for _, item := range decoded.Items {
+			nodes, ok := pending[item.Store]
+			if !ok {
+				return fmt.Errorf("chunk %d has a node of unknown store %s", i, item.Store)
+			}
+			node, err := decodeIAVLNode(item.Node)
+			if err != nil {
+				return fmt.Errorf("chunk %d: %v", i, err)
+			}
+			nodeHash := node.hash()
+			if !nodes[string(nodeHash)] {
+				return fmt.Errorf("chunk %d has an unexpected node %X of store %s", i, nodeHash, item.Store)
+			}
+			delete(nodes, string(nodeHash))
+			if node.height > 0 {
+				nodes[string(node.leftHash)] = true
+				nodes[string(node.rightHash)] = true
+			}
+
+			batch, ok := batches[item.Store]
+			if !ok {
+				batch = dbs[item.Store].NewBatch()
+				batches[item.Store] = batch
+			}
+			batch.Set(iavlNodeKey(nodeHash), item.Node)
+		}
+		for _, batch := range batches {
+			batch.Write()
+		}
+	}
+
+	for _, info := range snapshot.StoreInfos {
+		if n := len(pending[info.Name]); n > 0 {
+			return fmt.Errorf("snapshot is missing %d nodes of store %s", n, info.Name)
+		}
+		root := info.Core.CommitID.Hash
+		if root == nil {
+			root = []byte{}
+		}
+		dbs[info.Name].Set(iavlRootKey(snapshot.Height), root)
+	}
+
+	batch := rs.db.NewBatch()
+	setCommitInfo(batch, snapshot.Height, commitInfo{
+		Version:    snapshot.Height,
+		StoreInfos: snapshot.StoreInfos,
+	})
+	setLatestVersion(batch, snapshot.Height)
+	batch.Write()
+
+	err := rs.LoadVersion(snapshot.Height)
+	if err != nil {
+		return err
+	}
+	for _, info := range snapshot.StoreInfos {
+		commitID := rs.stores[rs.keysByName[info.Name]].LastCommitID()
+		if commitID.Version != info.Core.CommitID.Version || !bytes.Equal(commitID.Hash, info.Core.CommitID.Hash) {
+			return fmt.Errorf("restored store %s has commit id %v, expected %v", info.Name, commitID, info.Core.CommitID)
+		}
+	}
+	return nil
+}
+
+// only IAVL stores can be snapshotted
+func (rs *rootMultiStore) snapshotStoreParams(name string) (storeParams, error) {
+	key, ok := rs.keysByName[name]
+	if !ok {
+		return storeParams{}, fmt.Errorf("store %s is not mounted", name)
+	}
+	params := rs.storesParams[key]
+	if params.typ != sdk.StoreTypeIAVL {
+		return storeParams{}, fmt.Errorf("store %s is not an IAVL store", name)
+	}
+	return params, nil
+}
+
+//----------------------------------------
+// IAVL nodes
+
+// The nodes of an IAVL tree are persisted under "n<hash>" and the root hash of
+// each version under "r<version>", with the version as a big endian int64.
+// The nodes orphaned by a version are recorded under
+// "o<last version><first version><hash>", with the last version they live at.
+// The iavl package does not export the nodes, so they are decoded here in the
+// format they are persisted in, to walk the tree and recompute the node hashes.
+
+const (
+	iavlNodePrefix   = 'n'
+	iavlRootPrefix   = 'r'
+	iavlOrphanPrefix = 'o'
+)
+
+func iavlNodeKey(hash []byte) []byte {
+	return append([]byte{iavlNodePrefix}, hash...)
+}
+
+func iavlRootKey(version int64) []byte {
+	key := make([]byte, 9)
+	key[0] = iavlRootPrefix
+	binary.BigEndian.PutUint64(key[1:], uint64(version))
+	return key
+}
+
+// iavlOrphansKey returns the prefix of the orphans living up to the version
+func iavlOrphansKey(lastVersion int64) []byte {
+	key := make([]byte, 9)
+	key[0] = iavlOrphanPrefix
+	binary.BigEndian.PutUint64(key[1:], uint64(lastVersion))
+	return key
+}
+
+type iavlNode struct {
+	height    int8
+	size      int64
+	version   int64
+	key       []byte
+	value     []byte // leaf nodes only
+	leftHash  []byte // inner nodes only
+	rightHash []byte // inner nodes only
+}
+
+// decode a node persisted as its height, size, version and key, followed by
+// its value for a leaf node, or by the hashes of its children otherwise
+func decodeIAVLNode(bz []byte) (node iavlNode, err error) {
+	var n int
+	node.height, n, err = amino.DecodeInt8(bz)
+	if err != nil {
+		return node, fmt.Errorf("failed to decode node height: %v", err)
+	}
+	bz = bz[n:]
+	node.size, n, err = amino.DecodeVarint(bz)
+	if err != nil {
+		return node, fmt.Errorf("failed to decode node size: %v", err)
+	}
+	bz = bz[n:]
+	node.version, n, err = amino.DecodeVarint(bz)
+	if err != nil {
+		return node, fmt.Errorf("failed to decode node version: %v", err)
+	}
+	bz = bz[n:]
+	node.key, n, err = amino.DecodeByteSlice(bz)
+	if err != nil {
+		return node, fmt.Errorf("failed to decode node key: %v", err)
+	}
+	bz = bz[n:]
+
+	if node.height == 0 {
+		node.value, _, err = amino.DecodeByteSlice(bz)
+		if err != nil {
+			return node, fmt.Errorf("failed to decode node value: %v", err)
+		}
+		return node, nil
+	}
+	node.leftHash, n, err = amino.DecodeByteSlice(bz)
+	if err != nil {
+		return node, fmt.Errorf("failed to decode node left hash: %v", err)
+	}
+	bz = bz[n:]
+	node.rightHash, _, err = amino.DecodeByteSlice(bz)
+	if err != nil {
+		return node, fmt.Errorf("failed to decode node right hash: %v", err)
+	}
+	if len(node.leftHash) == 0 || len(node.rightHash) == 0 {
+		return node, fmt.Errorf("inner node is missing a child hash")
+	}
+	return node, nil
+}
+
+// hash the height, size and version of the node, followed by its key and the
+// hash of its value for a leaf node, or by the hashes of its children otherwise
+func (node iavlNode) hash() []byte {
+	var buf bytes.Buffer
+	// writing to a bytes.Buffer does not error
+	_ = amino.EncodeInt8(&buf, node.height)
+	_ = amino.EncodeVarint(&buf, node.size)
+	_ = amino.EncodeVarint(&buf, node.version)
+	if node.height == 0 {
+		_ = amino.EncodeByteSlice(&buf, node.key)
+		_ = amino.EncodeByteSlice(&buf, tmhash.Sum(node.value))
+	} else {
+		_ = amino.EncodeByteSlice(&buf, node.leftHash)
+		_ = amino.EncodeByteSlice(&buf, node.rightHash)
+	}
+	return tmhash.Sum(buf.Bytes())
+}
@@ -0,0 +1,200 @@
+package store
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	dbm "github.com/tendermint/tendermint/libs/db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func newSnapshotMultiStore(t *testing.T, db dbm.DB) *rootMultiStore {
+	store := newMultiStoreWithMounts(db)
+	store.MountStoreWithDB(sdk.NewTransientStoreKey("transient"), sdk.StoreTypeTransient, nil)
+	require.Nil(t, store.LoadLatestVersion())
+	return store
+}
+
+// commit a few versions, setting and deleting keys in store1 and store2 while
+// store3 stays empty
+func fillSnapshotMultiStore(store *rootMultiStore, versions int) {
+	s1 := store.getStoreByName("store1").(KVStore)
+	s2 := store.getStoreByName("store2").(KVStore)
+	for v := 0; v < versions; v++ {
+		for i := 0; i < 20; i++ {
+			s1.Set([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d-%d", v, i)))
+		}
+		s1.Delete([]byte(fmt.Sprintf("key%d", v)))
+		s2.Set([]byte(fmt.Sprintf("key%d", v)), []byte("value"))
+		store.Commit()
+	}
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	store := newSnapshotMultiStore(t, dbm.NewMemDB())
+	fillSnapshotMultiStore(store, 5)
+	commitID := store.LastCommitID()
+
+	chunks := make(map[uint32][]byte)
+	saveChunk := func(index uint32, chunk []byte) error {
+		chunks[index] = chunk
+		return nil
+	}
+	loadChunk := func(index uint32) ([]byte, error) {
+		return chunks[index], nil
+	}
+
+	// uncommitted heights cannot be snapshotted
+	_, err := store.Snapshot(commitID.Version+1, 100, saveChunk)
+	require.NotNil(t, err)
+
+	snapshot, err := store.Snapshot(commitID.Version, 100, saveChunk)
+	require.Nil(t, err)
+	require.Equal(t, commitID, snapshot.CommitID())
+	require.True(t, len(snapshot.ChunkHashes) > 1)
+	require.Equal(t, len(snapshot.ChunkHashes), len(chunks))
+	require.Len(t, snapshot.StoreInfos, 3)
+
+	// the restored store has the same commit id and contents
+	restored := newSnapshotMultiStore(t, dbm.NewMemDB())
+	require.Nil(t, restored.Restore(snapshot, loadChunk))
+	require.Equal(t, commitID, restored.LastCommitID())
+	for _, name := range []string{"store1", "store2", "store3"} {
+		expected := store.getStoreByName(name).(KVStore)
+		got := restored.getStoreByName(name).(KVStore)
+		require.Equal(t, sumKVPairs(expected), sumKVPairs(got), name)
+	}
+
+	// and commits the same versions afterwards
+	fillSnapshotMultiStore(store, 2)
+	fillSnapshotMultiStore(restored, 2)
+	require.Equal(t, store.LastCommitID(), restored.LastCommitID())
+
+	// a store which is not empty cannot be restored
+	require.NotNil(t, restored.Restore(snapshot, loadChunk))
+}
+
+func TestSnapshotRestoreVerification(t *testing.T) {
+	store := newSnapshotMultiStore(t, dbm.NewMemDB())
+	fillSnapshotMultiStore(store, 3)
+
+	chunks := make(map[uint32][]byte)
+	snapshot, err := store.Snapshot(store.LastCommitID().Version, 100, func(index uint32, chunk []byte) error {
+		chunks[index] = chunk
+		return nil
+	})
+	require.Nil(t, err)
+
+	restore := func(snapshot Snapshot, chunks map[uint32][]byte) error {
+		db := dbm.NewMemDB()
+		err := newSnapshotMultiStore(t, db).Restore(snapshot, func(index uint32) ([]byte, error) {
+			return chunks[index], nil
+		})
+		if err != nil {
+			// the commit info is not written, the store is still empty
+			require.Equal(t, int64(0), getLatestVersion(db))
+		}
+		return err
+	}
+
+	// a corrupted chunk does not match its hash
+	corrupted := make(map[uint32][]byte)
+	for index, chunk := range chunks {
+		corrupted[index] = chunk
+	}
+	corrupted[1] = append([]byte{}, chunks[1]...)
+	corrupted[1][len(corrupted[1])-1] ^= 0xff
+	require.NotNil(t, restore(snapshot, corrupted))
+
+	// a chunk whose hash was replaced as well does not match the tree
+	tampered := snapshot
+	tampered.ChunkHashes = append([][]byte{}, snapshot.ChunkHashes...)
+	tampered.ChunkHashes[1] = sha256Sum(corrupted[1])
+	require.NotNil(t, restore(tampered, corrupted))
+
+	// missing chunks leave nodes unrestored
+	truncated := snapshot
+	truncated.ChunkHashes = snapshot.ChunkHashes[:len(snapshot.ChunkHashes)-1]
+	require.NotNil(t, restore(truncated, chunks))
+
+	// the snapshot must be of a known format
+	unknown := snapshot
+	unknown.Format = SnapshotFormat + 1
+	require.NotNil(t, restore(unknown, chunks))
+
+	require.Nil(t, restore(snapshot, chunks))
+}
+
+func TestSnapshotPrunedVersion(t *testing.T) {
+	store := newSnapshotMultiStore(t, dbm.NewMemDB())
+	store.SetPruning(sdk.NewPruningStrategy(0, 0, 1))
+	fillSnapshotMultiStore(store, 3)
+
+	saveChunk := func(uint32, []byte) error { return nil }
+	_, err := store.Snapshot(2, 100, saveChunk)
+	require.NotNil(t, err)
+	_, err = store.Snapshot(3, 100, saveChunk)
+	require.Nil(t, err)
+}
+
+func TestLocalSnapshotStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "snapshots")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	ss := NewLocalSnapshotStore(dir)
+
+	snapshots, err := ss.List()
+	require.Nil(t, err)
+	require.Empty(t, snapshots)
+
+	store := newSnapshotMultiStore(t, dbm.NewMemDB())
+	store.SetPruning(sdk.PruneNothing)
+	fillSnapshotMultiStore(store, 2)
+	snapshot2, err := SaveSnapshot(store, ss, 2)
+	require.Nil(t, err)
+	_, err = SaveSnapshot(store, ss, 1)
+	require.Nil(t, err)
+
+	// a snapshot without metadata is not listed
+	require.Nil(t, ss.SaveChunk(3, 0, []byte("chunk")))
+
+	snapshots, err = ss.List()
+	require.Nil(t, err)
+	require.Len(t, snapshots, 2)
+	require.Equal(t, int64(2), snapshots[0].Height)
+	require.Equal(t, int64(1), snapshots[1].Height)
+
+	restored := newSnapshotMultiStore(t, dbm.NewMemDB())
+	snapshot, err := RestoreSnapshot(restored, ss, 2)
+	require.Nil(t, err)
+	require.Equal(t, snapshot2, snapshot)
+	require.Equal(t, store.LastCommitID(), restored.LastCommitID())
+
+	_, err = RestoreSnapshot(newSnapshotMultiStore(t, dbm.NewMemDB()), ss, 3)
+	require.NotNil(t, err)
+
+	require.Nil(t, ss.Delete(1))
+	snapshots, err = ss.List()
+	require.Nil(t, err)
+	require.Len(t, snapshots, 1)
+}
+
+func sumKVPairs(store KVStore) map[string]string {
+	kvs := make(map[string]string)
+	iter := store.Iterator(nil, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		kvs[string(iter.Key())] = string(iter.Value())
+	}
+	return kvs
+}
+
+func sha256Sum(bz []byte) []byte {
+	hash := sha256.Sum256(bz)
+	return hash[:]
+}
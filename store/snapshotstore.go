@@ -0,0 +1,178 @@
+package store
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+)
+
+// DefaultSnapshotChunkSize is the approximate size in bytes of the chunks of
+// the snapshots saved with SaveSnapshot.
+const DefaultSnapshotChunkSize = 10 * 1024 * 1024
+
+// SnapshotStore persists snapshots and their chunks.
+type SnapshotStore interface {
+	// SaveChunk persists a chunk of the snapshot at the given height.
+	SaveChunk(height int64, index uint32, chunk []byte) error
+
+	// LoadChunk returns a chunk of the snapshot at the given height.
+	LoadChunk(height int64, index uint32) ([]byte, error)
+
+	// Save persists a snapshot once all its chunks are saved.
+	Save(snapshot Snapshot) error
+
+	// Load returns the snapshot at the given height.
+	Load(height int64) (Snapshot, error)
+
+	// List returns the saved snapshots, sorted by decreasing height.
+	List() ([]Snapshot, error)
+
+	// Delete removes the snapshot at the given height and its chunks.
+	Delete(height int64) error
+}
+
+// SaveSnapshot snapshots the committed state at the given height into the
+// snapshot store.
+func SaveSnapshot(s Snapshotter, ss SnapshotStore, height int64) (Snapshot, error) {
+	saveChunk := func(index uint32, chunk []byte) error {
+		return ss.SaveChunk(height, index, chunk)
+	}
+	snapshot, err := s.Snapshot(height, DefaultSnapshotChunkSize, saveChunk)
+	if err != nil {
+		// don't leave the chunks of a failed snapshot around
+		_ = ss.Delete(height)
+		return Snapshot{}, err
+	}
+	err = ss.Save(snapshot)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	return snapshot, nil
+}
+
+// RestoreSnapshot restores the snapshot at the given height from the snapshot
+// store.
+func RestoreSnapshot(s Snapshotter, ss SnapshotStore, height int64) (Snapshot, error) {
+	snapshot, err := ss.Load(height)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	loadChunk := func(index uint32) ([]byte, error) {
+		return ss.LoadChunk(height, index)
+	}
+	err = s.Restore(snapshot, loadChunk)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	return snapshot, nil
+}
+
+//----------------------------------------
+// localSnapshotStore
+
+const snapshotMetadataFile = "snapshot.json"
+
+// localSnapshotStore saves each snapshot in a directory named after its
+// height, with its chunks in files named after their index.
+type localSnapshotStore struct {
+	dir string
+}
+
+var _ SnapshotStore = localSnapshotStore{}
+
+// NewLocalSnapshotStore returns a SnapshotStore saving snapshots in the given
+// local directory, which is created if needed.
+func NewLocalSnapshotStore(dir string) SnapshotStore {
+	return localSnapshotStore{dir: dir}
+}
+
+func (ls localSnapshotStore) snapshotDir(height int64) string {
+	return filepath.Join(ls.dir, strconv.FormatInt(height, 10))
+}
+
+func (ls localSnapshotStore) chunkFile(height int64, index uint32) string {
+	return filepath.Join(ls.snapshotDir(height), strconv.FormatUint(uint64(index), 10))
+}
+
+// Implements SnapshotStore.
+func (ls localSnapshotStore) SaveChunk(height int64, index uint32, chunk []byte) error {
+	err := os.MkdirAll(ls.snapshotDir(height), 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(ls.chunkFile(height, index), chunk, 0644)
+}
+
+// Implements SnapshotStore.
+func (ls localSnapshotStore) LoadChunk(height int64, index uint32) ([]byte, error) {
+	return ioutil.ReadFile(ls.chunkFile(height, index))
+}
+
+// Implements SnapshotStore.
+func (ls localSnapshotStore) Save(snapshot Snapshot) error {
+	bz, err := cdc.MarshalJSON(snapshot)
+	if err != nil {
+		return err
+	}
+	err = os.MkdirAll(ls.snapshotDir(snapshot.Height), 0755)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filepath.Join(ls.snapshotDir(snapshot.Height), snapshotMetadataFile), bz, 0644)
+}
+
+// Implements SnapshotStore.
+func (ls localSnapshotStore) Load(height int64) (Snapshot, error) {
+	bz, err := ioutil.ReadFile(filepath.Join(ls.snapshotDir(height), snapshotMetadataFile))
+	if os.IsNotExist(err) {
+		return Snapshot{}, fmt.Errorf("no snapshot at height %d", height)
+	}
+	if err != nil {
+		return Snapshot{}, err
+	}
+	var snapshot Snapshot
+	err = cdc.UnmarshalJSON(bz, &snapshot)
+	if err != nil {
+		return Snapshot{}, err
+	}
+	return snapshot, nil
+}
+
+// Implements SnapshotStore.
+// Snapshots which are not saved yet, or whose save failed, are not listed.
+func (ls localSnapshotStore) List() ([]Snapshot, error) {
+	files, err := ioutil.ReadDir(ls.dir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var snapshots []Snapshot
+	for _, file := range files {
+		height, err := strconv.ParseInt(file.Name(), 10, 64)
+		if err != nil || !file.IsDir() {
+			continue
+		}
+		_, err = os.Stat(filepath.Join(ls.snapshotDir(height), snapshotMetadataFile))
+		if os.IsNotExist(err) {
+			continue
+		}
+		snapshot, err := ls.Load(height)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+	sort.Slice(snapshots, func(i, j int) bool { return snapshots[i].Height > snapshots[j].Height })
+	return snapshots, nil
+}
+
+// Implements SnapshotStore.
+func (ls localSnapshotStore) Delete(height int64) error {
+	return os.RemoveAll(ls.snapshotDir(height))
+}
@@ -0,0 +1,124 @@
+package store
+
+// sortedTree is an AVL tree holding the dirty items of a cacheKVStore sorted by
+// key, so that they can be iterated over in a range and written to the parent
+// in order without being sorted again.
+//
+// Iterators iterate over a snapshot of the tree, whose nodes are never modified
+// afterwards: the nodes of the generations before the snapshot are copied on
+// write, while the nodes created since are modified in place.
+type sortedTree struct {
+	root *sortedNode
+	gen  uint64 // generation of the nodes which can be modified in place
+}
+
+type sortedNode struct {
+	key    string
+	value  []byte
+	height int8
+	gen    uint64
+
+	left, right *sortedNode
+}
+
+func newSortedTree() *sortedTree {
+	return &sortedTree{}
+}
+
+// set inserts or updates the item of the key
+func (tree *sortedTree) set(key string, value []byte) {
+	tree.root = tree.insert(tree.root, key, value)
+}
+
+// snapshot returns the root of the tree, the nodes reachable from it are not
+// modified by later calls to set.
+func (tree *sortedTree) snapshot() *sortedNode {
+	tree.gen++
+	return tree.root
+}
+
+// reset removes all items
+func (tree *sortedTree) reset() {
+	tree.root = nil
+	tree.gen++
+}
+
+func (tree *sortedTree) insert(node *sortedNode, key string, value []byte) *sortedNode {
+	if node == nil {
+		return &sortedNode{key: key, value: value, height: 1, gen: tree.gen}
+	}
+
+	node = tree.mutable(node)
+	switch {
+	case key < node.key:
+		node.left = tree.insert(node.left, key, value)
+	case key > node.key:
+		node.right = tree.insert(node.right, key, value)
+	default:
+		node.value = value
+		return node
+	}
+	return tree.balance(node)
+}
+
+// mutable returns the node if it can be modified in place, or a copy of it
+func (tree *sortedTree) mutable(node *sortedNode) *sortedNode {
+	if node.gen == tree.gen {
+		return node
+	}
+	cpy := *node
+	cpy.gen = tree.gen
+	return &cpy
+}
+
+// balance the subtree of a mutable node whose children are balanced
+func (tree *sortedTree) balance(node *sortedNode) *sortedNode {
+	node.updateHeight()
+	switch balance := node.left.getHeight() - node.right.getHeight(); {
+	case balance > 1:
+		if node.left.left.getHeight() < node.left.right.getHeight() {
+			node.left = tree.rotateLeft(tree.mutable(node.left))
+		}
+		return tree.rotateRight(node)
+	case balance < -1:
+		if node.right.right.getHeight() < node.right.left.getHeight() {
+			node.right = tree.rotateRight(tree.mutable(node.right))
+		}
+		return tree.rotateLeft(node)
+	default:
+		return node
+	}
+}
+
+func (tree *sortedTree) rotateRight(node *sortedNode) *sortedNode {
+	left := tree.mutable(node.left)
+	node.left = left.right
+	left.right = node
+	node.updateHeight()
+	left.updateHeight()
+	return left
+}
+
+func (tree *sortedTree) rotateLeft(node *sortedNode) *sortedNode {
+	right := tree.mutable(node.right)
+	node.right = right.left
+	right.left = node
+	node.updateHeight()
+	right.updateHeight()
+	return right
+}
+
+func (node *sortedNode) getHeight() int8 {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+func (node *sortedNode) updateHeight() {
+	node.height = node.left.getHeight()
+	if h := node.right.getHeight(); h > node.height {
+		node.height = h
+	}
+	node.height++
+}
@@ -0,0 +1,77 @@
+package store
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSortedTree(t *testing.T) {
+	tree := newSortedTree()
+	items := make(map[string][]byte)
+	var snapshots []*sortedNode
+	var snapshotItems []map[string][]byte
+
+	for i := 0; i < 2000; i++ {
+		key := fmt.Sprintf("key%d", rand.Intn(1000))
+		value := []byte(fmt.Sprintf("value%d", i))
+		tree.set(key, value)
+		items[key] = value
+
+		if i%250 == 0 {
+			cpy := make(map[string][]byte, len(items))
+			for k, v := range items {
+				cpy[k] = v
+			}
+			snapshots = append(snapshots, tree.snapshot())
+			snapshotItems = append(snapshotItems, cpy)
+		}
+	}
+	snapshots = append(snapshots, tree.snapshot())
+	snapshotItems = append(snapshotItems, items)
+
+	// the snapshots are not modified by the later writes
+	for i, root := range snapshots {
+		requireSortedNodes(t, root, snapshotItems[i])
+	}
+
+	tree.reset()
+	require.Nil(t, tree.snapshot())
+}
+
+func requireSortedNodes(t *testing.T, root *sortedNode, items map[string][]byte) {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	iter := newMemIterator(nil, nil, root, true)
+	for _, key := range keys {
+		require.True(t, iter.Valid())
+		require.Equal(t, key, string(iter.Key()))
+		require.Equal(t, items[key], iter.Value())
+		iter.Next()
+	}
+	require.False(t, iter.Valid())
+
+	// the tree is balanced
+	var checkBalance func(node *sortedNode) int8
+	checkBalance = func(node *sortedNode) int8 {
+		if node == nil {
+			return 0
+		}
+		left, right := checkBalance(node.left), checkBalance(node.right)
+		require.True(t, left-right <= 1 && right-left <= 1)
+		height := left + 1
+		if right > left {
+			height = right + 1
+		}
+		require.Equal(t, height, node.height)
+		return height
+	}
+	require.Equal(t, root.getHeight(), checkBalance(root))
+}
@@ -47,7 +47,10 @@ func NewContext(ms MultiStore, header abci.Header, isCheckTx bool, logger log.Lo
 	c = c.WithLogger(logger)
 	c = c.WithSigningValidators(nil)
 	c = c.WithGasMeter(NewInfiniteGasMeter())
+	c = c.WithBlockGasMeter(NewInfiniteGasMeter())
 	c = c.WithMinimumFees(Coins{})
+	c = c.WithKVGasConfig(DefaultGasConfig())
+	c = c.WithTransientGasConfig(TransientGasConfig())
 	return c
 }
 
@@ -73,12 +76,12 @@ func (c Context) Value(key interface{}) interface{} {
 
 // KVStore fetches a KVStore from the MultiStore.
 func (c Context) KVStore(key StoreKey) KVStore {
-	return c.multiStore().GetKVStore(key).Gas(c.GasMeter(), cachedDefaultGasConfig)
+	return c.multiStore().GetKVStore(key).Gas(c.GasMeter(), c.KVGasConfig())
 }
 
 // TransientStore fetches a TransientStore from the MultiStore.
 func (c Context) TransientStore(key StoreKey) KVStore {
-	return c.multiStore().GetKVStore(key).Gas(c.GasMeter(), cachedTransientGasConfig)
+	return c.multiStore().GetKVStore(key).Gas(c.GasMeter(), c.TransientGasConfig())
 }
 
 //----------------------------------------
@@ -140,7 +143,10 @@ const (
 	contextKeyLogger
 	contextKeySigningValidators
 	contextKeyGasMeter
+	contextKeyBlockGasMeter
 	contextKeyMinimumFees
+	contextKeyKVGasConfig
+	contextKeyTransientGasConfig
 )
 
 // NOTE: Do not expose MultiStore.
@@ -170,10 +176,18 @@ func (c Context) SigningValidators() []abci.SigningValidator {
 
 func (c Context) GasMeter() GasMeter { return c.Value(contextKeyGasMeter).(GasMeter) }
 
+func (c Context) BlockGasMeter() GasMeter { return c.Value(contextKeyBlockGasMeter).(GasMeter) }
+
 func (c Context) IsCheckTx() bool { return c.Value(contextKeyIsCheckTx).(bool) }
 
 func (c Context) MinimumFees() Coins { return c.Value(contextKeyMinimumFees).(Coins) }
 
+func (c Context) KVGasConfig() GasConfig { return c.Value(contextKeyKVGasConfig).(GasConfig) }
+
+func (c Context) TransientGasConfig() GasConfig {
+	return c.Value(contextKeyTransientGasConfig).(GasConfig)
+}
+
 func (c Context) WithMultiStore(ms MultiStore) Context { return c.withValue(contextKeyMultiStore, ms) }
 
 func (c Context) WithBlockHeader(header abci.Header) Context {
@@ -205,6 +219,10 @@ func (c Context) WithSigningValidators(SigningValidators []abci.SigningValidator
 
 func (c Context) WithGasMeter(meter GasMeter) Context { return c.withValue(contextKeyGasMeter, meter) }
 
+func (c Context) WithBlockGasMeter(meter GasMeter) Context {
+	return c.withValue(contextKeyBlockGasMeter, meter)
+}
+
 func (c Context) WithIsCheckTx(isCheckTx bool) Context {
 	return c.withValue(contextKeyIsCheckTx, isCheckTx)
 }
@@ -213,6 +231,14 @@ func (c Context) WithMinimumFees(minFees Coins) Context {
 	return c.withValue(contextKeyMinimumFees, minFees)
 }
 
+func (c Context) WithKVGasConfig(config GasConfig) Context {
+	return c.withValue(contextKeyKVGasConfig, config)
+}
+
+func (c Context) WithTransientGasConfig(config GasConfig) Context {
+	return c.withValue(contextKeyTransientGasConfig, config)
+}
+
 // Cache the multistore and return a new cached context. The cached context is
 // written to the context when writeCache is called.
 func (c Context) CacheContext() (cc Context, writeCache func()) {
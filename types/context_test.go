@@ -153,6 +153,8 @@ func TestContextWithCustom(t *testing.T) {
 	require.Panics(t, func() { ctx.Logger() })
 	require.Panics(t, func() { ctx.SigningValidators() })
 	require.Panics(t, func() { ctx.GasMeter() })
+	require.Panics(t, func() { ctx.BlockGasMeter() })
+	require.Panics(t, func() { ctx.KVGasConfig() })
 
 	header := abci.Header{}
 	height := int64(1)
@@ -162,7 +164,9 @@ func TestContextWithCustom(t *testing.T) {
 	logger := NewMockLogger()
 	signvals := []abci.SigningValidator{{}}
 	meter := types.NewGasMeter(10000)
+	blockGasMeter := types.NewGasMeter(20000)
 	minFees := types.Coins{types.NewInt64Coin("feeCoin", 1)}
+	kvGasConfig := types.GasConfig{HasCost: 1}
 
 	ctx = types.NewContext(nil, header, ischeck, logger).
 		WithBlockHeight(height).
@@ -170,7 +174,9 @@ func TestContextWithCustom(t *testing.T) {
 		WithTxBytes(txbytes).
 		WithSigningValidators(signvals).
 		WithGasMeter(meter).
-		WithMinimumFees(minFees)
+		WithBlockGasMeter(blockGasMeter).
+		WithMinimumFees(minFees).
+		WithKVGasConfig(kvGasConfig)
 
 	require.Equal(t, header, ctx.BlockHeader())
 	require.Equal(t, height, ctx.BlockHeight())
@@ -180,5 +186,8 @@ func TestContextWithCustom(t *testing.T) {
 	require.Equal(t, logger, ctx.Logger())
 	require.Equal(t, signvals, ctx.SigningValidators())
 	require.Equal(t, meter, ctx.GasMeter())
+	require.Equal(t, blockGasMeter, ctx.BlockGasMeter())
 	require.Equal(t, minFees, types.Coins{types.NewInt64Coin("feeCoin", 1)})
+	require.Equal(t, kvGasConfig, ctx.KVGasConfig())
+	require.Equal(t, types.TransientGasConfig(), ctx.TransientGasConfig())
 }
@@ -1,5 +1,7 @@
 package types
 
+import "fmt"
+
 // Gas measured by the SDK
 type Gas = int64
 
@@ -11,10 +13,55 @@ type ErrorOutOfGas struct {
 // GasMeter interface to track gas consumption
 type GasMeter interface {
 	GasConsumed() Gas
+	Limit() Gas
 	ConsumeGas(amount Gas, descriptor string)
+	IsOutOfGas() bool
+
+	// GasBreakdown returns the gas consumed by each descriptor, in the order
+	// the descriptors first consumed gas
+	GasBreakdown() []GasConsumption
+}
+
+// GasConsumption is the gas consumed by the operations of a descriptor
+type GasConsumption struct {
+	Descriptor string `json:"descriptor"`
+	Gas        Gas    `json:"gas"`
+}
+
+// gasBreakdown records the gas consumed by each descriptor
+type gasBreakdown struct {
+	consumptions []GasConsumption
+	indexes      map[string]int
+}
+
+func (b *gasBreakdown) record(amount Gas, descriptor string) {
+	if amount == 0 {
+		return
+	}
+	if b.indexes == nil {
+		b.indexes = make(map[string]int)
+	}
+	i, ok := b.indexes[descriptor]
+	if !ok {
+		i = len(b.consumptions)
+		b.indexes[descriptor] = i
+		b.consumptions = append(b.consumptions, GasConsumption{Descriptor: descriptor})
+	}
+	b.consumptions[i].Gas += amount
+}
+
+// GasBreakdown returns a copy of the recorded consumptions
+func (b *gasBreakdown) GasBreakdown() []GasConsumption {
+	if len(b.consumptions) == 0 {
+		return nil
+	}
+	consumptions := make([]GasConsumption, len(b.consumptions))
+	copy(consumptions, b.consumptions)
+	return consumptions
 }
 
 type basicGasMeter struct {
+	gasBreakdown
 	limit    Gas
 	consumed Gas
 }
@@ -30,14 +77,26 @@ func (g *basicGasMeter) GasConsumed() Gas {
 	return g.consumed
 }
 
+func (g *basicGasMeter) Limit() Gas {
+	return g.limit
+}
+
 func (g *basicGasMeter) ConsumeGas(amount Gas, descriptor string) {
 	g.consumed += amount
+	g.record(amount, descriptor)
 	if g.consumed > g.limit {
 		panic(ErrorOutOfGas{descriptor})
 	}
 }
 
+// IsOutOfGas returns whether the limit has been reached, any further gas
+// consumption would exceed it
+func (g *basicGasMeter) IsOutOfGas() bool {
+	return g.consumed >= g.limit
+}
+
 type infiniteGasMeter struct {
+	gasBreakdown
 	consumed Gas
 }
 
@@ -51,26 +110,46 @@ func (g *infiniteGasMeter) GasConsumed() Gas {
 	return g.consumed
 }
 
+// Limit of an infinite gas meter is zero
+func (g *infiniteGasMeter) Limit() Gas {
+	return 0
+}
+
 func (g *infiniteGasMeter) ConsumeGas(amount Gas, descriptor string) {
 	g.consumed += amount
+	g.record(amount, descriptor)
+}
+
+func (g *infiniteGasMeter) IsOutOfGas() bool {
+	return false
 }
 
 // GasConfig defines gas cost for each operation on KVStores
 type GasConfig struct {
-	HasCost          Gas
-	ReadCostFlat     Gas
-	ReadCostPerByte  Gas
-	WriteCostFlat    Gas
-	WriteCostPerByte Gas
-	KeyCostFlat      Gas
-	ValueCostFlat    Gas
-	ValueCostPerByte Gas
+	HasCost          Gas `json:"has_cost"`
+	ReadCostFlat     Gas `json:"read_cost_flat"`
+	ReadCostPerByte  Gas `json:"read_cost_per_byte"`
+	WriteCostFlat    Gas `json:"write_cost_flat"`
+	WriteCostPerByte Gas `json:"write_cost_per_byte"`
+	DeleteCost       Gas `json:"delete_cost"`
+	IterNextCostFlat Gas `json:"iter_next_cost_flat"`
+	KeyCostFlat      Gas `json:"key_cost_flat"`
+	ValueCostFlat    Gas `json:"value_cost_flat"`
+	ValueCostPerByte Gas `json:"value_cost_per_byte"`
 }
 
-var (
-	cachedDefaultGasConfig   = DefaultGasConfig()
-	cachedTransientGasConfig = TransientGasConfig()
-)
+// Validate checks that no cost is negative
+func (config GasConfig) Validate() error {
+	costs := []Gas{config.HasCost, config.ReadCostFlat, config.ReadCostPerByte, config.WriteCostFlat,
+		config.WriteCostPerByte, config.DeleteCost, config.IterNextCostFlat, config.KeyCostFlat,
+		config.ValueCostFlat, config.ValueCostPerByte}
+	for _, cost := range costs {
+		if cost < 0 {
+			return fmt.Errorf("negative gas cost %d in %+v", cost, config)
+		}
+	}
+	return nil
+}
 
 // Default gas config for KVStores
 func DefaultGasConfig() GasConfig {
@@ -80,6 +159,8 @@ func DefaultGasConfig() GasConfig {
 		ReadCostPerByte:  1,
 		WriteCostFlat:    10,
 		WriteCostPerByte: 10,
+		DeleteCost:       0,
+		IterNextCostFlat: 0,
 		KeyCostFlat:      5,
 		ValueCostFlat:    10,
 		ValueCostPerByte: 1,
@@ -29,8 +29,44 @@ func TestGasMeter(t *testing.T) {
 			require.Equal(t, used, meter.GasConsumed(), "Gas consumption not match. tc #%d, usage #%d", tcnum, unum)
 		}
 
+		require.True(t, meter.IsOutOfGas(), "Limit reached but not out of gas. tc #%d", tcnum)
 		require.Panics(t, func() { meter.ConsumeGas(1, "") }, "Exceeded but not panicked. tc #%d", tcnum)
 		break
 
 	}
 }
+
+func TestInfiniteGasMeter(t *testing.T) {
+	meter := NewInfiniteGasMeter()
+	meter.ConsumeGas(1000000, "")
+	require.Equal(t, Gas(1000000), meter.GasConsumed())
+	require.Equal(t, Gas(0), meter.Limit())
+	require.False(t, meter.IsOutOfGas())
+}
+
+func TestGasBreakdown(t *testing.T) {
+	for _, meter := range []GasMeter{NewGasMeter(100), NewInfiniteGasMeter()} {
+		require.Nil(t, meter.GasBreakdown())
+		meter.ConsumeGas(10, "write")
+		meter.ConsumeGas(0, "delete")
+		meter.ConsumeGas(5, "read")
+		meter.ConsumeGas(20, "write")
+
+		breakdown := meter.GasBreakdown()
+		require.Equal(t, []GasConsumption{{"write", 30}, {"read", 5}}, breakdown)
+		breakdown[0].Gas = 0
+		require.Equal(t, Gas(30), meter.GasBreakdown()[0].Gas)
+	}
+
+	// the consumption running out of gas is recorded
+	meter := NewGasMeter(10)
+	require.Panics(t, func() { meter.ConsumeGas(11, "write") })
+	require.Equal(t, []GasConsumption{{"write", 11}}, meter.GasBreakdown())
+}
+
+func TestGasConfigValidate(t *testing.T) {
+	require.Nil(t, DefaultGasConfig().Validate())
+	config := DefaultGasConfig()
+	config.DeleteCost = -1
+	require.NotNil(t, config.Validate())
+}
@@ -6,3 +6,43 @@ type Handler func(ctx Context, msg Msg) Result
 // AnteHandler authenticates transactions, before their internal messages are handled.
 // If newCtx.IsZero(), ctx is used instead.
 type AnteHandler func(ctx Context, tx Tx, simulate bool) (newCtx Context, result Result, abort bool)
+
+// AnteDecorator is a step of an AnteHandler. It hands the tx over to the next
+// steps by calling next, unless it aborts the tx.
+type AnteDecorator interface {
+	AnteHandle(ctx Context, tx Tx, simulate bool, next AnteHandler) (newCtx Context, result Result, abort bool)
+}
+
+// ChainAnteDecorators returns the AnteHandler running the decorators in order.
+// The last decorator is handed an AnteHandler which accepts the tx.
+func ChainAnteDecorators(decorators ...AnteDecorator) AnteHandler {
+	handler := func(ctx Context, _ Tx, _ bool) (Context, Result, bool) {
+		return ctx, Result{}, false
+	}
+	for i := len(decorators) - 1; i >= 0; i-- {
+		decorator, next := decorators[i], handler
+		handler = func(ctx Context, tx Tx, simulate bool) (Context, Result, bool) {
+			return decorator.AnteHandle(ctx, tx, simulate, next)
+		}
+	}
+	return handler
+}
+
+// WrapAnteHandler returns a decorator running the AnteHandler before the next
+// steps, so that an existing AnteHandler can be inserted into a chain.
+func WrapAnteHandler(ah AnteHandler) AnteDecorator {
+	return anteHandlerDecorator(ah)
+}
+
+type anteHandlerDecorator AnteHandler
+
+func (ah anteHandlerDecorator) AnteHandle(ctx Context, tx Tx, simulate bool, next AnteHandler) (Context, Result, bool) {
+	newCtx, result, abort := ah(ctx, tx, simulate)
+	if abort {
+		return newCtx, result, true
+	}
+	if !newCtx.IsZero() {
+		ctx = newCtx
+	}
+	return next(ctx, tx, simulate)
+}
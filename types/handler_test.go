@@ -0,0 +1,71 @@
+package types_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/types"
+)
+
+type testDecoratorKey string
+
+// a decorator recording its name in the context, aborting the tx if asked to
+type testDecorator struct {
+	name  string
+	abort bool
+}
+
+func (d testDecorator) AnteHandle(ctx types.Context, tx types.Tx, simulate bool, next types.AnteHandler) (types.Context, types.Result, bool) {
+	ctx = ctx.WithValue(testDecoratorKey(d.name), true)
+	if d.abort {
+		return ctx, types.ErrUnauthorized(d.name).Result(), true
+	}
+	return next(ctx, tx, simulate)
+}
+
+func TestChainAnteDecorators(t *testing.T) {
+	ctx := types.NewContext(nil, abci.Header{}, false, log.NewNopLogger())
+
+	// no decorator accepts the tx
+	newCtx, result, abort := types.ChainAnteDecorators()(ctx, nil, false)
+	require.False(t, abort)
+	require.True(t, result.IsOK())
+	require.Equal(t, ctx, newCtx)
+
+	newCtx, _, abort = types.ChainAnteDecorators(testDecorator{name: "a"}, testDecorator{name: "b"})(ctx, nil, false)
+	require.False(t, abort)
+	require.NotNil(t, newCtx.Value(testDecoratorKey("a")))
+	require.NotNil(t, newCtx.Value(testDecoratorKey("b")))
+
+	// the decorators after the aborting one are skipped
+	ah := types.ChainAnteDecorators(testDecorator{name: "a"}, testDecorator{name: "b", abort: true}, testDecorator{name: "c"})
+	newCtx, result, abort = ah(ctx, nil, false)
+	require.True(t, abort)
+	require.Equal(t, types.CodeUnauthorized, result.Code)
+	require.NotNil(t, newCtx.Value(testDecoratorKey("b")))
+	require.Nil(t, newCtx.Value(testDecoratorKey("c")))
+}
+
+func TestWrapAnteHandler(t *testing.T) {
+	ctx := types.NewContext(nil, abci.Header{}, false, log.NewNopLogger())
+	accept := func(ctx types.Context, _ types.Tx, _ bool) (types.Context, types.Result, bool) {
+		return ctx.WithValue(testDecoratorKey("wrapped"), true), types.Result{}, false
+	}
+	reject := func(ctx types.Context, _ types.Tx, _ bool) (types.Context, types.Result, bool) {
+		return ctx, types.ErrUnauthorized("rejected").Result(), true
+	}
+
+	// the context of the wrapped handler is handed to the next decorators
+	newCtx, _, abort := types.ChainAnteDecorators(types.WrapAnteHandler(accept), testDecorator{name: "a"})(ctx, nil, false)
+	require.False(t, abort)
+	require.NotNil(t, newCtx.Value(testDecoratorKey("wrapped")))
+	require.NotNil(t, newCtx.Value(testDecoratorKey("a")))
+
+	newCtx, result, abort := types.ChainAnteDecorators(types.WrapAnteHandler(reject), testDecorator{name: "a"})(ctx, nil, false)
+	require.True(t, abort)
+	require.Equal(t, types.CodeUnauthorized, result.Code)
+	require.Nil(t, newCtx.Value(testDecoratorKey("a")))
+}
@@ -1,5 +1,11 @@
 package types
 
+import (
+	"encoding/json"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
 // Result is the union of ResponseDeliverTx and ResponseCheckTx.
 type Result struct {
 
@@ -7,9 +13,11 @@ type Result struct {
 	Code ABCICodeType
 
 	// Data is any data returned from the app.
+	// The Data of a tx which ran its messages holds its encoded MsgResults.
 	Data []byte
 
 	// Log is just debug information. NOTE: nondeterministic.
+	// The Log of a tx which ran its messages holds its encoded MsgLogs.
 	Log string
 
 	// GasWanted is the maximum units of work we allow this tx to perform.
@@ -18,6 +26,12 @@ type Result struct {
 	// GasUsed is the amount of gas actually consumed. NOTE: unimplemented
 	GasUsed int64
 
+	// GasBreakdown is the gas consumed by each operation descriptor.
+	GasBreakdown []GasConsumption
+
+	// Priority is the priority of a checked tx in the mempool, the higher first.
+	Priority int64
+
 	// Tx fee amount and denom.
 	FeeAmount int64
 	FeeDenom  string
@@ -30,3 +44,54 @@ type Result struct {
 func (res Result) IsOK() bool {
 	return res.Code.IsOK()
 }
+
+// MsgResult is the result of a message of a multi-message tx. Its log, which
+// is nondeterministic, is kept apart in a MsgLog.
+type MsgResult struct {
+	MsgIndex int    `json:"msg_index"`
+	Route    string `json:"route"`
+	Data     []byte `json:"data"`
+	Tags     Tags   `json:"tags"`
+}
+
+// MsgLog is the log of a message of a multi-message tx.
+type MsgLog struct {
+	MsgIndex int    `json:"msg_index"`
+	Success  bool   `json:"success"`
+	Log      string `json:"log"`
+}
+
+var msgResultsCdc = codec.New()
+
+// EncodeMsgResults encodes the results of the messages of a tx
+// deterministically, into the Data of the result of the tx.
+func EncodeMsgResults(msgResults []MsgResult) []byte {
+	return msgResultsCdc.MustMarshalBinary(msgResults)
+}
+
+// DecodeMsgResults decodes the results of the messages of a tx from the Data
+// of the result of the tx, an empty Data having no results.
+func DecodeMsgResults(data []byte) (msgResults []MsgResult, err error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+	err = msgResultsCdc.UnmarshalBinary(data, &msgResults)
+	return msgResults, err
+}
+
+// EncodeMsgLogs encodes the logs of the messages of a tx as a JSON array, into
+// the Log of the result of the tx.
+func EncodeMsgLogs(msgLogs []MsgLog) string {
+	bz, err := json.Marshal(msgLogs)
+	if err != nil {
+		panic(err)
+	}
+	return string(bz)
+}
+
+// DecodeMsgLogs decodes the logs of the messages of a tx from the Log of the
+// result of the tx, which fails if the tx did not run its messages.
+func DecodeMsgLogs(log string) (msgLogs []MsgLog, err error) {
+	err = json.Unmarshal([]byte(log), &msgLogs)
+	return msgLogs, err
+}
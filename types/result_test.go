@@ -16,3 +16,39 @@ func TestResult(t *testing.T) {
 	res.Code = ABCICodeType(1)
 	require.False(t, res.IsOK())
 }
+
+func TestMsgResultsEncoding(t *testing.T) {
+	msgResults := []MsgResult{
+		{MsgIndex: 0, Route: "bank", Data: []byte("data"), Tags: NewTags("action", []byte("send"))},
+		{MsgIndex: 1, Route: "stake", Tags: NewTags("action", []byte("delegate"))},
+	}
+	data := EncodeMsgResults(msgResults)
+	require.Equal(t, data, EncodeMsgResults(msgResults))
+
+	decoded, err := DecodeMsgResults(data)
+	require.Nil(t, err)
+	require.Equal(t, msgResults, decoded)
+
+	decoded, err = DecodeMsgResults(nil)
+	require.Nil(t, err)
+	require.Nil(t, decoded)
+
+	_, err = DecodeMsgResults([]byte("data"))
+	require.NotNil(t, err)
+}
+
+func TestMsgLogsEncoding(t *testing.T) {
+	msgLogs := []MsgLog{
+		{MsgIndex: 0, Success: true, Log: "log"},
+		{MsgIndex: 1, Success: false, Log: "failure"},
+	}
+	log := EncodeMsgLogs(msgLogs)
+	require.Equal(t, `[{"msg_index":0,"success":true,"log":"log"},{"msg_index":1,"success":false,"log":"failure"}]`, log)
+
+	decoded, err := DecodeMsgLogs(log)
+	require.Nil(t, err)
+	require.Equal(t, msgLogs, decoded)
+
+	_, err = DecodeMsgLogs("failure")
+	require.NotNil(t, err)
+}
@@ -47,6 +47,7 @@ type Validator interface {
 	GetTokens() Dec               // validation tokens
 	GetDelegatorShares() Dec      // Total out standing delegator shares
 	GetBondHeight() int64         // height in which the validator became active
+	GetCommission() Dec           // validator commission rate
 }
 
 // validator which fulfills abci validator interface for use in Tendermint
@@ -101,12 +102,20 @@ type DelegationSet interface {
 		fn func(index int64, delegation Delegation) (stop bool))
 }
 
-// validator event hooks
+// event hooks for staking validator and delegation objects
 // These can be utilized to communicate between a staking keeper
 // and another keeper which must take particular actions when
-// validators are bonded and unbonded. The second keeper must implement
+// validators or delegations change. The second keeper must implement
 // this interface, which then the staking keeper can call.
-type ValidatorHooks interface {
+type StakingHooks interface {
+	OnValidatorCreated(ctx Context, address ValAddress)  // Must be called when a validator is created
+	OnValidatorModified(ctx Context, address ValAddress) // Must be called before a validator's tokens, status or commission change
+	OnValidatorRemoved(ctx Context, address ValAddress)  // Must be called before a validator is deleted
+
 	OnValidatorBonded(ctx Context, address ConsAddress)         // Must be called when a validator is bonded
 	OnValidatorBeginUnbonding(ctx Context, address ConsAddress) // Must be called when a validator begins unbonding
+
+	OnDelegationCreated(ctx Context, delAddr AccAddress, valAddr ValAddress)        // Must be called when a delegation is created
+	OnDelegationSharesModified(ctx Context, delAddr AccAddress, valAddr ValAddress) // Must be called before a delegation's shares change
+	OnDelegationRemoved(ctx Context, delAddr AccAddress, valAddr ValAddress)        // Must be called before a delegation is deleted
 }
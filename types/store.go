@@ -12,19 +12,60 @@ import (
 // NOTE: These are implemented in cosmos-sdk/store.
 
 // PruningStrategy specfies how old states will be deleted over time
-type PruningStrategy uint8
+type PruningStrategy struct {
+	// KeepRecent is the number of states kept before the latest one.
+	KeepRecent int64 `json:"keep_recent"`
 
-const (
+	// KeepEvery keeps every KeepEvery-th state besides the recent ones,
+	// 0 keeps none of them and 1 keeps all states.
+	KeepEvery int64 `json:"keep_every"`
+
+	// Interval is the number of blocks between two runs of the pruning, which
+	// deletes the states released since the previous run. 0 disables pruning.
+	Interval int64 `json:"interval"`
+}
+
+var (
 	// PruneSyncable means only those states not needed for state syncing will be deleted (keeps last 100 + every 10000th)
-	PruneSyncable PruningStrategy = iota
+	PruneSyncable = NewPruningStrategy(100, 10000, 10)
 
 	// PruneEverything means all saved states will be deleted, storing only the current state
-	PruneEverything PruningStrategy = iota
+	PruneEverything = NewPruningStrategy(0, 0, 10)
 
 	// PruneNothing means all historic states will be saved, nothing will be deleted
-	PruneNothing PruningStrategy = iota
+	PruneNothing = NewPruningStrategy(0, 1, 0)
 )
 
+// NewPruningStrategy returns a PruningStrategy keeping the given number of
+// recent states and every keepEvery-th state, pruning every interval blocks.
+func NewPruningStrategy(keepRecent, keepEvery, interval int64) PruningStrategy {
+	return PruningStrategy{
+		KeepRecent: keepRecent,
+		KeepEvery:  keepEvery,
+		Interval:   interval,
+	}
+}
+
+// Validate returns an error if any of the values is negative.
+func (ps PruningStrategy) Validate() error {
+	if ps.KeepRecent < 0 || ps.KeepEvery < 0 || ps.Interval < 0 {
+		return fmt.Errorf("invalid pruning strategy: keep-recent %d, keep-every %d and interval %d must not be negative",
+			ps.KeepRecent, ps.KeepEvery, ps.Interval)
+	}
+	return nil
+}
+
+// Prunes returns whether any state is ever deleted.
+func (ps PruningStrategy) Prunes() bool {
+	return ps.Interval > 0 && ps.KeepEvery != 1
+}
+
+// Keeps returns whether the state at the version is kept once the state at
+// latest is committed.
+func (ps PruningStrategy) Keeps(version, latest int64) bool {
+	return version >= latest-ps.KeepRecent || (ps.KeepEvery != 0 && version%ps.KeepEvery == 0)
+}
+
 type Store interface { //nolint
 	GetStoreType() StoreType
 	CacheWrapper
@@ -80,6 +121,11 @@ type MultiStore interface { //nolint
 
 	// ResetTraceContext resets the current tracing context.
 	ResetTraceContext() MultiStore
+
+	// ListeningEnabled returns if the writes into the MultiStore are
+	// recorded for the listeners of the CommitMultiStore. The writes are
+	// annotated with the "txHash" of the tracing context.
+	ListeningEnabled() bool
 }
 
 // From MultiStore.CacheMultiStore()....
@@ -112,6 +158,33 @@ type CommitMultiStore interface {
 	// the next commit after loading must be idempotent (return the
 	// same commit id).  Otherwise the behavior is undefined.
 	LoadVersion(ver int64) error
+
+	// CacheMultiStoreWithVersion cache-wraps the stores at a committed
+	// version, to query the state at that version. It fails if the version
+	// is pruned. The returned store must not be written.
+	CacheMultiStoreWithVersion(version int64) (CacheMultiStore, error)
+
+	// AddListener adds a listener of the changes written into the stores
+	// cache-wrapped by CacheMultiStore, which is notified of the changes of
+	// each version once the version is persisted.
+	AddListener(listener StateListener)
+
+	// SetInterBlockCache caches up to size keys of a mounted IAVL store
+	// across blocks, between the store and the caches of CacheMultiStore.
+	// A size of 0 disables the cache.
+	SetInterBlockCache(key StoreKey, size int)
+
+	// InterBlockCacheMetrics returns the metrics of the inter-block caches,
+	// by store name.
+	InterBlockCacheMetrics() map[string]InterBlockCacheMetrics
+}
+
+// InterBlockCacheMetrics are the hits and misses of the inter-block cache of a
+// store, counted since the store was loaded.
+type InterBlockCacheMetrics struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+	Size   int   `json:"size"` // number of cached keys
 }
 
 //---------subsp-------------------------------
@@ -280,6 +353,13 @@ func (key *KVStoreKey) String() string {
 	return fmt.Sprintf("KVStoreKey{%p, %s}", key, key.name)
 }
 
+// InclusiveEndBytes returns the []byte that would end a
+// range query such that the input would be included
+func InclusiveEndBytes(inclusiveBytes []byte) (exclusiveBytes []byte) {
+	exclusiveBytes = append(inclusiveBytes, byte(0x00))
+	return exclusiveBytes
+}
+
 // PrefixEndBytes returns the []byte that would end a
 // range query for all []byte with a certain prefix
 // Deals with last byte of prefix being FF without overflowing
@@ -339,3 +419,31 @@ type KVPair cmn.KVPair
 // TraceContext contains TraceKVStore context data. It will be written with
 // every trace operation.
 type TraceContext map[string]interface{}
+
+//----------------------------------------
+
+// StoreChange is a write or a delete of a key of a store, the value of a delete
+// being nil. The changes written by a tx hold the hex-encoded hash of the tx.
+type StoreChange struct {
+	StoreName string `json:"store_name"`
+	TxHash    string `json:"tx_hash,omitempty"`
+	Key       []byte `json:"key"`
+	Value     []byte `json:"value,omitempty"`
+	Delete    bool   `json:"delete,omitempty"`
+}
+
+// ChangeSet holds the changes of the state committed at a height, in the order
+// they were written.
+type ChangeSet struct {
+	Height  int64         `json:"height"`
+	Changes []StoreChange `json:"changes"`
+}
+
+// StateListener is notified of the changes of the state of each block.
+type StateListener interface {
+	// OnCommit is called once the changes are committed and persisted. An
+	// error halts the node, and the changes are notified again when the
+	// latest version is loaded after a restart, as they are if the node
+	// stops before the listeners are notified.
+	OnCommit(changeSet ChangeSet) error
+}
@@ -37,3 +37,20 @@ func TestCommitID(t *testing.T) {
 	}
 	require.False(t, nonempty.IsZero())
 }
+
+func TestPruningStrategy(t *testing.T) {
+	require.Nil(t, PruneSyncable.Validate())
+	require.NotNil(t, NewPruningStrategy(-1, 0, 1).Validate())
+	require.NotNil(t, NewPruningStrategy(0, 0, -1).Validate())
+
+	require.True(t, PruneEverything.Prunes())
+	require.False(t, PruneNothing.Prunes())
+	require.False(t, NewPruningStrategy(0, 0, 0).Prunes())
+
+	strategy := NewPruningStrategy(2, 3, 1)
+	for version, kept := range map[int64]bool{5: false, 6: true, 7: false, 8: true, 9: true, 10: true} {
+		require.Equal(t, kept, strategy.Keeps(version, 10), "version %d", version)
+	}
+	require.True(t, PruneNothing.Keeps(1, 10))
+	require.False(t, PruneEverything.Keeps(9, 10))
+}
@@ -57,6 +57,7 @@ func MakeTag(k string, v []byte) Tag {
 // common tags
 var (
 	TagAction       = "action"
+	TagPriority     = "priority"
 	TagSrcValidator = "source-validator"
 	TagDstValidator = "destination-validator"
 	TagDelegator    = "delegator"
@@ -2,6 +2,8 @@ package types
 
 import (
 	"encoding/json"
+	"time"
+
 	tcmd "github.com/tendermint/tendermint/cmd/tendermint/commands"
 	tmtypes "github.com/tendermint/tendermint/types"
 )
@@ -34,6 +36,24 @@ func MustSortJSON(toSortJSON []byte) []byte {
 	return js
 }
 
+// Slight modification of the RFC3339Nano but it right pads all zeros and drops the time zone info
+const SortableTimeFormat = "2006-01-02T15:04:05.000000000"
+
+// Formats a time.Time into a []byte that can be sorted
+func FormatTimeBytes(t time.Time) []byte {
+	return []byte(t.UTC().Round(0).Format(SortableTimeFormat))
+}
+
+// Parses a []byte encoded using FormatTimeKey back into a time.Time
+func ParseTimeBytes(bz []byte) (time.Time, error) {
+	str := string(bz)
+	t, err := time.Parse(SortableTimeFormat, str)
+	if err != nil {
+		return t, err
+	}
+	return t.UTC().Round(0), nil
+}
+
 // DefaultChainID returns the chain ID from the genesis file if present. An
 // error is returned if the file cannot be read or parsed.
 //
@@ -4,129 +4,385 @@ import (
 	"bytes"
 	"encoding/hex"
 	"fmt"
+	"math"
+	"time"
 
+	"github.com/cosmos/cosmos-sdk/crypto/multisig"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
 )
 
 const (
-	deductFeesCost        sdk.Gas = 10
-	memoCostPerByte       sdk.Gas = 1
-	ed25519VerifyCost             = 59
-	secp256k1VerifyCost           = 100
-	maxMemoCharacters             = 100
-	feeDeductionGasFactor         = 0.001
+	maxMemoCharacters     = 100
+	feeDeductionGasFactor = 0.001
+	gasPricePrecision     = 1000000 // gas prices are per million units of gas
 )
 
 // NewAnteHandler returns an AnteHandler that checks
 // and increments sequence numbers, checks signatures & account numbers,
 // and deducts fees from the first signer.
+// The txs are charged the default gas schedule.
 func NewAnteHandler(am AccountMapper, fck FeeCollectionKeeper) sdk.AnteHandler {
+	return sdk.ChainAnteDecorators(NewAnteDecorators(am, fck)...)
+}
 
-	return func(
-		ctx sdk.Context, tx sdk.Tx, simulate bool,
-	) (newCtx sdk.Context, res sdk.Result, abort bool) {
+// NewAnteHandlerWithParams returns the AnteHandler of NewAnteHandler, which
+// charges the txs the gas schedule of the params.
+func NewAnteHandlerWithParams(am AccountMapper, fck FeeCollectionKeeper, paramsGetter params.Getter) sdk.AnteHandler {
+	return sdk.ChainAnteDecorators(NewAnteDecoratorsWithParams(am, fck, paramsGetter)...)
+}
 
-		// This AnteHandler requires Txs to be StdTxs
-		stdTx, ok := tx.(StdTx)
-		if !ok {
-			return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
-		}
+// NewAnteDecorators returns the decorators of NewAnteHandler in order, so
+// that apps can insert their own steps into the chain.
+func NewAnteDecorators(am AccountMapper, fck FeeCollectionKeeper) []sdk.AnteDecorator {
+	return newAnteDecorators(am, NewSetUpContextDecorator(), NewDeductFeeDecorator(fck))
+}
 
-		// set the gas meter
-		if simulate {
-			newCtx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
-		} else {
-			newCtx = ctx.WithGasMeter(sdk.NewGasMeter(stdTx.Fee.Gas))
-		}
+// NewAnteDecoratorsWithParams returns the decorators of
+// NewAnteHandlerWithParams in order.
+func NewAnteDecoratorsWithParams(am AccountMapper, fck FeeCollectionKeeper, paramsGetter params.Getter) []sdk.AnteDecorator {
+	return newAnteDecorators(am, NewSetUpContextDecoratorWithParams(paramsGetter), NewDeductFeeDecorator(fck))
+}
 
-		// AnteHandlers must have their own defer/recover in order
-		// for the BaseApp to know how much gas was used!
-		// This is because the GasMeter is created in the AnteHandler,
-		// but if it panics the context won't be set properly in runTx's recover ...
-		defer func() {
-			if r := recover(); r != nil {
-				switch rType := r.(type) {
-				case sdk.ErrorOutOfGas:
-					log := fmt.Sprintf("out of gas in location: %v", rType.Descriptor)
-					res = sdk.ErrOutOfGas(log).Result()
-					res.GasWanted = stdTx.Fee.Gas
-					res.GasUsed = newCtx.GasMeter().GasConsumed()
-					abort = true
-				default:
-					panic(r)
-				}
-			}
-		}()
+// NewAnteDecoratorsWithFeeGrants returns the decorators of
+// NewAnteDecoratorsWithParams, which charge the fees of the txs with a fee
+// granter to the allowance of the granter.
+func NewAnteDecoratorsWithFeeGrants(am AccountMapper, fck FeeCollectionKeeper, fgk FeeGrantKeeper, paramsGetter params.Getter) []sdk.AnteDecorator {
+	return newAnteDecorators(am, NewSetUpContextDecoratorWithParams(paramsGetter), NewDeductGrantedFeeDecorator(am, fck, fgk))
+}
+
+func newAnteDecorators(am AccountMapper, setUp SetUpContextDecorator, deductFee DeductFeeDecorator) []sdk.AnteDecorator {
+	return []sdk.AnteDecorator{
+		setUp,
+		NewValidateBasicDecorator(),
+		NewMemoGasDecorator(),
+		NewSigVerificationDecorator(am),
+		NewMinimumFeeDecorator(),
+		NewTxPriorityDecorator(),
+		deductFee,
+	}
+}
+
+//----------------------------------------
+// Decorators
 
-		err := validateBasic(stdTx)
-		if err != nil {
-			return newCtx, err.Result(), true
+// SetUpContextDecorator sets the gas meter of the tx and the gas schedule
+// charged to it, and turns the out of gas panics of the next decorators into
+// an ErrOutOfGas result. It must come first in the chain, as the decorators
+// below require StdTxs and read their gas schedule from the context.
+type SetUpContextDecorator struct {
+	getGasSchedule func(sdk.Context) GasSchedule
+}
+
+// NewSetUpContextDecorator returns a SetUpContextDecorator charging the
+// default gas schedule.
+func NewSetUpContextDecorator() SetUpContextDecorator {
+	return SetUpContextDecorator{func(sdk.Context) GasSchedule {
+		return DefaultGasSchedule()
+	}}
+}
+
+// NewSetUpContextDecoratorWithParams returns a SetUpContextDecorator charging
+// the gas schedule of the params.
+func NewSetUpContextDecoratorWithParams(paramsGetter params.Getter) SetUpContextDecorator {
+	return SetUpContextDecorator{func(ctx sdk.Context) GasSchedule {
+		return GetGasSchedule(ctx, paramsGetter)
+	}}
+}
+
+// AnteHandle implements sdk.AnteDecorator
+func (sud SetUpContextDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (newCtx sdk.Context, res sdk.Result, abort bool) {
+
+	// This AnteHandler requires Txs to be StdTxs
+	stdTx, ok := tx.(StdTx)
+	if !ok {
+		return ctx, sdk.ErrInternal("tx must be StdTx").Result(), true
+	}
+
+	// set the gas meter and the gas costs of the store operations
+	gasSchedule := sud.getGasSchedule(ctx)
+	if simulate {
+		newCtx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	} else {
+		newCtx = ctx.WithGasMeter(sdk.NewGasMeter(stdTx.Fee.Gas))
+	}
+	newCtx = newCtx.WithKVGasConfig(gasSchedule.KVStore).
+		WithTransientGasConfig(gasSchedule.TransientStore)
+	newCtx = withGasSchedule(newCtx, gasSchedule)
+
+	// AnteHandlers must have their own defer/recover in order
+	// for the BaseApp to know how much gas was used!
+	// This is because the GasMeter is created in the AnteHandler,
+	// but if it panics the context won't be set properly in runTx's recover ...
+	defer func() {
+		if r := recover(); r != nil {
+			switch rType := r.(type) {
+			case sdk.ErrorOutOfGas:
+				log := fmt.Sprintf("out of gas in location: %v", rType.Descriptor)
+				res = sdk.ErrOutOfGas(log).Result()
+				res.GasWanted = stdTx.Fee.Gas
+				res.GasUsed = newCtx.GasMeter().GasConsumed()
+				abort = true
+			default:
+				panic(r)
+			}
 		}
+	}()
 
-		sigs := stdTx.GetSignatures() // When simulating, this would just be a 0-length slice.
-		signerAddrs := stdTx.GetSigners()
-		msgs := tx.GetMsgs()
+	newCtx, res, abort = next(newCtx, tx, simulate)
+	if abort {
+		return newCtx, res, true
+	}
 
-		// charge gas for the memo
-		newCtx.GasMeter().ConsumeGas(memoCostPerByte*sdk.Gas(len(stdTx.GetMemo())), "memo")
+	// TODO: tx tags (?)
 
-		// Get the sign bytes (requires all account & sequence numbers and the fee)
-		sequences := make([]int64, len(sigs))
-		accNums := make([]int64, len(sigs))
-		for i := 0; i < len(sigs); i++ {
-			sequences[i] = sigs[i].Sequence
-			accNums[i] = sigs[i].AccountNumber
+	res.GasWanted = stdTx.Fee.Gas
+	return newCtx, res, false // continue...
+}
+
+// ValidateBasicDecorator checks the tx based on things that don't depend on
+// the context.
+type ValidateBasicDecorator struct{}
+
+// NewValidateBasicDecorator returns a ValidateBasicDecorator
+func NewValidateBasicDecorator() ValidateBasicDecorator {
+	return ValidateBasicDecorator{}
+}
+
+// AnteHandle implements sdk.AnteDecorator
+func (vbd ValidateBasicDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	err := validateBasic(tx.(StdTx))
+	if err != nil {
+		return ctx, err.Result(), true
+	}
+	return next(ctx, tx, simulate)
+}
+
+// MemoGasDecorator charges gas for each byte of the memo.
+type MemoGasDecorator struct{}
+
+// NewMemoGasDecorator returns a MemoGasDecorator
+func NewMemoGasDecorator() MemoGasDecorator {
+	return MemoGasDecorator{}
+}
+
+// AnteHandle implements sdk.AnteDecorator
+func (mgd MemoGasDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	memo := tx.(StdTx).GetMemo()
+	ctx.GasMeter().ConsumeGas(gasScheduleFromContext(ctx).MemoCostPerByte*sdk.Gas(len(memo)), "memo")
+	return next(ctx, tx, simulate)
+}
+
+// SigVerificationDecorator checks the signatures and account numbers of the
+// signers, increments their sequence numbers and caches their accounts in the
+// context. The accounts are saved once the next decorators return, so that
+// these update them through the signers of the context, as the
+// DeductFeeDecorator does.
+type SigVerificationDecorator struct {
+	am AccountMapper
+}
+
+// NewSigVerificationDecorator returns a SigVerificationDecorator
+func NewSigVerificationDecorator(am AccountMapper) SigVerificationDecorator {
+	return SigVerificationDecorator{am}
+}
+
+// AnteHandle implements sdk.AnteDecorator
+func (svd SigVerificationDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	stdTx := tx.(StdTx)
+	gasSchedule := gasScheduleFromContext(ctx)
+
+	sigs := stdTx.GetSignatures() // When simulating, this would just be a 0-length slice.
+	signerAddrs := stdTx.GetSigners()
+	msgs := tx.GetMsgs()
+
+	// Get the sign bytes (requires all account & sequence numbers and the fee)
+	sequences := make([]int64, len(sigs))
+	accNums := make([]int64, len(sigs))
+	for i := 0; i < len(sigs); i++ {
+		sequences[i] = sigs[i].Sequence
+		accNums[i] = sigs[i].AccountNumber
+	}
+	fee := stdTx.Fee
+
+	// Check sig and nonce and collect signer accounts.
+	var signerAccs = make([]Account, len(signerAddrs))
+	for i := 0; i < len(sigs); i++ {
+		signerAddr, sig := signerAddrs[i], sigs[i]
+
+		// check signature, return account with incremented nonce
+		signBytes := StdSignBytes(ctx.ChainID(), accNums[i], sequences[i], fee, msgs, stdTx.GetMemo())
+		signerAcc, res := processSig(ctx, svd.am, signerAddr, sig, signBytes, simulate, gasSchedule)
+		if !res.IsOK() {
+			return ctx, res, true
 		}
-		fee := stdTx.Fee
-
-		// Check sig and nonce and collect signer accounts.
-		var signerAccs = make([]Account, len(signerAddrs))
-		for i := 0; i < len(sigs); i++ {
-			signerAddr, sig := signerAddrs[i], sigs[i]
-
-			// check signature, return account with incremented nonce
-			signBytes := StdSignBytes(newCtx.ChainID(), accNums[i], sequences[i], fee, msgs, stdTx.GetMemo())
-			signerAcc, res := processSig(newCtx, am, signerAddr, sig, signBytes, simulate)
-			if !res.IsOK() {
-				return newCtx, res, true
-			}
+		signerAccs[i] = signerAcc
+	}
 
-			requiredFees := adjustFeesByGas(ctx.MinimumFees(), fee.Gas)
-			// fees must be greater than the minimum set by the validator adjusted by gas
-			if ctx.IsCheckTx() && !simulate && !ctx.MinimumFees().IsZero() && fee.Amount.IsLT(requiredFees) {
-				// validators reject any tx from the mempool with less than the minimum fee per gas * gas factor
-				return newCtx, sdk.ErrInsufficientFee(fmt.Sprintf(
-					"insufficient fee, got: %q required: %q", fee.Amount, requiredFees)).Result(), true
-			}
+	// cache the signer accounts in the context
+	newCtx, res, abort := next(WithSigners(ctx, signerAccs), tx, simulate)
+	if abort {
+		return newCtx, res, true
+	}
 
-			// first sig pays the fees
-			// Can this function be moved outside of the loop?
-			if i == 0 && !fee.Amount.IsZero() {
-				newCtx.GasMeter().ConsumeGas(deductFeesCost, "deductFees")
-				signerAcc, res = deductFees(signerAcc, fee)
-				if !res.IsOK() {
-					return newCtx, res, true
-				}
-				fck.addCollectedFees(newCtx, fee.Amount)
-			}
+	// Save the accounts.
+	for _, signerAcc := range GetSigners(newCtx) {
+		svd.am.SetAccount(newCtx, signerAcc)
+	}
+	return newCtx, res, false
+}
 
-			// Save the account.
-			am.SetAccount(newCtx, signerAcc)
-			signerAccs[i] = signerAcc
+// MinimumFeeDecorator rejects from the mempool the txs paying less than the
+// minimum fees set by the validator adjusted by gas.
+type MinimumFeeDecorator struct{}
+
+// NewMinimumFeeDecorator returns a MinimumFeeDecorator
+func NewMinimumFeeDecorator() MinimumFeeDecorator {
+	return MinimumFeeDecorator{}
+}
+
+// AnteHandle implements sdk.AnteDecorator
+func (mfd MinimumFeeDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	fee := tx.(StdTx).Fee
+	requiredFees := adjustFeesByGas(ctx.MinimumFees(), fee.Gas)
+	// fees must be greater than the minimum set by the validator adjusted by gas
+	if ctx.IsCheckTx() && !simulate && !ctx.MinimumFees().IsZero() && fee.Amount.IsLT(requiredFees) {
+		// validators reject any tx from the mempool with less than the minimum fee per gas * gas factor
+		return ctx, sdk.ErrInsufficientFee(fmt.Sprintf(
+			"insufficient fee, got: %q required: %q", fee.Amount, requiredFees)).Result(), true
+	}
+	return next(ctx, tx, simulate)
+}
+
+// TxPriorityDecorator sets the priority of the tx in the mempool to its gas
+// price, so that the txs paying more per unit of gas get in first.
+type TxPriorityDecorator struct{}
+
+// NewTxPriorityDecorator returns a TxPriorityDecorator
+func NewTxPriorityDecorator() TxPriorityDecorator {
+	return TxPriorityDecorator{}
+}
+
+// AnteHandle implements sdk.AnteDecorator
+func (tpd TxPriorityDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	newCtx, res, abort := next(ctx, tx, simulate)
+	if abort {
+		return newCtx, res, true
+	}
+	res.Priority = TxPriority(tx.(StdTx).Fee)
+	return newCtx, res, false
+}
+
+// FeeGrantKeeper charges the fees of a tx to the allowance a granter gave to
+// the fee payer of the tx
+type FeeGrantKeeper interface {
+	UseGrantedFees(ctx sdk.Context, granter, grantee sdk.AccAddress, fee sdk.Coins, msgs []sdk.Msg) sdk.Error
+}
+
+// DeductFeeDecorator deducts the fees from the first signer and adds them to
+// the collected fees. It must follow the SigVerificationDecorator, which
+// caches the signer accounts in the context and saves them.
+type DeductFeeDecorator struct {
+	am  AccountMapper
+	fck FeeCollectionKeeper
+	fgk FeeGrantKeeper // nil if the fees cannot be granted
+}
+
+// NewDeductFeeDecorator returns a DeductFeeDecorator, which rejects the txs
+// with a fee granter.
+func NewDeductFeeDecorator(fck FeeCollectionKeeper) DeductFeeDecorator {
+	return DeductFeeDecorator{fck: fck}
+}
+
+// NewDeductGrantedFeeDecorator returns a DeductFeeDecorator, which deducts the
+// fees of the txs with a fee granter from the granter once charged to the
+// allowance it gave to the first signer.
+func NewDeductGrantedFeeDecorator(am AccountMapper, fck FeeCollectionKeeper, fgk FeeGrantKeeper) DeductFeeDecorator {
+	return DeductFeeDecorator{am, fck, fgk}
+}
+
+// AnteHandle implements sdk.AnteDecorator
+func (dfd DeductFeeDecorator) AnteHandle(
+	ctx sdk.Context, tx sdk.Tx, simulate bool, next sdk.AnteHandler,
+) (sdk.Context, sdk.Result, bool) {
+	fee := tx.(StdTx).Fee
+	if fee.Amount.IsZero() {
+		return next(ctx, tx, simulate)
+	}
+	signerAccs := GetSigners(ctx)
+	if len(signerAccs) == 0 {
+		return ctx, sdk.ErrInternal("no signer accounts in the context to deduct the fees from").Result(), true
+	}
+	if len(fee.Granter) != 0 {
+		res := dfd.deductGrantedFees(ctx, fee, signerAccs, tx.GetMsgs())
+		if !res.IsOK() {
+			return ctx, res, true
 		}
+		return next(ctx, tx, simulate)
+	}
 
-		// cache the signer accounts in the context
-		newCtx = WithSigners(newCtx, signerAccs)
+	// first sig pays the fees
+	ctx.GasMeter().ConsumeGas(gasScheduleFromContext(ctx).DeductFeesCost, "deductFees")
+	payer, res := deductFees(ctx.BlockHeader().Time, signerAccs[0], fee)
+	if !res.IsOK() {
+		return ctx, res, true
+	}
+	dfd.fck.AddCollectedFees(ctx, fee.Amount)
+
+	signerAccs = append([]Account{payer}, signerAccs[1:]...)
+	return next(WithSigners(ctx, signerAccs), tx, simulate)
+}
 
-		// TODO: tx tags (?)
+// charge the fees to the allowance the granter gave to the first signer, and
+// deduct them from the granter
+func (dfd DeductFeeDecorator) deductGrantedFees(ctx sdk.Context, fee StdFee, signerAccs []Account, msgs []sdk.Msg) sdk.Result {
+	if dfd.fgk == nil {
+		return sdk.ErrUnauthorized("fee grants are not supported").Result()
+	}
+	// the signer accounts cached in the context are saved once the next
+	// decorators return, which would undo the deduction from a signing granter
+	for _, signerAcc := range signerAccs {
+		if bytes.Equal(signerAcc.GetAddress(), fee.Granter) {
+			return sdk.ErrUnauthorized("the fee granter cannot sign the tx").Result()
+		}
+	}
+	err := dfd.fgk.UseGrantedFees(ctx, fee.Granter, signerAccs[0].GetAddress(), fee.Amount, msgs)
+	if err != nil {
+		return err.Result()
+	}
 
-		return newCtx, sdk.Result{GasWanted: stdTx.Fee.Gas}, false // continue...
+	granterAcc := dfd.am.GetAccount(ctx, fee.Granter)
+	if granterAcc == nil {
+		return sdk.ErrUnknownAddress(fee.Granter.String()).Result()
 	}
+	ctx.GasMeter().ConsumeGas(gasScheduleFromContext(ctx).DeductFeesCost, "deductFees")
+	granterAcc, res := deductFees(ctx.BlockHeader().Time, granterAcc, fee)
+	if !res.IsOK() {
+		return res
+	}
+	dfd.am.SetAccount(ctx, granterAcc)
+	dfd.fck.AddCollectedFees(ctx, fee.Amount)
+	return sdk.Result{}
 }
 
+//----------------------------------------
+// Helpers
+
 // Validate the transaction based on things that don't depend on the context
 func validateBasic(tx StdTx) (err sdk.Error) {
 	// Assert that there are signatures.
@@ -154,7 +410,7 @@ func validateBasic(tx StdTx) (err sdk.Error) {
 // if the account doesn't have a pubkey, set it.
 func processSig(
 	ctx sdk.Context, am AccountMapper,
-	addr sdk.AccAddress, sig StdSignature, signBytes []byte, simulate bool) (
+	addr sdk.AccAddress, sig StdSignature, signBytes []byte, simulate bool, gasSchedule GasSchedule) (
 	acc Account, res sdk.Result) {
 	// Get the account.
 	acc = am.GetAccount(ctx, addr)
@@ -190,7 +446,7 @@ func processSig(
 		return nil, sdk.ErrInternal("setting PubKey on signer's account").Result()
 	}
 
-	consumeSignatureVerificationGas(ctx.GasMeter(), pubKey)
+	consumeSignatureVerificationGas(ctx.GasMeter(), sig.Signature, pubKey, gasSchedule)
 	if !simulate && !pubKey.VerifyBytes(signBytes, sig.Signature) {
 		return nil, sdk.ErrUnauthorized("signature verification failed").Result()
 	}
@@ -232,12 +488,25 @@ func processPubKey(acc Account, sig StdSignature, simulate bool) (crypto.PubKey,
 	return pubKey, sdk.Result{}
 }
 
-func consumeSignatureVerificationGas(meter sdk.GasMeter, pubkey crypto.PubKey) {
-	switch pubkey.(type) {
+// consume the gas for verifying the signature with the public key. Threshold
+// public keys are charged for every sub-signature, or for all of their keys if
+// the signature cannot be decoded, e.g. when simulating.
+func consumeSignatureVerificationGas(meter sdk.GasMeter, sig []byte, pubkey crypto.PubKey, gasSchedule GasSchedule) {
+	switch pubkey := pubkey.(type) {
 	case ed25519.PubKeyEd25519:
-		meter.ConsumeGas(ed25519VerifyCost, "ante verify: ed25519")
+		meter.ConsumeGas(gasSchedule.Ed25519VerifyCost, "ante verify: ed25519")
 	case secp256k1.PubKeySecp256k1:
-		meter.ConsumeGas(secp256k1VerifyCost, "ante verify: secp256k1")
+		meter.ConsumeGas(gasSchedule.Secp256k1VerifyCost, "ante verify: secp256k1")
+	case multisig.PubKeyMultisigThreshold:
+		mSig, err := multisig.UnmarshalMultisignature(sig)
+		chargeAll := err != nil || len(mSig.Sigs) != len(pubkey.PubKeys)
+		for i, subKey := range pubkey.PubKeys {
+			if chargeAll {
+				consumeSignatureVerificationGas(meter, nil, subKey, gasSchedule)
+			} else if len(mSig.Sigs[i]) != 0 {
+				consumeSignatureVerificationGas(meter, mSig.Sigs[i], subKey, gasSchedule)
+			}
+		}
 	default:
 		panic("Unrecognized signature type")
 	}
@@ -252,10 +521,33 @@ func adjustFeesByGas(fees sdk.Coins, gas int64) sdk.Coins {
 	return fees.Plus(gasFees)
 }
 
+// TxPriority returns the effective gas price of the fee, i.e. the amount paid
+// per million units of gas wanted. The price of a fee of several denominations
+// is the lowest price among them, so that no denomination raises it.
+func TxPriority(fee StdFee) int64 {
+	if fee.Gas <= 0 || len(fee.Amount) == 0 {
+		return 0
+	}
+	var priority sdk.Int
+	for i, coin := range fee.Amount {
+		price := coin.Amount.MulRaw(gasPricePrecision).DivRaw(fee.Gas)
+		if i == 0 || price.LT(priority) {
+			priority = price
+		}
+	}
+	if priority.Sign() < 0 {
+		return 0
+	}
+	if !priority.IsInt64() {
+		return math.MaxInt64
+	}
+	return priority.Int64()
+}
+
 // Deduct the fee from the account.
 // We could use the CoinKeeper (in addition to the AccountMapper,
 // because the CoinKeeper doesn't give us accounts), but it seems easier to do this.
-func deductFees(acc Account, fee StdFee) (Account, sdk.Result) {
+func deductFees(blockTime time.Time, acc Account, fee StdFee) (Account, sdk.Result) {
 	coins := acc.GetCoins()
 	feeAmount := fee.Amount
 
@@ -264,6 +556,15 @@ func deductFees(acc Account, fee StdFee) (Account, sdk.Result) {
 		errMsg := fmt.Sprintf("%s < %s", coins, feeAmount)
 		return nil, sdk.ErrInsufficientFunds(errMsg).Result()
 	}
+
+	// fees cannot be paid with the locked coins of a vesting account
+	if vacc, ok := acc.(VestingAccount); ok {
+		spendableCoins := vacc.SpendableCoins(blockTime)
+		if !spendableCoins.IsGTE(feeAmount) {
+			errMsg := fmt.Sprintf("%s < %s", spendableCoins, feeAmount)
+			return nil, sdk.ErrInsufficientFunds(errMsg).Result()
+		}
+	}
 	err := acc.SetCoins(newCoins)
 	if err != nil {
 		// Handle w/ #870
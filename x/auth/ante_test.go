@@ -2,15 +2,20 @@ package auth
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
 	codec "github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/multisig"
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/stretchr/testify/require"
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	"github.com/tendermint/tendermint/crypto/secp256k1"
+	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
 )
 
@@ -338,6 +343,80 @@ func TestAnteHandlerFees(t *testing.T) {
 	require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{sdk.NewInt64Coin("atom", 150)}))
 }
 
+// fee grant keeper granting fee allowances without any limit
+type testFeeGrantKeeper map[string]bool
+
+func (fgk testFeeGrantKeeper) UseGrantedFees(ctx sdk.Context, granter, grantee sdk.AccAddress, fee sdk.Coins, msgs []sdk.Msg) sdk.Error {
+	if !fgk[granter.String()+grantee.String()] {
+		return sdk.ErrUnauthorized("no fee allowance")
+	}
+	return nil
+}
+
+// Test logic around granted fee deduction.
+func TestAnteHandlerGrantedFees(t *testing.T) {
+	// setup
+	db := dbm.NewMemDB()
+	capKey, capKey2, paramsKey := sdk.NewKVStoreKey("capkey"), sdk.NewKVStoreKey("capkey2"), sdk.NewKVStoreKey("params")
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(capKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(capKey2, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(paramsKey, sdk.StoreTypeIAVL, db)
+	require.Nil(t, ms.LoadLatestVersion())
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, ProtoBaseAccount)
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	paramsKeeper := params.NewKeeper(cdc, paramsKey)
+	feeGrantKeeper := testFeeGrantKeeper{}
+	anteHandler := sdk.ChainAnteDecorators(NewAnteDecoratorsWithFeeGrants(mapper, feeCollector, feeGrantKeeper, paramsKeeper.Getter())...)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+
+	// keys and addresses
+	priv1, addr1 := privAndAddr()
+	priv2, addr2 := privAndAddr()
+
+	// set the accounts, the grantee having no funds
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	mapper.SetAccount(ctx, acc1)
+	acc2 := mapper.NewAccountWithAddress(ctx, addr2)
+	acc2.SetCoins(sdk.Coins{sdk.NewInt64Coin("atom", 200)})
+	mapper.SetAccount(ctx, acc2)
+
+	// msg and signatures
+	var tx sdk.Tx
+	msg := newTestMsg(addr1)
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
+	fee := newStdFee()
+	fee.Granter = addr2
+	msgs := []sdk.Msg{msg}
+
+	// the granter gave no fee allowance
+	tx = newTestTx(ctx, msgs, privs, accnums, seqs, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeUnauthorized)
+
+	// the fees are deducted from the granter
+	feeGrantKeeper[addr2.String()+addr1.String()] = true
+	checkValidTx(t, anteHandler, ctx, tx, false)
+	require.True(t, mapper.GetAccount(ctx, addr1).GetCoins().IsZero())
+	require.Equal(t, int64(1), mapper.GetAccount(ctx, addr1).GetSequence())
+	require.True(t, mapper.GetAccount(ctx, addr2).GetCoins().IsEqual(sdk.Coins{sdk.NewInt64Coin("atom", 50)}))
+	require.True(t, feeCollector.GetCollectedFees(ctx).IsEqual(sdk.Coins{sdk.NewInt64Coin("atom", 150)}))
+
+	// the granter does not have enough funds to pay the fee
+	tx = newTestTx(ctx, msgs, privs, accnums, []int64{1}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeInsufficientFunds)
+
+	// the granter cannot sign the tx
+	feeGrantKeeper[addr2.String()+addr2.String()] = true
+	tx = newTestTx(ctx, []sdk.Msg{newTestMsg(addr2)}, []crypto.PrivKey{priv2}, []int64{1}, []int64{0}, fee)
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeUnauthorized)
+
+	// the default chain does not support fee grants
+	tx = newTestTx(ctx, msgs, privs, accnums, []int64{1}, fee)
+	checkInvalidTx(t, NewAnteHandler(mapper, feeCollector), ctx, tx, false, sdk.CodeUnauthorized)
+}
+
 // Test logic around memo gas consumption.
 func TestAnteHandlerMemoGas(t *testing.T) {
 	// setup
@@ -602,8 +681,22 @@ func TestProcessPubKey(t *testing.T) {
 }
 
 func TestConsumeSignatureVerificationGas(t *testing.T) {
+	msg := []byte("message")
+	privs := []crypto.PrivKey{ed25519.GenPrivKey(), secp256k1.GenPrivKey(), secp256k1.GenPrivKey()}
+	pubs := []crypto.PubKey{privs[0].PubKey(), privs[1].PubKey(), privs[2].PubKey()}
+	multisigKey := multisig.NewPubKeyMultisigThreshold(2, pubs)
+	mSig := multisig.NewMultisig(len(pubs))
+	for i := 0; i < 2; i++ {
+		sig, err := privs[i].Sign(msg)
+		require.Nil(t, err)
+		mSig.AddSignature(sig, i)
+	}
+	gs := DefaultGasSchedule()
+	ed25519VerifyCost, secp256k1VerifyCost := gs.Ed25519VerifyCost, gs.Secp256k1VerifyCost
+
 	type args struct {
 		meter  sdk.GasMeter
+		sig    []byte
 		pubkey crypto.PubKey
 	}
 	tests := []struct {
@@ -612,22 +705,72 @@ func TestConsumeSignatureVerificationGas(t *testing.T) {
 		gasConsumed int64
 		wantPanic   bool
 	}{
-		{"PubKeyEd25519", args{sdk.NewInfiniteGasMeter(), ed25519.GenPrivKey().PubKey()}, ed25519VerifyCost, false},
-		{"PubKeySecp256k1", args{sdk.NewInfiniteGasMeter(), secp256k1.GenPrivKey().PubKey()}, secp256k1VerifyCost, false},
-		{"unknown key", args{sdk.NewInfiniteGasMeter(), nil}, 0, true},
+		{"PubKeyEd25519", args{sdk.NewInfiniteGasMeter(), nil, ed25519.GenPrivKey().PubKey()}, ed25519VerifyCost, false},
+		{"PubKeySecp256k1", args{sdk.NewInfiniteGasMeter(), nil, secp256k1.GenPrivKey().PubKey()}, secp256k1VerifyCost, false},
+		{"Multisig", args{sdk.NewInfiniteGasMeter(), mSig.Marshal(), multisigKey}, ed25519VerifyCost + secp256k1VerifyCost, false},
+		{"Multisig without signature", args{sdk.NewInfiniteGasMeter(), nil, multisigKey}, ed25519VerifyCost + 2*secp256k1VerifyCost, false},
+		{"unknown key", args{sdk.NewInfiniteGasMeter(), nil, nil}, 0, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			if tt.wantPanic {
-				require.Panics(t, func() { consumeSignatureVerificationGas(tt.args.meter, tt.args.pubkey) })
+				require.Panics(t, func() { consumeSignatureVerificationGas(tt.args.meter, tt.args.sig, tt.args.pubkey, gs) })
 			} else {
-				consumeSignatureVerificationGas(tt.args.meter, tt.args.pubkey)
+				consumeSignatureVerificationGas(tt.args.meter, tt.args.sig, tt.args.pubkey, gs)
 				require.Equal(t, tt.args.meter.GasConsumed(), tt.gasConsumed)
 			}
 		})
 	}
 }
 
+// Test accounts controlled by a threshold public key
+func TestAnteHandlerMultisig(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, ProtoBaseAccount)
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	anteHandler := NewAnteHandler(mapper, feeCollector)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+
+	// 2 of 3 multisig account
+	priv1, _ := privAndAddr()
+	priv2, _ := privAndAddr()
+	priv3, _ := privAndAddr()
+	pubs := []crypto.PubKey{priv1.PubKey(), priv2.PubKey(), priv3.PubKey()}
+	multisigKey := multisig.NewPubKeyMultisigThreshold(2, pubs)
+	addr := sdk.AccAddress(multisigKey.Address())
+
+	acc := mapper.NewAccountWithAddress(ctx, addr)
+	acc.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc)
+
+	msgs := []sdk.Msg{newTestMsg(addr)}
+	fee := newStdFee()
+	newMultisigTx := func(seq int64, signers ...crypto.PrivKey) sdk.Tx {
+		signBytes := StdSignBytes(ctx.ChainID(), 0, seq, fee, msgs, "")
+		mSig := multisig.NewMultisig(len(pubs))
+		for _, priv := range signers {
+			sig, err := priv.Sign(signBytes)
+			require.Nil(t, err)
+			require.Nil(t, mSig.AddSignatureFromPubKey(sig, priv.PubKey(), pubs))
+		}
+		sigs := []StdSignature{{PubKey: multisigKey, Signature: mSig.Marshal(), AccountNumber: 0, Sequence: seq}}
+		return NewStdTx(msgs, fee, sigs, "")
+	}
+
+	// signatures below the threshold are rejected
+	checkInvalidTx(t, anteHandler, ctx, newMultisigTx(0, priv1), false, sdk.CodeUnauthorized)
+
+	checkValidTx(t, anteHandler, ctx, newMultisigTx(0, priv1, priv3), false)
+	acc = mapper.GetAccount(ctx, addr)
+	require.True(t, multisigKey.Equals(acc.GetPubKey()))
+
+	checkValidTx(t, anteHandler, ctx, newMultisigTx(1, priv1, priv2, priv3), false)
+	checkInvalidTx(t, anteHandler, ctx, newMultisigTx(2, priv2), false, sdk.CodeUnauthorized)
+}
+
 func TestAdjustFeesByGas(t *testing.T) {
 	type args struct {
 		fee sdk.Coins
@@ -648,3 +791,66 @@ func TestAdjustFeesByGas(t *testing.T) {
 		})
 	}
 }
+
+// Test a step inserted into the decorators of the AnteHandler.
+func TestAnteDecoratorsInsertion(t *testing.T) {
+	// setup
+	ms, capKey, capKey2 := setupMultiStore()
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, ProtoBaseAccount)
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+
+	// a step rejecting the txs with a memo, after the fees are deducted
+	rejectMemo := func(ctx sdk.Context, tx sdk.Tx, _ bool) (sdk.Context, sdk.Result, bool) {
+		if tx.(StdTx).GetMemo() != "" {
+			return ctx, sdk.ErrUnauthorized("memo").Result(), true
+		}
+		return ctx, sdk.Result{}, false
+	}
+	anteHandler := sdk.ChainAnteDecorators(append(NewAnteDecorators(mapper, feeCollector), sdk.WrapAnteHandler(rejectMemo))...)
+
+	priv1, addr1 := privAndAddr()
+	acc1 := mapper.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(newCoins())
+	mapper.SetAccount(ctx, acc1)
+
+	msgs := []sdk.Msg{newTestMsg(addr1)}
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []int64{0}, []int64{0}
+	fee := newStdFee()
+
+	tx := newTestTxWithMemo(ctx, msgs, privs, accnums, seqs, fee, "memo")
+	checkInvalidTx(t, anteHandler, ctx, tx, false, sdk.CodeUnauthorized)
+	require.Equal(t, int64(0), mapper.GetAccount(ctx, addr1).GetSequence())
+
+	// the signer is saved with the fees deducted once all the steps passed
+	tx = newTestTx(ctx, msgs, privs, accnums, seqs, fee)
+	newCtx, result, abort := anteHandler(ctx, tx, false)
+	require.False(t, abort, result.Log)
+	require.Equal(t, fee.Gas, result.GasWanted)
+	require.Equal(t, int64(30000), result.Priority)
+	acc1 = mapper.GetAccount(ctx, addr1)
+	require.Equal(t, int64(1), acc1.GetSequence())
+	require.True(t, newCoins().Minus(fee.Amount).IsEqual(acc1.GetCoins()))
+	require.Equal(t, acc1.GetCoins(), GetSigners(newCtx)[0].GetCoins())
+}
+
+func TestTxPriority(t *testing.T) {
+	tests := []struct {
+		name string
+		fee  StdFee
+		want int64
+	}{
+		{"no fee", NewStdFee(10000), 0},
+		{"no gas", NewStdFee(0, sdk.NewInt64Coin("atom", 10)), 0},
+		{"fraction of a coin per gas", NewStdFee(200000, sdk.NewInt64Coin("atom", 50)), 250},
+		{"lowest price of the denoms", NewStdFee(1000, sdk.NewInt64Coin("atom", 5), sdk.NewInt64Coin("photon", 2)), 2000},
+		{"overflow", NewStdFee(1, sdk.NewInt64Coin("atom", math.MaxInt64)), math.MaxInt64},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, TxPriority(tt.fee))
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	amino "github.com/tendermint/go-amino"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/crypto/multisig"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// GetMultiSignCommand returns the multisign command
+func GetMultiSignCommand(codec *amino.Codec, decoder auth.AccountDecoder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "multisign <file> <name> [<signature>...]",
+		Short: "Assemble the signature of a multisig key",
+		Long: `Sign transactions created with the --generate-only flag on behalf of a multisig key.
+Read a transaction from <file> and the signatures of the keys of the multisig key
+<name> from the <signature> files, as printed by the sign command with the
+--multisig flag. Combine the signatures into a multisignature, append it to the
+transaction, and print its JSON encoding.`,
+		RunE: makeMultiSignCmd(codec, decoder),
+		Args: cobra.MinimumNArgs(3),
+	}
+	return cmd
+}
+
+func makeMultiSignCmd(cdc *amino.Codec, decoder auth.AccountDecoder) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) (err error) {
+		stdTx, err := readAndUnmarshalStdTx(cdc, args[0])
+		if err != nil {
+			return
+		}
+
+		kb, err := keys.GetKeyBase()
+		if err != nil {
+			return
+		}
+		info, err := kb.Get(args[1])
+		if err != nil {
+			return
+		}
+		multisigPub, ok := info.GetPubKey().(multisig.PubKeyMultisigThreshold)
+		if !ok {
+			return fmt.Errorf("%s is not a multisig key", args[1])
+		}
+
+		chainID := viper.GetString(client.FlagChainID)
+		multisigSig := multisig.NewMultisig(len(multisigPub.PubKeys))
+		var accnum, sequence int64
+		for i, filename := range args[2:] {
+			sig, err := readAndUnmarshalStdSignature(cdc, filename)
+			if err != nil {
+				return err
+			}
+			if i == 0 {
+				accnum, sequence = sig.AccountNumber, sig.Sequence
+			} else if sig.AccountNumber != accnum || sig.Sequence != sequence {
+				return fmt.Errorf("signature %s does not match the account number and sequence of the other signatures", filename)
+			}
+
+			signBytes := auth.StdSignBytes(chainID, accnum, sequence, stdTx.Fee, stdTx.GetMsgs(), stdTx.GetMemo())
+			if !sig.PubKey.VerifyBytes(signBytes, sig.Signature) {
+				return fmt.Errorf("signature %s is invalid", filename)
+			}
+			if err := multisigSig.AddSignatureFromPubKey(sig.Signature, sig.PubKey, multisigPub.PubKeys); err != nil {
+				return err
+			}
+		}
+		if multisigSig.NumSigned() < int(multisigPub.K) {
+			return fmt.Errorf("%d signatures are required, got %d", multisigPub.K, multisigSig.NumSigned())
+		}
+
+		newStdSig := auth.StdSignature{
+			PubKey:        multisigPub,
+			Signature:     multisigSig.Marshal(),
+			AccountNumber: accnum,
+			Sequence:      sequence,
+		}
+		newTx := auth.NewStdTx(stdTx.GetMsgs(), stdTx.Fee, append(stdTx.GetSignatures(), newStdSig), stdTx.GetMemo())
+
+		json, err := cdc.MarshalJSON(newTx)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("%s\n", json)
+		return
+	}
+}
+
+func readAndUnmarshalStdSignature(cdc *amino.Codec, filename string) (stdSig auth.StdSignature, err error) {
+	var bytes []byte
+	if bytes, err = ioutil.ReadFile(filename); err != nil {
+		return
+	}
+	if err = cdc.UnmarshalJSON(bytes, &stdSig); err != nil {
+		return
+	}
+	return
+}
@@ -19,6 +19,7 @@ import (
 const (
 	flagAppend    = "append"
 	flagPrintSigs = "print-sigs"
+	flagMultisig  = "multisig"
 )
 
 // GetSignCommand returns the sign command
@@ -27,13 +28,19 @@ func GetSignCommand(codec *amino.Codec, decoder auth.AccountDecoder) *cobra.Comm
 		Use:   "sign <file>",
 		Short: "Sign transactions",
 		Long: `Sign transactions created with the --generate-only flag.
-Read a transaction from <file>, sign it, and print its JSON encoding.`,
+Read a transaction from <file>, sign it, and print its JSON encoding.
+
+When the --multisig=<address> flag is given, the transaction is signed on behalf
+of the multisig account with the given address, and only the signature is printed.
+The signatures of the keys of the multisig account can then be combined with the
+multisign command.`,
 		RunE: makeSignCmd(codec, decoder),
 		Args: cobra.ExactArgs(1),
 	}
 	cmd.Flags().String(client.FlagName, "", "Name of private key with which to sign")
 	cmd.Flags().Bool(flagAppend, true, "Append the signature to the existing ones. If disabled, old signatures would be overwritten")
 	cmd.Flags().Bool(flagPrintSigs, false, "Print the addresses that must sign the transaction and those who have already signed it, then exit")
+	cmd.Flags().String(flagMultisig, "", "Address of the multisig account on behalf of which the transaction is signed")
 	return cmd
 }
 
@@ -53,6 +60,23 @@ func makeSignCmd(cdc *amino.Codec, decoder auth.AccountDecoder) func(cmd *cobra.
 		cliCtx := context.NewCLIContext().WithCodec(cdc).WithAccountDecoder(decoder)
 		txBldr := authtxb.NewTxBuilderFromCLI()
 
+		if multisigAddrStr := viper.GetString(flagMultisig); multisigAddrStr != "" {
+			multisigAddr, err := sdk.AccAddressFromBech32(multisigAddrStr)
+			if err != nil {
+				return err
+			}
+			sig, err := utils.SignStdTxWithSignerAddress(txBldr, cliCtx, multisigAddr, name, stdTx)
+			if err != nil {
+				return err
+			}
+			json, err := cdc.MarshalJSON(sig)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("%s\n", json)
+			return nil
+		}
+
 		newTx, err := utils.SignStdTx(txBldr, cliCtx, name, stdTx, viper.GetBool(flagAppend))
 		if err != nil {
 			return err
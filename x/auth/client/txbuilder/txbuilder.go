@@ -22,6 +22,7 @@ type TxBuilder struct {
 	ChainID       string
 	Memo          string
 	Fee           string
+	FeeGranter    string
 }
 
 // NewTxBuilderFromCLI returns a new initialized TxBuilder with parameters from
@@ -44,6 +45,7 @@ func NewTxBuilderFromCLI() TxBuilder {
 		Sequence:      viper.GetInt64(client.FlagSequence),
 		SimulateGas:   client.GasFlagVar.Simulate,
 		Fee:           viper.GetString(client.FlagFee),
+		FeeGranter:    viper.GetString(client.FlagFeeGranter),
 		Memo:          viper.GetString(client.FlagMemo),
 	}
 }
@@ -72,6 +74,12 @@ func (bldr TxBuilder) WithFee(fee string) TxBuilder {
 	return bldr
 }
 
+// WithFeeGranter returns a copy of the context with an updated fee granter.
+func (bldr TxBuilder) WithFeeGranter(feeGranter string) TxBuilder {
+	bldr.FeeGranter = feeGranter
+	return bldr
+}
+
 // WithSequence returns a copy of the context with an updated sequence number.
 func (bldr TxBuilder) WithSequence(sequence int64) TxBuilder {
 	bldr.Sequence = sequence
@@ -91,7 +99,8 @@ func (bldr TxBuilder) WithAccountNumber(accnum int64) TxBuilder {
 }
 
 // Build builds a single message to be signed from a TxBuilder given a set of
-// messages. It returns an error if a fee is supplied but cannot be parsed.
+// messages. It returns an error if a fee or a fee granter is supplied but
+// cannot be parsed.
 func (bldr TxBuilder) Build(msgs []sdk.Msg) (auth.StdSignMsg, error) {
 	chainID := bldr.ChainID
 	if chainID == "" {
@@ -108,13 +117,23 @@ func (bldr TxBuilder) Build(msgs []sdk.Msg) (auth.StdSignMsg, error) {
 		fee = parsedFee
 	}
 
+	stdFee := auth.NewStdFee(bldr.Gas, fee)
+	if bldr.FeeGranter != "" {
+		granter, err := sdk.AccAddressFromBech32(bldr.FeeGranter)
+		if err != nil {
+			return auth.StdSignMsg{}, err
+		}
+
+		stdFee.Granter = granter
+	}
+
 	return auth.StdSignMsg{
 		ChainID:       bldr.ChainID,
 		AccountNumber: bldr.AccountNumber,
 		Sequence:      bldr.Sequence,
 		Memo:          bldr.Memo,
 		Msgs:          msgs,
-		Fee:           auth.NewStdFee(bldr.Gas, fee),
+		Fee:           stdFee,
 	}, nil
 }
 
@@ -31,6 +31,7 @@ type contextKey int // local to the auth module
 
 const (
 	contextKeySigners contextKey = iota
+	contextKeyGasSchedule
 )
 
 // add the signers to the context
@@ -46,3 +47,17 @@ func GetSigners(ctx types.Context) []Account {
 	}
 	return v.([]Account)
 }
+
+// add the gas schedule charged to the tx to the context
+func withGasSchedule(ctx types.Context, gasSchedule GasSchedule) types.Context {
+	return ctx.WithValue(contextKeyGasSchedule, gasSchedule)
+}
+
+// get the gas schedule from the context, the default one if the context has none
+func gasScheduleFromContext(ctx types.Context) GasSchedule {
+	v := ctx.Value(contextKeyGasSchedule)
+	if v == nil {
+		return DefaultGasSchedule()
+	}
+	return v.(GasSchedule)
+}
@@ -0,0 +1,71 @@
+package auth
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// GasScheduleKey is the param key of the gas schedule
+const GasScheduleKey = "auth/GasSchedule"
+
+// GasSchedule defines the gas costs of the operations charged to a tx, the
+// KVStore operations of its messages included
+type GasSchedule struct {
+	KVStore             sdk.GasConfig `json:"kv_store"`
+	TransientStore      sdk.GasConfig `json:"transient_store"`
+	MemoCostPerByte     sdk.Gas       `json:"memo_cost_per_byte"`
+	DeductFeesCost      sdk.Gas       `json:"deduct_fees_cost"`
+	Ed25519VerifyCost   sdk.Gas       `json:"ed25519_verify_cost"`
+	Secp256k1VerifyCost sdk.Gas       `json:"secp256k1_verify_cost"`
+}
+
+// DefaultGasSchedule returns the gas schedule charged unless changed through
+// governance
+func DefaultGasSchedule() GasSchedule {
+	return GasSchedule{
+		KVStore:             sdk.DefaultGasConfig(),
+		TransientStore:      sdk.TransientGasConfig(),
+		MemoCostPerByte:     1,
+		DeductFeesCost:      10,
+		Ed25519VerifyCost:   59,
+		Secp256k1VerifyCost: 100,
+	}
+}
+
+// Validate checks that no cost is negative
+func (gs GasSchedule) Validate() error {
+	if err := gs.KVStore.Validate(); err != nil {
+		return fmt.Errorf("kv store: %v", err)
+	}
+	if err := gs.TransientStore.Validate(); err != nil {
+		return fmt.Errorf("transient store: %v", err)
+	}
+	if gs.MemoCostPerByte < 0 || gs.DeductFeesCost < 0 || gs.Ed25519VerifyCost < 0 || gs.Secp256k1VerifyCost < 0 {
+		return fmt.Errorf("negative gas cost in %+v", gs)
+	}
+	return nil
+}
+
+// RegisterParams registers the gas schedule as a governable param
+func RegisterParams(registry params.Registry) {
+	registry.Register(GasScheduleKey, GasSchedule{}, func(value interface{}) error {
+		return value.(GasSchedule).Validate()
+	})
+}
+
+// GetGasSchedule returns the gas schedule of the params, the default schedule
+// if the params have none. Reading the params consumes no gas.
+func GetGasSchedule(ctx sdk.Context, paramsGetter params.Getter) GasSchedule {
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	if paramsGetter.GetRaw(ctx, GasScheduleKey) == nil {
+		return DefaultGasSchedule()
+	}
+	var gs GasSchedule
+	err := paramsGetter.Get(ctx, GasScheduleKey, &gs)
+	if err != nil {
+		panic(err)
+	}
+	return gs
+}
@@ -0,0 +1,99 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	codec "github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// the gas consumed by a descriptor
+func gasOf(meter sdk.GasMeter, descriptor string) sdk.Gas {
+	for _, consumption := range meter.GasBreakdown() {
+		if consumption.Descriptor == descriptor {
+			return consumption.Gas
+		}
+	}
+	return 0
+}
+
+func TestAnteHandlerGasSchedule(t *testing.T) {
+	// setup
+	db := dbm.NewMemDB()
+	capKey, capKey2, paramsKey := sdk.NewKVStoreKey("capkey"), sdk.NewKVStoreKey("capkey2"), sdk.NewKVStoreKey("params")
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(capKey, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(capKey2, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(paramsKey, sdk.StoreTypeIAVL, db)
+	require.Nil(t, ms.LoadLatestVersion())
+	cdc := codec.New()
+	RegisterBaseAccount(cdc)
+	mapper := NewAccountMapper(cdc, capKey, ProtoBaseAccount)
+	feeCollector := NewFeeCollectionKeeper(cdc, capKey2)
+	paramsKeeper := params.NewKeeper(cdc, paramsKey)
+	anteHandler := NewAnteHandlerWithParams(mapper, feeCollector, paramsKeeper.Getter())
+	ctx := sdk.NewContext(ms, abci.Header{ChainID: "mychainid"}, false, log.NewNopLogger())
+
+	priv1, addr1 := privAndAddr()
+	mapper.SetAccount(ctx, mapper.NewAccountWithAddress(ctx, addr1))
+	msg := newTestMsg(addr1)
+	privs, accnums := []crypto.PrivKey{priv1}, []int64{0}
+	fee := NewStdFee(100000, sdk.NewInt64Coin("atom", 0))
+	memo := "abcininasidniandsinasindiansdiansdinaisndiasndiadninsd"
+
+	// the default schedule is charged while the params have none
+	tx := newTestTxWithMemo(ctx, []sdk.Msg{msg}, privs, accnums, []int64{0}, fee, memo)
+	newCtx, result, abort := anteHandler(ctx, tx, false)
+	require.False(t, abort, result.Log)
+	require.Equal(t, sdk.Gas(len(memo)), gasOf(newCtx.GasMeter(), "memo"))
+	require.Equal(t, DefaultGasSchedule().Ed25519VerifyCost, gasOf(newCtx.GasMeter(), "ante verify: ed25519"))
+	require.Equal(t, sdk.DefaultGasConfig(), newCtx.KVGasConfig())
+
+	// then the schedule of the params
+	gasSchedule := DefaultGasSchedule()
+	gasSchedule.MemoCostPerByte = 3
+	gasSchedule.Ed25519VerifyCost = 70
+	gasSchedule.KVStore.ReadCostFlat = 20
+	require.Nil(t, paramsKeeper.Setter().Set(ctx, GasScheduleKey, gasSchedule))
+	require.Equal(t, gasSchedule, GetGasSchedule(ctx, paramsKeeper.Getter()))
+
+	tx = newTestTxWithMemo(ctx, []sdk.Msg{msg}, privs, accnums, []int64{1}, fee, memo)
+	newCtx, result, abort = anteHandler(ctx, tx, false)
+	require.False(t, abort, result.Log)
+	require.Equal(t, 3*sdk.Gas(len(memo)), gasOf(newCtx.GasMeter(), "memo"))
+	require.Equal(t, sdk.Gas(70), gasOf(newCtx.GasMeter(), "ante verify: ed25519"))
+	require.Equal(t, gasSchedule.KVStore, newCtx.KVGasConfig())
+	require.Equal(t, gasSchedule.TransientStore, newCtx.TransientGasConfig())
+}
+
+func TestGasScheduleParams(t *testing.T) {
+	cdc := codec.New()
+	registry := params.NewRegistry()
+	RegisterParams(registry)
+
+	gasSchedule := DefaultGasSchedule()
+	gasSchedule.KVStore.WriteCostFlat = 50
+	bz, err := cdc.MarshalJSON(gasSchedule)
+	require.Nil(t, err)
+	param, err := registry.Parse(cdc, GasScheduleKey, string(bz))
+	require.Nil(t, err)
+	require.Equal(t, gasSchedule, param)
+
+	// negative costs are rejected
+	gasSchedule.KVStore.WriteCostFlat = -1
+	bz, err = cdc.MarshalJSON(gasSchedule)
+	require.Nil(t, err)
+	_, err = registry.Parse(cdc, GasScheduleKey, string(bz))
+	require.NotNil(t, err)
+	gasSchedule = DefaultGasSchedule()
+	gasSchedule.DeductFeesCost = -1
+	require.NotNil(t, gasSchedule.Validate())
+}
@@ -75,9 +75,12 @@ func FeePayer(tx sdk.Tx) sdk.AccAddress {
 // StdFee includes the amount of coins paid in fees and the maximum
 // gas to be used by the transaction. The ratio yields an effective "gasprice",
 // which must be above some miminum to be accepted into the mempool.
+// If the Granter is set, it pays the fees out of the allowance it granted
+// to the fee payer instead of the fee payer.
 type StdFee struct {
-	Amount sdk.Coins `json:"amount"`
-	Gas    int64     `json:"gas"`
+	Amount  sdk.Coins      `json:"amount"`
+	Gas     int64          `json:"gas"`
+	Granter sdk.AccAddress `json:"granter,omitempty"`
 }
 
 func NewStdFee(gas int64, amount ...sdk.Coin) StdFee {
@@ -0,0 +1,246 @@
+package auth
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// VestingAccount is an account whose original coins are locked until they
+// vest. Locked coins cannot be transferred, but they can be delegated, in which
+// case the delegated coins are tracked as either vesting or free.
+type VestingAccount interface {
+	Account
+
+	// Delegation and undelegation accounting, the delegated coins must be
+	// tracked before they are subtracted from the coins of the account.
+	TrackDelegation(blockTime time.Time, amount sdk.Coins)
+	TrackUndelegation(amount sdk.Coins)
+
+	GetVestedCoins(blockTime time.Time) sdk.Coins
+	GetVestingCoins(blockTime time.Time) sdk.Coins
+	SpendableCoins(blockTime time.Time) sdk.Coins
+
+	GetStartTime() time.Time
+	GetEndTime() time.Time
+
+	GetOriginalVesting() sdk.Coins
+	GetDelegatedFree() sdk.Coins
+	GetDelegatedVesting() sdk.Coins
+}
+
+//-----------------------------------------------------------
+// BaseVestingAccount
+
+// BaseVestingAccount implements the vesting bookkeeping shared by all vesting
+// accounts. It is embedded in the concrete vesting accounts, which determine
+// how the original vesting coins vest over time.
+type BaseVestingAccount struct {
+	*BaseAccount
+
+	OriginalVesting  sdk.Coins `json:"original_vesting"`  // coins locked at account creation
+	DelegatedFree    sdk.Coins `json:"delegated_free"`    // delegated vested coins
+	DelegatedVesting sdk.Coins `json:"delegated_vesting"` // delegated vesting coins
+
+	EndTime time.Time `json:"end_time"` // time at which all coins are vested
+}
+
+// nolint
+func (bva BaseVestingAccount) GetOriginalVesting() sdk.Coins  { return bva.OriginalVesting }
+func (bva BaseVestingAccount) GetDelegatedFree() sdk.Coins    { return bva.DelegatedFree }
+func (bva BaseVestingAccount) GetDelegatedVesting() sdk.Coins { return bva.DelegatedVesting }
+func (bva BaseVestingAccount) GetEndTime() time.Time          { return bva.EndTime }
+
+// spendableCoins returns the coins of the account which are not locked. The
+// locked coins are the vesting coins which are not delegated, as delegated
+// coins have already been subtracted from the coins of the account.
+func (bva BaseVestingAccount) spendableCoins(vestingCoins sdk.Coins) sdk.Coins {
+	var spendableCoins sdk.Coins
+	coins := bva.GetCoins()
+
+	for _, coin := range coins {
+		vestingAmt := vestingCoins.AmountOf(coin.Denom)
+		delVestingAmt := bva.DelegatedVesting.AmountOf(coin.Denom)
+
+		locked := maxInt(vestingAmt.Sub(delVestingAmt), sdk.ZeroInt())
+		spendable := maxInt(coin.Amount.Sub(locked), sdk.ZeroInt())
+		if !spendable.IsZero() {
+			spendableCoins = spendableCoins.Plus(sdk.Coins{sdk.NewCoin(coin.Denom, spendable)})
+		}
+	}
+	return spendableCoins
+}
+
+// trackDelegation splits the delegated amount between the vesting coins which
+// are not delegated yet and the free coins, vesting coins being delegated first.
+func (bva *BaseVestingAccount) trackDelegation(vestingCoins, amount sdk.Coins) {
+	coins := bva.GetCoins()
+
+	for _, coin := range amount {
+		if coins.AmountOf(coin.Denom).LT(coin.Amount) {
+			panic("delegation attempt with insufficient funds")
+		}
+
+		vestingAmt := vestingCoins.AmountOf(coin.Denom)
+		delVestingAmt := bva.DelegatedVesting.AmountOf(coin.Denom)
+
+		// the delegated amount taken from the vesting coins, the rest is free
+		x := sdk.MinInt(maxInt(vestingAmt.Sub(delVestingAmt), sdk.ZeroInt()), coin.Amount)
+		y := coin.Amount.Sub(x)
+
+		if !x.IsZero() {
+			bva.DelegatedVesting = bva.DelegatedVesting.Plus(sdk.Coins{sdk.NewCoin(coin.Denom, x)})
+		}
+		if !y.IsZero() {
+			bva.DelegatedFree = bva.DelegatedFree.Plus(sdk.Coins{sdk.NewCoin(coin.Denom, y)})
+		}
+	}
+}
+
+// TrackUndelegation tracks the undelegated amount, free coins being undelegated
+// first. The undelegated amount may be lower than the delegated one if the
+// delegation was slashed.
+func (bva *BaseVestingAccount) TrackUndelegation(amount sdk.Coins) {
+	for _, coin := range amount {
+		delFreeAmt := bva.DelegatedFree.AmountOf(coin.Denom)
+		delVestingAmt := bva.DelegatedVesting.AmountOf(coin.Denom)
+
+		x := sdk.MinInt(delFreeAmt, coin.Amount)
+		y := sdk.MinInt(delVestingAmt, coin.Amount.Sub(x))
+
+		if !x.IsZero() {
+			bva.DelegatedFree = bva.DelegatedFree.Minus(sdk.Coins{sdk.NewCoin(coin.Denom, x)})
+		}
+		if !y.IsZero() {
+			bva.DelegatedVesting = bva.DelegatedVesting.Minus(sdk.Coins{sdk.NewCoin(coin.Denom, y)})
+		}
+	}
+}
+
+func maxInt(i1, i2 sdk.Int) sdk.Int {
+	if i1.GT(i2) {
+		return i1
+	}
+	return i2
+}
+
+//-----------------------------------------------------------
+// ContinuousVestingAccount
+
+var _ VestingAccount = (*ContinuousVestingAccount)(nil)
+
+// ContinuousVestingAccount vests its original coins linearly between its
+// start and end times.
+type ContinuousVestingAccount struct {
+	*BaseVestingAccount
+
+	StartTime time.Time `json:"start_time"` // time at which vesting starts
+}
+
+// NewContinuousVestingAccount locks all the coins of the base account, to be
+// vested linearly between the start and end times.
+func NewContinuousVestingAccount(baseAcc *BaseAccount, startTime, endTime time.Time) *ContinuousVestingAccount {
+	return &ContinuousVestingAccount{
+		BaseVestingAccount: &BaseVestingAccount{
+			BaseAccount:     baseAcc,
+			OriginalVesting: baseAcc.Coins,
+			EndTime:         endTime,
+		},
+		StartTime: startTime,
+	}
+}
+
+// GetStartTime implements VestingAccount.
+func (cva ContinuousVestingAccount) GetStartTime() time.Time {
+	return cva.StartTime
+}
+
+// GetVestedCoins returns the coins vested at the block time, proportionally to
+// the time elapsed since the start time.
+func (cva ContinuousVestingAccount) GetVestedCoins(blockTime time.Time) sdk.Coins {
+	if !blockTime.After(cva.StartTime) {
+		return nil
+	}
+	if !blockTime.Before(cva.EndTime) {
+		return cva.OriginalVesting
+	}
+
+	elapsed := int64(blockTime.Sub(cva.StartTime))
+	period := int64(cva.EndTime.Sub(cva.StartTime))
+
+	var vestedCoins sdk.Coins
+	for _, coin := range cva.OriginalVesting {
+		vestedAmt := coin.Amount.MulRaw(elapsed).DivRaw(period)
+		if !vestedAmt.IsZero() {
+			vestedCoins = vestedCoins.Plus(sdk.Coins{sdk.NewCoin(coin.Denom, vestedAmt)})
+		}
+	}
+	return vestedCoins
+}
+
+// GetVestingCoins returns the coins still vesting at the block time.
+func (cva ContinuousVestingAccount) GetVestingCoins(blockTime time.Time) sdk.Coins {
+	return cva.OriginalVesting.Minus(cva.GetVestedCoins(blockTime))
+}
+
+// SpendableCoins returns the coins which can be transferred at the block time.
+func (cva ContinuousVestingAccount) SpendableCoins(blockTime time.Time) sdk.Coins {
+	return cva.spendableCoins(cva.GetVestingCoins(blockTime))
+}
+
+// TrackDelegation implements VestingAccount.
+func (cva *ContinuousVestingAccount) TrackDelegation(blockTime time.Time, amount sdk.Coins) {
+	cva.trackDelegation(cva.GetVestingCoins(blockTime), amount)
+}
+
+//-----------------------------------------------------------
+// DelayedVestingAccount
+
+var _ VestingAccount = (*DelayedVestingAccount)(nil)
+
+// DelayedVestingAccount vests all its original coins at once at its end time.
+type DelayedVestingAccount struct {
+	*BaseVestingAccount
+}
+
+// NewDelayedVestingAccount locks all the coins of the base account until the
+// end time.
+func NewDelayedVestingAccount(baseAcc *BaseAccount, endTime time.Time) *DelayedVestingAccount {
+	return &DelayedVestingAccount{
+		BaseVestingAccount: &BaseVestingAccount{
+			BaseAccount:     baseAcc,
+			OriginalVesting: baseAcc.Coins,
+			EndTime:         endTime,
+		},
+	}
+}
+
+// GetStartTime implements VestingAccount, a delayed vesting account has no
+// start time.
+func (dva DelayedVestingAccount) GetStartTime() time.Time {
+	return time.Time{}
+}
+
+// GetVestedCoins returns all the original coins once the end time is reached,
+// and none before.
+func (dva DelayedVestingAccount) GetVestedCoins(blockTime time.Time) sdk.Coins {
+	if !blockTime.Before(dva.EndTime) {
+		return dva.OriginalVesting
+	}
+	return nil
+}
+
+// GetVestingCoins returns the coins still vesting at the block time.
+func (dva DelayedVestingAccount) GetVestingCoins(blockTime time.Time) sdk.Coins {
+	return dva.OriginalVesting.Minus(dva.GetVestedCoins(blockTime))
+}
+
+// SpendableCoins returns the coins which can be transferred at the block time.
+func (dva DelayedVestingAccount) SpendableCoins(blockTime time.Time) sdk.Coins {
+	return dva.spendableCoins(dva.GetVestingCoins(blockTime))
+}
+
+// TrackDelegation implements VestingAccount.
+func (dva *DelayedVestingAccount) TrackDelegation(blockTime time.Time, amount sdk.Coins) {
+	dva.trackDelegation(dva.GetVestingCoins(blockTime), amount)
+}
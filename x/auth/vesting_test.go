@@ -0,0 +1,213 @@
+package auth
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	stakeDenom = "steak"
+	feeDenom   = "fee"
+)
+
+func initBaseAccount() (*BaseAccount, sdk.Coins) {
+	_, _, addr := keyPubAddr()
+	origCoins := sdk.Coins{sdk.NewInt64Coin(feeDenom, 1000), sdk.NewInt64Coin(stakeDenom, 100)}
+	bacc := NewBaseAccountWithAddress(addr)
+	bacc.SetCoins(origCoins)
+	return &bacc, origCoins
+}
+
+func TestGetVestedCoinsContVestingAcc(t *testing.T) {
+	now := time.Now()
+	endTime := now.Add(24 * time.Hour)
+
+	bacc, origCoins := initBaseAccount()
+	cva := NewContinuousVestingAccount(bacc, now, endTime)
+
+	// require no coins vested in the very beginning of the vesting schedule
+	vestedCoins := cva.GetVestedCoins(now)
+	require.Nil(t, vestedCoins)
+
+	// require all coins vested at the end of the vesting schedule
+	vestedCoins = cva.GetVestedCoins(endTime)
+	require.Equal(t, origCoins, vestedCoins)
+
+	// require 50% of coins vested
+	vestedCoins = cva.GetVestedCoins(now.Add(12 * time.Hour))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(feeDenom, 500), sdk.NewInt64Coin(stakeDenom, 50)}, vestedCoins)
+
+	// require 100% of coins vested
+	vestedCoins = cva.GetVestedCoins(now.Add(48 * time.Hour))
+	require.Equal(t, origCoins, vestedCoins)
+}
+
+func TestSpendableCoinsContVestingAcc(t *testing.T) {
+	now := time.Now()
+	endTime := now.Add(24 * time.Hour)
+
+	bacc, origCoins := initBaseAccount()
+	cva := NewContinuousVestingAccount(bacc, now, endTime)
+
+	// require that there exist no spendable coins in the beginning of the
+	// vesting schedule
+	spendableCoins := cva.SpendableCoins(now)
+	require.Nil(t, spendableCoins)
+
+	// require that all original coins are spendable at the end of the vesting
+	// schedule
+	spendableCoins = cva.SpendableCoins(endTime)
+	require.Equal(t, origCoins, spendableCoins)
+
+	// require that all vested coins (50%) are spendable
+	spendableCoins = cva.SpendableCoins(now.Add(12 * time.Hour))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(feeDenom, 500), sdk.NewInt64Coin(stakeDenom, 50)}, spendableCoins)
+
+	// receive some coins
+	recvAmt := sdk.Coins{sdk.NewInt64Coin(stakeDenom, 50)}
+	cva.SetCoins(cva.GetCoins().Plus(recvAmt))
+
+	// require that all vested coins (50%) are spendable plus any received
+	spendableCoins = cva.SpendableCoins(now.Add(12 * time.Hour))
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(feeDenom, 500), sdk.NewInt64Coin(stakeDenom, 100)}, spendableCoins)
+
+	// spend all spendable coins
+	cva.SetCoins(cva.GetCoins().Minus(spendableCoins))
+
+	// require that no more coins are spendable
+	spendableCoins = cva.SpendableCoins(now.Add(12 * time.Hour))
+	require.Nil(t, spendableCoins)
+}
+
+func TestTrackDelegationContVestingAcc(t *testing.T) {
+	now := time.Now()
+	endTime := now.Add(24 * time.Hour)
+
+	bacc, origCoins := initBaseAccount()
+
+	// require the ability to delegate all vesting coins
+	cva := NewContinuousVestingAccount(bacc, now, endTime)
+	cva.TrackDelegation(now, origCoins)
+	require.Equal(t, origCoins, cva.DelegatedVesting)
+	require.Nil(t, cva.DelegatedFree)
+
+	// require the ability to delegate all vested coins
+	bacc.SetCoins(origCoins)
+	cva = NewContinuousVestingAccount(bacc, now, endTime)
+	cva.TrackDelegation(endTime, origCoins)
+	require.Nil(t, cva.DelegatedVesting)
+	require.Equal(t, origCoins, cva.DelegatedFree)
+
+	// require the ability to delegate all vesting coins (50%) and all vested coins (50%)
+	bacc.SetCoins(origCoins)
+	cva = NewContinuousVestingAccount(bacc, now, endTime)
+	cva.TrackDelegation(now.Add(12*time.Hour), sdk.Coins{sdk.NewInt64Coin(stakeDenom, 50)})
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakeDenom, 50)}, cva.DelegatedVesting)
+	require.Nil(t, cva.DelegatedFree)
+
+	cva.TrackDelegation(now.Add(12*time.Hour), sdk.Coins{sdk.NewInt64Coin(stakeDenom, 50)})
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakeDenom, 50)}, cva.DelegatedVesting)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakeDenom, 50)}, cva.DelegatedFree)
+
+	// require no modifications when delegation amount is greater than the coins
+	bacc.SetCoins(origCoins)
+	cva = NewContinuousVestingAccount(bacc, now, endTime)
+	require.Panics(t, func() {
+		cva.TrackDelegation(endTime, sdk.Coins{sdk.NewInt64Coin(stakeDenom, 1000000)})
+	})
+	require.Nil(t, cva.DelegatedVesting)
+	require.Nil(t, cva.DelegatedFree)
+}
+
+func TestTrackUndelegationContVestingAcc(t *testing.T) {
+	now := time.Now()
+	endTime := now.Add(24 * time.Hour)
+
+	bacc, origCoins := initBaseAccount()
+
+	// require the ability to undelegate all vesting coins
+	cva := NewContinuousVestingAccount(bacc, now, endTime)
+	cva.TrackDelegation(now, origCoins)
+	cva.TrackUndelegation(origCoins)
+	require.Nil(t, cva.DelegatedFree)
+	require.Nil(t, cva.DelegatedVesting)
+
+	// require the ability to undelegate all vested coins
+	bacc.SetCoins(origCoins)
+	cva = NewContinuousVestingAccount(bacc, now, endTime)
+	cva.TrackDelegation(endTime, origCoins)
+	cva.TrackUndelegation(origCoins)
+	require.Nil(t, cva.DelegatedFree)
+	require.Nil(t, cva.DelegatedVesting)
+
+	// vest 50% and delegate to two validators
+	bacc.SetCoins(origCoins)
+	cva = NewContinuousVestingAccount(bacc, now, endTime)
+	cva.TrackDelegation(now.Add(12*time.Hour), sdk.Coins{sdk.NewInt64Coin(stakeDenom, 50)})
+	cva.TrackDelegation(now.Add(12*time.Hour), sdk.Coins{sdk.NewInt64Coin(stakeDenom, 50)})
+
+	// undelegate from one validator that got slashed 50%, free coins are undelegated first
+	cva.TrackUndelegation(sdk.Coins{sdk.NewInt64Coin(stakeDenom, 25)})
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakeDenom, 25)}, cva.DelegatedFree)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakeDenom, 50)}, cva.DelegatedVesting)
+
+	// undelegate from the other validator that did not get slashed
+	cva.TrackUndelegation(sdk.Coins{sdk.NewInt64Coin(stakeDenom, 50)})
+	require.Nil(t, cva.DelegatedFree)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(stakeDenom, 25)}, cva.DelegatedVesting)
+}
+
+func TestGetVestedCoinsDelVestingAcc(t *testing.T) {
+	now := time.Now()
+	endTime := now.Add(24 * time.Hour)
+
+	bacc, origCoins := initBaseAccount()
+	dva := NewDelayedVestingAccount(bacc, endTime)
+
+	// require no coins are vested until schedule maturation
+	vestedCoins := dva.GetVestedCoins(now)
+	require.Nil(t, vestedCoins)
+	vestedCoins = dva.GetVestedCoins(now.Add(12 * time.Hour))
+	require.Nil(t, vestedCoins)
+
+	// require all coins be vested at schedule maturation
+	vestedCoins = dva.GetVestedCoins(endTime)
+	require.Equal(t, origCoins, vestedCoins)
+}
+
+func TestSpendableCoinsDelVestingAcc(t *testing.T) {
+	now := time.Now()
+	endTime := now.Add(24 * time.Hour)
+
+	bacc, origCoins := initBaseAccount()
+	dva := NewDelayedVestingAccount(bacc, endTime)
+
+	// require that no coins are spendable before the end time
+	require.Nil(t, dva.SpendableCoins(now))
+	require.Nil(t, dva.SpendableCoins(now.Add(12*time.Hour)))
+
+	// require that all coins are spendable at the end time
+	require.Equal(t, origCoins, dva.SpendableCoins(endTime))
+
+	// receive some coins, which are spendable before the end time
+	recvAmt := sdk.Coins{sdk.NewInt64Coin(stakeDenom, 50)}
+	dva.SetCoins(dva.GetCoins().Plus(recvAmt))
+	require.Equal(t, recvAmt, dva.SpendableCoins(now))
+
+	// delegate some locked coins, the received coins remain spendable
+	delAmt := sdk.Coins{sdk.NewInt64Coin(stakeDenom, 50)}
+	dva.TrackDelegation(now, delAmt)
+	dva.SetCoins(dva.GetCoins().Minus(delAmt))
+	require.Equal(t, delAmt, dva.DelegatedVesting)
+	require.Equal(t, recvAmt, dva.SpendableCoins(now))
+
+	// undelegate the locked coins, they remain locked
+	dva.TrackUndelegation(delAmt)
+	dva.SetCoins(dva.GetCoins().Plus(delAmt))
+	require.Nil(t, dva.DelegatedVesting)
+	require.Equal(t, recvAmt, dva.SpendableCoins(now))
+}
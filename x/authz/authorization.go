@@ -0,0 +1,167 @@
+package authz
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+// Authorization authorizes a grantee to execute the msgs of a type on behalf
+// of a granter.
+type Authorization interface {
+	// MsgType returns the type of the msgs authorized, as returned by MsgType.
+	MsgType() string
+
+	// Accept checks a msg of the type executed on behalf of the granter. It
+	// returns the updated authorization, and whether the authorization is used
+	// up and may be removed.
+	Accept(granter sdk.AccAddress, msg sdk.Msg) (updated Authorization, usedUp bool, err sdk.Error)
+
+	// ValidateBasic checks the authorization independently of the state.
+	ValidateBasic() sdk.Error
+}
+
+// MsgType returns the type of a msg the authorizations are granted for, its
+// route and its name, e.g. bank/send.
+func MsgType(msg sdk.Msg) string {
+	return msg.Type() + "/" + msg.Name()
+}
+
+// AuthorizationGrant is the authorization a granter gives to a grantee.
+type AuthorizationGrant struct {
+	Granter       sdk.AccAddress `json:"granter"`
+	Grantee       sdk.AccAddress `json:"grantee"`
+	Authorization Authorization  `json:"authorization"`
+	Expiration    time.Time      `json:"expiration"` // time from which the authorization is rejected, never if zero
+}
+
+func (grant AuthorizationGrant) String() string {
+	expiration := "never"
+	if !grant.Expiration.IsZero() {
+		expiration = grant.Expiration.String()
+	}
+	return fmt.Sprintf(`Authorization Grant:
+  Granter:       %s
+  Grantee:       %s
+  Msg Type:      %s
+  Authorization: %v
+  Expiration:    %s`, grant.Granter, grant.Grantee, grant.Authorization.MsgType(), grant.Authorization, expiration)
+}
+
+//-----------------------------------------------------------
+// SendAuthorization
+
+// SendAuthorization authorizes to send the coins of the granter up to a spend
+// limit.
+type SendAuthorization struct {
+	SpendLimit sdk.Coins `json:"spend_limit"` // coins left to send
+}
+
+var _ Authorization = SendAuthorization{}
+
+// nolint
+func (a SendAuthorization) MsgType() string { return MsgType(bank.MsgSend{}) }
+
+// Implements Authorization.
+func (a SendAuthorization) Accept(granter sdk.AccAddress, msg sdk.Msg) (Authorization, bool, sdk.Error) {
+	send, ok := msg.(bank.MsgSend)
+	if !ok {
+		return a, false, ErrMsgNotAuthorized(DefaultCodespace, fmt.Sprintf("not a send msg: %v", msg))
+	}
+	var amount sdk.Coins
+	for _, in := range send.Inputs {
+		if bytes.Equal(in.Address, granter) {
+			amount = amount.Plus(in.Coins)
+		}
+	}
+	left := a.SpendLimit.Minus(amount)
+	if !left.IsNotNegative() {
+		return a, false, ErrSpendLimitExceeded(DefaultCodespace, amount, a.SpendLimit)
+	}
+	return SendAuthorization{left}, left.IsZero(), nil
+}
+
+// Implements Authorization.
+func (a SendAuthorization) ValidateBasic() sdk.Error {
+	if !a.SpendLimit.IsValid() || !a.SpendLimit.IsPositive() {
+		return sdk.ErrInvalidCoins(a.SpendLimit.String())
+	}
+	return nil
+}
+
+func (a SendAuthorization) String() string {
+	return fmt.Sprintf("SendAuthorization{%s}", a.SpendLimit)
+}
+
+//-----------------------------------------------------------
+// VoteAuthorization
+
+// VoteAuthorization authorizes to vote on a single governance proposal.
+type VoteAuthorization struct {
+	ProposalID int64 `json:"proposal_id"`
+}
+
+var _ Authorization = VoteAuthorization{}
+
+// nolint
+func (a VoteAuthorization) MsgType() string { return MsgType(gov.MsgVote{}) }
+
+// Implements Authorization.
+func (a VoteAuthorization) Accept(granter sdk.AccAddress, msg sdk.Msg) (Authorization, bool, sdk.Error) {
+	vote, ok := msg.(gov.MsgVote)
+	if !ok {
+		return a, false, ErrMsgNotAuthorized(DefaultCodespace, fmt.Sprintf("not a vote msg: %v", msg))
+	}
+	if vote.ProposalID != a.ProposalID {
+		return a, false, ErrMsgNotAuthorized(DefaultCodespace, fmt.Sprintf("not authorized to vote on proposal %d", vote.ProposalID))
+	}
+	return a, false, nil
+}
+
+// Implements Authorization.
+func (a VoteAuthorization) ValidateBasic() sdk.Error {
+	if a.ProposalID < 0 {
+		return ErrInvalidAuthorization(DefaultCodespace, fmt.Sprintf("invalid proposal id %d", a.ProposalID))
+	}
+	return nil
+}
+
+func (a VoteAuthorization) String() string {
+	return fmt.Sprintf("VoteAuthorization{%d}", a.ProposalID)
+}
+
+//-----------------------------------------------------------
+// GenericAuthorization
+
+// GenericAuthorization authorizes any msg of a type, e.g. stake/delegate.
+type GenericAuthorization struct {
+	Msg string `json:"msg"` // type of the msgs authorized
+}
+
+var _ Authorization = GenericAuthorization{}
+
+// nolint
+func (a GenericAuthorization) MsgType() string { return a.Msg }
+
+// Implements Authorization.
+func (a GenericAuthorization) Accept(granter sdk.AccAddress, msg sdk.Msg) (Authorization, bool, sdk.Error) {
+	return a, false, nil
+}
+
+// Implements Authorization.
+func (a GenericAuthorization) ValidateBasic() sdk.Error {
+	parts := strings.Split(a.Msg, "/")
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return ErrInvalidAuthorization(DefaultCodespace, fmt.Sprintf("invalid msg type %q, expected <route>/<name>", a.Msg))
+	}
+	return nil
+}
+
+func (a GenericAuthorization) String() string {
+	return fmt.Sprintf("GenericAuthorization{%s}", a.Msg)
+}
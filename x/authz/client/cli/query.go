@@ -0,0 +1,101 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+// GetCmdQueryAuthorization implements the query authorization command.
+func GetCmdQueryAuthorization(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "authorization",
+		Short: "query the authorization of a msg type a granter gave to a grantee",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			granterAddr, err := sdk.AccAddressFromBech32(viper.GetString(flagGranter))
+			if err != nil {
+				return err
+			}
+
+			granteeAddr, err := sdk.AccAddressFromBech32(viper.GetString(flagGrantee))
+			if err != nil {
+				return err
+			}
+
+			params := authz.QueryAuthorizationParams{
+				Granter: granterAddr,
+				Grantee: granteeAddr,
+				MsgType: viper.GetString(flagMsgType),
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, authz.QueryAuthorization), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagGranter, "", "bech32 address of the granter")
+	cmd.Flags().String(flagGrantee, "", "bech32 address of the grantee")
+	cmd.Flags().String(flagMsgType, "", "type of the msgs of the authorization, e.g. bank/send")
+
+	return cmd
+}
+
+// GetCmdQueryAuthorizations implements the query authorizations command.
+func GetCmdQueryAuthorizations(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "authorizations",
+		Short: "query the authorizations a granter gave to a grantee",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			granterAddr, err := sdk.AccAddressFromBech32(viper.GetString(flagGranter))
+			if err != nil {
+				return err
+			}
+
+			granteeAddr, err := sdk.AccAddressFromBech32(viper.GetString(flagGrantee))
+			if err != nil {
+				return err
+			}
+
+			params := authz.QueryAuthorizationsParams{
+				Granter: granterAddr,
+				Grantee: granteeAddr,
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, authz.QueryAuthorizations), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagGranter, "", "bech32 address of the granter")
+	cmd.Flags().String(flagGrantee, "", "bech32 address of the grantee")
+
+	return cmd
+}
@@ -0,0 +1,207 @@
+package cli
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/authz"
+)
+
+const (
+	flagGranter    = "granter"
+	flagGrantee    = "grantee"
+	flagSpendLimit = "spend-limit"
+	flagProposalID = "proposal-id"
+	flagMsgType    = "msg-type"
+	flagExpiration = "expiration"
+)
+
+// GetCmdGrantAuthorization implements the command granting an authorization.
+func GetCmdGrantAuthorization(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant",
+		Short: "authorize a grantee to execute msgs on behalf of the sender, replacing any previous authorization of the msg type",
+		Long: `Authorize a grantee to execute msgs on behalf of the sender. The authorization is one of:
+  --spend-limit: send coins up to the spend limit
+  --proposal-id: vote on the proposal
+  --msg-type:    execute any msg of the type, e.g. stake/delegate`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			granterAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			granteeAddr, err := sdk.AccAddressFromBech32(viper.GetString(flagGrantee))
+			if err != nil {
+				return err
+			}
+
+			authorization, err := authorizationFromFlags(cmd)
+			if err != nil {
+				return err
+			}
+
+			var expiration time.Time
+			if s := viper.GetString(flagExpiration); s != "" {
+				expiration, err = time.Parse(time.RFC3339, s)
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := authz.NewMsgGrantAuthorization(granterAddr, granteeAddr, authorization, expiration)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+
+			// Build and sign the transaction, then broadcast to a Tendermint
+			// node.
+			return utils.SendTx(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagGrantee, "", "bech32 address of the grantee")
+	cmd.Flags().String(flagSpendLimit, "", "coins the grantee may send")
+	cmd.Flags().Int64(flagProposalID, 0, "id of the proposal the grantee may vote on")
+	cmd.Flags().String(flagMsgType, "", "type of the msgs the grantee may execute, e.g. stake/delegate")
+	cmd.Flags().String(flagExpiration, "", "RFC3339 time from which the authorization is rejected, never if empty")
+
+	return cmd
+}
+
+// the authorization of the single authorization flag set
+func authorizationFromFlags(cmd *cobra.Command) (authz.Authorization, error) {
+	var authorizations []authz.Authorization
+	if s := viper.GetString(flagSpendLimit); s != "" {
+		spendLimit, err := sdk.ParseCoins(s)
+		if err != nil {
+			return nil, err
+		}
+		authorizations = append(authorizations, authz.SendAuthorization{SpendLimit: spendLimit})
+	}
+	if cmd.Flags().Changed(flagProposalID) {
+		authorizations = append(authorizations, authz.VoteAuthorization{ProposalID: viper.GetInt64(flagProposalID)})
+	}
+	if s := viper.GetString(flagMsgType); s != "" {
+		authorizations = append(authorizations, authz.GenericAuthorization{Msg: s})
+	}
+	if len(authorizations) != 1 {
+		return nil, errors.New("exactly one of --spend-limit, --proposal-id and --msg-type is required")
+	}
+	return authorizations[0], nil
+}
+
+// GetCmdRevokeAuthorization implements the command revoking an authorization.
+func GetCmdRevokeAuthorization(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "revoke the authorization of a msg type the sender gave to a grantee",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			granterAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			granteeAddr, err := sdk.AccAddressFromBech32(viper.GetString(flagGrantee))
+			if err != nil {
+				return err
+			}
+
+			msg := authz.NewMsgRevokeAuthorization(granterAddr, granteeAddr, viper.GetString(flagMsgType))
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+
+			// Build and sign the transaction, then broadcast to a Tendermint
+			// node.
+			return utils.SendTx(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagGrantee, "", "bech32 address of the grantee")
+	cmd.Flags().String(flagMsgType, "", "type of the msgs of the authorization, e.g. bank/send")
+
+	return cmd
+}
+
+// GetCmdExec implements the command executing the msgs of a tx on behalf of
+// their signers.
+func GetCmdExec(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "exec <file>",
+		Short: "execute the msgs of a tx generated with --generate-only on behalf of their signers",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			granteeAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			var stdTx auth.StdTx
+			err = cdc.UnmarshalJSON(bz, &stdTx)
+			if err != nil {
+				return err
+			}
+
+			msg := authz.NewMsgExec(granteeAddr, stdTx.GetMsgs())
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+
+			// Build and sign the transaction, then broadcast to a Tendermint
+			// node.
+			return utils.SendTx(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	return cmd
+}
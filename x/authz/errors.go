@@ -0,0 +1,52 @@
+//nolint
+package authz
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	DefaultCodespace sdk.CodespaceType = 12
+
+	CodeAuthorizationNotFound sdk.CodeType = 1
+	CodeAuthorizationExpired  sdk.CodeType = 2
+	CodeSpendLimitExceeded    sdk.CodeType = 3
+	CodeMsgNotAuthorized      sdk.CodeType = 4
+	CodeInvalidAuthorization  sdk.CodeType = 5
+	CodeInvalidGrantee        sdk.CodeType = 6
+	CodeNoMsgs                sdk.CodeType = 7
+)
+
+//----------------------------------------
+// Error constructors
+
+func ErrAuthorizationNotFound(codespace sdk.CodespaceType, granter, grantee sdk.AccAddress, msgType string) sdk.Error {
+	return sdk.NewError(codespace, CodeAuthorizationNotFound, fmt.Sprintf("%s granted no authorization of %s msgs to %s", granter, msgType, grantee))
+}
+
+func ErrAuthorizationExpired(codespace sdk.CodespaceType, expiration time.Time) sdk.Error {
+	return sdk.NewError(codespace, CodeAuthorizationExpired, fmt.Sprintf("authorization expired at %v", expiration))
+}
+
+func ErrSpendLimitExceeded(codespace sdk.CodespaceType, amount, spendLimit sdk.Coins) sdk.Error {
+	return sdk.NewError(codespace, CodeSpendLimitExceeded, fmt.Sprintf("amount %s exceeds the spend limit %s", amount, spendLimit))
+}
+
+func ErrMsgNotAuthorized(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeMsgNotAuthorized, msg)
+}
+
+func ErrInvalidAuthorization(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidAuthorization, msg)
+}
+
+func ErrInvalidGrantee(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidGrantee, msg)
+}
+
+func ErrNoMsgs(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeNoMsgs, "no msgs to execute")
+}
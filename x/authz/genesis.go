@@ -0,0 +1,45 @@
+package authz
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - the authorizations given at genesis
+type GenesisState struct {
+	AuthorizationGrants []AuthorizationGrant `json:"authorization_grants"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// InitGenesis - store the authorizations
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	for _, grant := range data.AuthorizationGrants {
+		k.GrantAuthorization(ctx, grant)
+	}
+}
+
+// WriteGenesis - output the authorizations
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	var grants []AuthorizationGrant
+	k.IterateAuthorizationGrants(ctx, func(grant AuthorizationGrant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	return GenesisState{grants}
+}
+
+// ValidateGenesis checks the authorizations
+func ValidateGenesis(data GenesisState) error {
+	for _, grant := range data.AuthorizationGrants {
+		msg := NewMsgGrantAuthorization(grant.Granter, grant.Grantee, grant.Authorization, grant.Expiration)
+		if err := msg.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid authorization of %s to %s: %v", grant.Granter, grant.Grantee, err.Result().Log)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package authz
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Handle all "authz" type messages.
+func NewHandler(keeper Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgGrantAuthorization:
+			return handleMsgGrantAuthorization(ctx, keeper, msg)
+		case MsgRevokeAuthorization:
+			return handleMsgRevokeAuthorization(ctx, keeper, msg)
+		case MsgExec:
+			return handleMsgExec(ctx, keeper, msg)
+		default:
+			errMsg := "Unrecognized authz msg type"
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+func handleMsgGrantAuthorization(ctx sdk.Context, keeper Keeper, msg MsgGrantAuthorization) sdk.Result {
+	keeper.GrantAuthorization(ctx, AuthorizationGrant{
+		Granter:       msg.Granter,
+		Grantee:       msg.Grantee,
+		Authorization: msg.Authorization,
+		Expiration:    msg.Expiration,
+	})
+	return sdk.Result{
+		Tags: grantTags(msg.Granter, msg.Grantee, msg.Authorization.MsgType()),
+	}
+}
+
+func handleMsgRevokeAuthorization(ctx sdk.Context, keeper Keeper, msg MsgRevokeAuthorization) sdk.Result {
+	err := keeper.RevokeAuthorization(ctx, msg.Granter, msg.Grantee, msg.MsgType)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: grantTags(msg.Granter, msg.Grantee, msg.MsgType),
+	}
+}
+
+func handleMsgExec(ctx sdk.Context, keeper Keeper, msg MsgExec) sdk.Result {
+	result := keeper.DispatchMsgs(ctx, msg.Grantee, msg.Msgs)
+	result.Tags = append(result.Tags, sdk.MakeTag(TagGrantee, []byte(msg.Grantee.String())))
+	return result
+}
+
+func grantTags(granter, grantee sdk.AccAddress, msgType string) sdk.Tags {
+	return sdk.NewTags(
+		TagGranter, []byte(granter.String()),
+		TagGrantee, []byte(grantee.String()),
+		TagMsgType, []byte(msgType),
+	)
+}
+
+// nolint
+var (
+	TagGranter = "granter"
+	TagGrantee = "grantee"
+	TagMsgType = "msg-type"
+)
@@ -0,0 +1,178 @@
+package authz
+
+import (
+	"bytes"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Keeper of the authorizations
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *codec.Codec
+	router   baseapp.Router // routes the msgs executed on behalf of the granters
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, router baseapp.Router, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:  key,
+		cdc:       cdc,
+		router:    router,
+		codespace: codespace,
+	}
+}
+
+// Keys for authorization store items
+var (
+	AuthorizationKeyPrefix = []byte{0x00} // prefix for the authorizations by granter, grantee and msg type
+)
+
+// gets the prefix of the authorizations a granter gave to a grantee
+func GetAuthorizationsKey(granter, grantee sdk.AccAddress) []byte {
+	return append(append(AuthorizationKeyPrefix, granter.Bytes()...), grantee.Bytes()...)
+}
+
+// gets the key of the authorization of a msg type a granter gave to a grantee
+func GetAuthorizationKey(granter, grantee sdk.AccAddress, msgType string) []byte {
+	return append(GetAuthorizationsKey(granter, grantee), []byte(msgType)...)
+}
+
+//______________________________________________________________________
+
+// GetAuthorizationGrant returns the authorization of a msg type the granter
+// gave to the grantee
+func (k Keeper) GetAuthorizationGrant(ctx sdk.Context, granter, grantee sdk.AccAddress, msgType string) (grant AuthorizationGrant, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(GetAuthorizationKey(granter, grantee, msgType))
+	if bz == nil {
+		return grant, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &grant)
+	return grant, true
+}
+
+// GrantAuthorization sets the authorization the granter gives to the grantee,
+// replacing any previous one of the same msg type
+func (k Keeper) GrantAuthorization(ctx sdk.Context, grant AuthorizationGrant) {
+	key := GetAuthorizationKey(grant.Granter, grant.Grantee, grant.Authorization.MsgType())
+	ctx.KVStore(k.storeKey).Set(key, k.cdc.MustMarshalBinary(grant))
+}
+
+// RevokeAuthorization removes the authorization of a msg type the granter
+// gave to the grantee
+func (k Keeper) RevokeAuthorization(ctx sdk.Context, granter, grantee sdk.AccAddress, msgType string) sdk.Error {
+	store := ctx.KVStore(k.storeKey)
+	key := GetAuthorizationKey(granter, grantee, msgType)
+	if !store.Has(key) {
+		return ErrAuthorizationNotFound(k.codespace, granter, grantee, msgType)
+	}
+	store.Delete(key)
+	return nil
+}
+
+// GetAuthorizationGrants returns the authorizations the granter gave to the
+// grantee
+func (k Keeper) GetAuthorizationGrants(ctx sdk.Context, granter, grantee sdk.AccAddress) (grants []AuthorizationGrant) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), GetAuthorizationsKey(granter, grantee))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var grant AuthorizationGrant
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &grant)
+		grants = append(grants, grant)
+	}
+	return grants
+}
+
+// IterateAuthorizationGrants iterates over all the authorizations until the
+// function returns true
+func (k Keeper) IterateAuthorizationGrants(ctx sdk.Context, fn func(grant AuthorizationGrant) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), AuthorizationKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var grant AuthorizationGrant
+		k.cdc.MustUnmarshalBinary(iterator.Value(), &grant)
+		if fn(grant) {
+			break
+		}
+	}
+}
+
+// DispatchMsgs executes the msgs on behalf of their signers, each signer other
+// than the grantee having authorized the grantee to execute the msg. The msgs
+// are routed through the router of the app, and the execution stops at the
+// first failed msg.
+func (k Keeper) DispatchMsgs(ctx sdk.Context, grantee sdk.AccAddress, msgs []sdk.Msg) sdk.Result {
+	msgLogs := make([]sdk.MsgLog, 0, len(msgs))
+	msgResults := make([]sdk.MsgResult, 0, len(msgs))
+	var tags sdk.Tags
+	for msgIdx, msg := range msgs {
+		for _, granter := range msg.GetSigners() {
+			if bytes.Equal(granter, grantee) {
+				continue
+			}
+			err := k.useAuthorization(ctx, granter, grantee, msg)
+			if err != nil {
+				return err.Result()
+			}
+		}
+
+		msgType := msg.Type()
+		handler := k.router.Route(msgType)
+		if handler == nil {
+			return sdk.ErrUnknownRequest("Unrecognized Msg type: " + msgType).Result()
+		}
+		msgResult := handler(ctx, msg)
+		msgResult.Tags = append(msgResult.Tags, sdk.MakeTag(sdk.TagAction, []byte(msg.Name())))
+
+		msgResults = append(msgResults, sdk.MsgResult{
+			MsgIndex: msgIdx,
+			Route:    msgType,
+			Data:     msgResult.Data,
+			Tags:     msgResult.Tags,
+		})
+		tags = append(tags, msgResult.Tags...)
+		msgLogs = append(msgLogs, sdk.MsgLog{
+			MsgIndex: msgIdx,
+			Success:  msgResult.IsOK(),
+			Log:      msgResult.Log,
+		})
+
+		if !msgResult.IsOK() {
+			msgResult.Log = sdk.EncodeMsgLogs(msgLogs)
+			return msgResult
+		}
+	}
+
+	return sdk.Result{
+		Data: sdk.EncodeMsgResults(msgResults),
+		Log:  sdk.EncodeMsgLogs(msgLogs),
+		Tags: tags,
+	}
+}
+
+// checks a msg executed on behalf of the granter against the authorization it
+// gave to the grantee, the used up authorizations being removed
+func (k Keeper) useAuthorization(ctx sdk.Context, granter, grantee sdk.AccAddress, msg sdk.Msg) sdk.Error {
+	msgType := MsgType(msg)
+	grant, found := k.GetAuthorizationGrant(ctx, granter, grantee, msgType)
+	if !found {
+		return ErrAuthorizationNotFound(k.codespace, granter, grantee, msgType)
+	}
+	if !grant.Expiration.IsZero() && !ctx.BlockHeader().Time.Before(grant.Expiration) {
+		return ErrAuthorizationExpired(k.codespace, grant.Expiration)
+	}
+	updated, usedUp, err := grant.Authorization.Accept(granter, msg)
+	if err != nil {
+		return err
+	}
+	if usedUp {
+		return k.RevokeAuthorization(ctx, granter, grantee, msgType)
+	}
+	grant.Authorization = updated
+	k.GrantAuthorization(ctx, grant)
+	return nil
+}
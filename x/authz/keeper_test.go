@@ -0,0 +1,196 @@
+package authz
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+)
+
+var (
+	granter = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	grantee = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	other   = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+)
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper, *[]sdk.Msg) {
+	keyAuthz := sdk.NewKVStoreKey("authz")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAuthz, sdk.StoreTypeIAVL, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+
+	// the routed msgs are recorded, the msgs to the other address failing
+	var routed []sdk.Msg
+	handler := func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		routed = append(routed, msg)
+		if send, ok := msg.(bank.MsgSend); ok && send.Outputs[0].Address.Equals(other) {
+			return sdk.ErrUnauthorized("").Result()
+		}
+		return sdk.Result{Data: []byte(msg.Name())}
+	}
+	router := baseapp.NewRouter().AddRoute("bank", handler).AddRoute("gov", handler)
+
+	cdc := codec.New()
+	RegisterCodec(cdc)
+	ctx := sdk.NewContext(ms, abci.Header{Height: 10, Time: time.Unix(1000, 0).UTC()}, false, log.NewNopLogger())
+	return ctx, NewKeeper(cdc, keyAuthz, router, DefaultCodespace), &routed
+}
+
+func newSend(from, to sdk.AccAddress, amount int64) bank.MsgSend {
+	coins := sdk.Coins{sdk.NewInt64Coin("atom", amount)}
+	return bank.NewMsgSend([]bank.Input{bank.NewInput(from, coins)}, []bank.Output{bank.NewOutput(to, coins)})
+}
+
+func TestAuthorizationAccept(t *testing.T) {
+	spendLimit := sdk.Coins{sdk.NewInt64Coin("atom", 100)}
+	vote := gov.NewMsgVote(granter, 3, gov.OptionYes)
+
+	tests := []struct {
+		authorization Authorization
+		msg           sdk.Msg
+		updated       Authorization
+		usedUp        bool
+		code          sdk.CodeType
+	}{
+		{SendAuthorization{spendLimit}, newSend(granter, grantee, 40), SendAuthorization{sdk.Coins{sdk.NewInt64Coin("atom", 60)}}, false, sdk.CodeOK},
+		{SendAuthorization{spendLimit}, newSend(granter, grantee, 100), SendAuthorization{nil}, true, sdk.CodeOK},
+		{SendAuthorization{spendLimit}, newSend(granter, grantee, 101), nil, false, CodeSpendLimitExceeded},
+		{SendAuthorization{spendLimit}, vote, nil, false, CodeMsgNotAuthorized},
+		{VoteAuthorization{3}, vote, VoteAuthorization{3}, false, sdk.CodeOK},
+		{VoteAuthorization{4}, vote, nil, false, CodeMsgNotAuthorized},
+		{GenericAuthorization{"gov/vote"}, vote, GenericAuthorization{"gov/vote"}, false, sdk.CodeOK},
+	}
+
+	for i, tc := range tests {
+		updated, usedUp, err := tc.authorization.Accept(granter, tc.msg)
+		if tc.code != sdk.CodeOK {
+			require.NotNil(t, err, "test: %v", i)
+			require.Equal(t, tc.code, err.Code(), "test: %v", i)
+			continue
+		}
+		require.Nil(t, err, "test: %v", i)
+		require.Equal(t, tc.updated, updated, "test: %v", i)
+		require.Equal(t, tc.usedUp, usedUp, "test: %v", i)
+	}
+}
+
+func TestMsgValidateBasic(t *testing.T) {
+	tests := []struct {
+		msg        sdk.Msg
+		expectPass bool
+	}{
+		{NewMsgGrantAuthorization(granter, grantee, SendAuthorization{sdk.Coins{sdk.NewInt64Coin("atom", 1)}}, time.Time{}), true},
+		{NewMsgGrantAuthorization(granter, grantee, SendAuthorization{sdk.Coins{}}, time.Time{}), false},
+		{NewMsgGrantAuthorization(granter, grantee, GenericAuthorization{"stake/delegate"}, time.Time{}), true},
+		{NewMsgGrantAuthorization(granter, grantee, GenericAuthorization{"stake"}, time.Time{}), false},
+		{NewMsgGrantAuthorization(granter, grantee, nil, time.Time{}), false},
+		{NewMsgGrantAuthorization(granter, granter, VoteAuthorization{1}, time.Time{}), false},
+		{NewMsgGrantAuthorization(sdk.AccAddress{}, grantee, VoteAuthorization{1}, time.Time{}), false},
+		{NewMsgRevokeAuthorization(granter, grantee, "bank/send"), true},
+		{NewMsgRevokeAuthorization(granter, grantee, ""), false},
+		{NewMsgExec(grantee, []sdk.Msg{newSend(granter, grantee, 1)}), true},
+		{NewMsgExec(grantee, []sdk.Msg{newSend(granter, grantee, 0)}), false},
+		{NewMsgExec(grantee, nil), false},
+		{NewMsgExec(sdk.AccAddress{}, []sdk.Msg{newSend(granter, grantee, 1)}), false},
+	}
+
+	for i, tc := range tests {
+		if tc.expectPass {
+			require.Nil(t, tc.msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, tc.msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+func TestGrantAndRevokeAuthorization(t *testing.T) {
+	ctx, keeper, _ := createTestInput(t)
+
+	_, found := keeper.GetAuthorizationGrant(ctx, granter, grantee, "gov/vote")
+	require.False(t, found)
+	require.NotNil(t, keeper.RevokeAuthorization(ctx, granter, grantee, "gov/vote"))
+
+	keeper.GrantAuthorization(ctx, AuthorizationGrant{granter, grantee, VoteAuthorization{1}, time.Time{}})
+	keeper.GrantAuthorization(ctx, AuthorizationGrant{granter, grantee, VoteAuthorization{2}, time.Time{}})
+	keeper.GrantAuthorization(ctx, AuthorizationGrant{granter, grantee, GenericAuthorization{"stake/delegate"}, time.Time{}})
+	keeper.GrantAuthorization(ctx, AuthorizationGrant{grantee, granter, GenericAuthorization{"stake/delegate"}, time.Time{}})
+
+	// a grant replaces the authorization of the same msg type
+	grant, found := keeper.GetAuthorizationGrant(ctx, granter, grantee, "gov/vote")
+	require.True(t, found)
+	require.Equal(t, VoteAuthorization{2}, grant.Authorization)
+	require.Len(t, keeper.GetAuthorizationGrants(ctx, granter, grantee), 2)
+	require.Len(t, WriteGenesis(ctx, keeper).AuthorizationGrants, 3)
+
+	require.Nil(t, keeper.RevokeAuthorization(ctx, granter, grantee, "gov/vote"))
+	grants := keeper.GetAuthorizationGrants(ctx, granter, grantee)
+	require.Len(t, grants, 1)
+	require.Equal(t, GenericAuthorization{"stake/delegate"}, grants[0].Authorization)
+}
+
+func TestDispatchMsgs(t *testing.T) {
+	ctx, keeper, routed := createTestInput(t)
+	send := newSend(granter, other, 40)
+
+	// the msgs of the grantee need no authorization
+	res := keeper.DispatchMsgs(ctx, grantee, []sdk.Msg{newSend(grantee, granter, 40)})
+	require.True(t, res.IsOK(), res.Log)
+	require.Len(t, *routed, 1)
+
+	res = keeper.DispatchMsgs(ctx, grantee, []sdk.Msg{newSend(granter, grantee, 40)})
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeAuthorizationNotFound), res.Code)
+	require.Len(t, *routed, 1)
+
+	// the spend limit decreases with each send until it is used up
+	keeper.GrantAuthorization(ctx, AuthorizationGrant{granter, grantee, SendAuthorization{sdk.Coins{sdk.NewInt64Coin("atom", 100)}}, time.Time{}})
+	keeper.GrantAuthorization(ctx, AuthorizationGrant{granter, grantee, VoteAuthorization{3}, time.Time{}})
+	res = keeper.DispatchMsgs(ctx, grantee, []sdk.Msg{newSend(granter, grantee, 40), gov.NewMsgVote(granter, 3, gov.OptionNo)})
+	require.True(t, res.IsOK(), res.Log)
+	require.Len(t, *routed, 3)
+	msgResults, err := sdk.DecodeMsgResults(res.Data)
+	require.Nil(t, err)
+	require.Len(t, msgResults, 2)
+	require.Equal(t, []byte("vote"), msgResults[1].Data)
+	msgLogs, err := sdk.DecodeMsgLogs(res.Log)
+	require.Nil(t, err)
+	require.Equal(t, []sdk.MsgLog{{MsgIndex: 0, Success: true}, {MsgIndex: 1, Success: true}}, msgLogs)
+	grant, _ := keeper.GetAuthorizationGrant(ctx, granter, grantee, "bank/send")
+	require.True(t, sdk.Coins{sdk.NewInt64Coin("atom", 60)}.IsEqual(grant.Authorization.(SendAuthorization).SpendLimit))
+
+	res = keeper.DispatchMsgs(ctx, grantee, []sdk.Msg{newSend(granter, grantee, 70)})
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeSpendLimitExceeded), res.Code)
+	res = keeper.DispatchMsgs(ctx, grantee, []sdk.Msg{gov.NewMsgVote(granter, 4, gov.OptionNo)})
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeMsgNotAuthorized), res.Code)
+	require.Len(t, *routed, 3)
+
+	// the execution stops at the first failed msg, the tx reverting the use
+	// of the authorization
+	res = keeper.DispatchMsgs(ctx, grantee, []sdk.Msg{send, newSend(granter, grantee, 10)})
+	require.Equal(t, sdk.ToABCICode(sdk.CodespaceRoot, sdk.CodeUnauthorized), res.Code)
+	require.Len(t, *routed, 4)
+
+	res = keeper.DispatchMsgs(ctx, grantee, []sdk.Msg{newSend(granter, grantee, 20)})
+	require.True(t, res.IsOK(), res.Log)
+	_, found := keeper.GetAuthorizationGrant(ctx, granter, grantee, "bank/send")
+	require.False(t, found)
+
+	// the expired authorizations are rejected
+	keeper.GrantAuthorization(ctx, AuthorizationGrant{granter, grantee, GenericAuthorization{"bank/send"}, ctx.BlockHeader().Time})
+	res = keeper.DispatchMsgs(ctx, grantee, []sdk.Msg{newSend(granter, grantee, 20)})
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeAuthorizationExpired), res.Code)
+}
@@ -0,0 +1,191 @@
+package authz
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name to identify transaction types
+const MsgRoute = "authz"
+
+var _, _, _ sdk.Msg = MsgGrantAuthorization{}, MsgRevokeAuthorization{}, MsgExec{}
+
+//-----------------------------------------------------------
+// MsgGrantAuthorization
+type MsgGrantAuthorization struct {
+	Granter       sdk.AccAddress `json:"granter"`       // address whose msgs are authorized
+	Grantee       sdk.AccAddress `json:"grantee"`       // address executing the msgs
+	Authorization Authorization  `json:"authorization"` // authorization replacing any previous one of its msg type
+	Expiration    time.Time      `json:"expiration"`    // time from which the authorization is rejected, never if zero
+}
+
+func NewMsgGrantAuthorization(granter, grantee sdk.AccAddress, authorization Authorization, expiration time.Time) MsgGrantAuthorization {
+	return MsgGrantAuthorization{
+		Granter:       granter,
+		Grantee:       grantee,
+		Authorization: authorization,
+		Expiration:    expiration,
+	}
+}
+
+// Implements Msg.
+func (msg MsgGrantAuthorization) Type() string { return MsgRoute }
+func (msg MsgGrantAuthorization) Name() string { return "grant_authorization" }
+
+// Implements Msg.
+func (msg MsgGrantAuthorization) ValidateBasic() sdk.Error {
+	if err := validateGrant(msg.Granter, msg.Grantee); err != nil {
+		return err
+	}
+	if msg.Authorization == nil {
+		return ErrInvalidAuthorization(DefaultCodespace, "missing authorization")
+	}
+	return msg.Authorization.ValidateBasic()
+}
+
+func (msg MsgGrantAuthorization) String() string {
+	return fmt.Sprintf("MsgGrantAuthorization{%s, %s, %v, %v}", msg.Granter, msg.Grantee, msg.Authorization, msg.Expiration)
+}
+
+// Implements Msg.
+func (msg MsgGrantAuthorization) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgGrantAuthorization) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+//-----------------------------------------------------------
+// MsgRevokeAuthorization
+type MsgRevokeAuthorization struct {
+	Granter sdk.AccAddress `json:"granter"`  // address which gave the authorization
+	Grantee sdk.AccAddress `json:"grantee"`  // address the authorization was given to
+	MsgType string         `json:"msg_type"` // msg type of the authorization, e.g. bank/send
+}
+
+func NewMsgRevokeAuthorization(granter, grantee sdk.AccAddress, msgType string) MsgRevokeAuthorization {
+	return MsgRevokeAuthorization{
+		Granter: granter,
+		Grantee: grantee,
+		MsgType: msgType,
+	}
+}
+
+// Implements Msg.
+func (msg MsgRevokeAuthorization) Type() string { return MsgRoute }
+func (msg MsgRevokeAuthorization) Name() string { return "revoke_authorization" }
+
+// Implements Msg.
+func (msg MsgRevokeAuthorization) ValidateBasic() sdk.Error {
+	if err := validateGrant(msg.Granter, msg.Grantee); err != nil {
+		return err
+	}
+	if len(msg.MsgType) == 0 {
+		return ErrInvalidAuthorization(DefaultCodespace, "missing msg type")
+	}
+	return nil
+}
+
+func (msg MsgRevokeAuthorization) String() string {
+	return fmt.Sprintf("MsgRevokeAuthorization{%s, %s, %s}", msg.Granter, msg.Grantee, msg.MsgType)
+}
+
+// Implements Msg.
+func (msg MsgRevokeAuthorization) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgRevokeAuthorization) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+//-----------------------------------------------------------
+// MsgExec
+type MsgExec struct {
+	Grantee sdk.AccAddress `json:"grantee"` // address executing the msgs
+	Msgs    []sdk.Msg      `json:"msgs"`    // msgs executed on behalf of their signers
+}
+
+func NewMsgExec(grantee sdk.AccAddress, msgs []sdk.Msg) MsgExec {
+	return MsgExec{
+		Grantee: grantee,
+		Msgs:    msgs,
+	}
+}
+
+// Implements Msg.
+func (msg MsgExec) Type() string { return MsgRoute }
+func (msg MsgExec) Name() string { return "exec" }
+
+// Implements Msg.
+func (msg MsgExec) ValidateBasic() sdk.Error {
+	if len(msg.Grantee) == 0 {
+		return sdk.ErrInvalidAddress(msg.Grantee.String())
+	}
+	if len(msg.Msgs) == 0 {
+		return ErrNoMsgs(DefaultCodespace)
+	}
+	for _, m := range msg.Msgs {
+		if err := m.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (msg MsgExec) String() string {
+	return fmt.Sprintf("MsgExec{%s, %v}", msg.Grantee, msg.Msgs)
+}
+
+// Implements Msg.
+func (msg MsgExec) GetSignBytes() []byte {
+	var msgs []json.RawMessage
+	for _, m := range msg.Msgs {
+		msgs = append(msgs, m.GetSignBytes())
+	}
+	b, err := msgCdc.MarshalJSON(struct {
+		Grantee sdk.AccAddress    `json:"grantee"`
+		Msgs    []json.RawMessage `json:"msgs"`
+	}{
+		Grantee: msg.Grantee,
+		Msgs:    msgs,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgExec) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Grantee}
+}
+
+// a granter cannot authorize itself
+func validateGrant(granter, grantee sdk.AccAddress) sdk.Error {
+	if len(granter) == 0 {
+		return sdk.ErrInvalidAddress(granter.String())
+	}
+	if len(grantee) == 0 {
+		return sdk.ErrInvalidAddress(grantee.String())
+	}
+	if bytes.Equal(granter, grantee) {
+		return ErrInvalidGrantee(DefaultCodespace, "cannot authorize oneself")
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package authz
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// query endpoints supported by the authz Querier
+const (
+	QueryAuthorization  = "authorization"
+	QueryAuthorizations = "authorizations"
+)
+
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryAuthorization:
+			return queryAuthorization(ctx, req, keeper)
+		case QueryAuthorizations:
+			return queryAuthorizations(ctx, req, keeper)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown authz query endpoint")
+		}
+	}
+}
+
+// Params for query 'custom/authz/authorization'
+type QueryAuthorizationParams struct {
+	Granter sdk.AccAddress
+	Grantee sdk.AccAddress
+	MsgType string
+}
+
+func queryAuthorization(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryAuthorizationParams
+	err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("incorrectly formatted request data - %s", err2.Error()))
+	}
+
+	grant, found := keeper.GetAuthorizationGrant(ctx, params.Granter, params.Grantee, params.MsgType)
+	if !found {
+		return []byte{}, ErrAuthorizationNotFound(DefaultCodespace, params.Granter, params.Grantee, params.MsgType)
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, grant)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+	return bz, nil
+}
+
+// Params for query 'custom/authz/authorizations'
+type QueryAuthorizationsParams struct {
+	Granter sdk.AccAddress
+	Grantee sdk.AccAddress
+}
+
+func queryAuthorizations(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryAuthorizationsParams
+	err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("incorrectly formatted request data - %s", err2.Error()))
+	}
+
+	grants := keeper.GetAuthorizationGrants(ctx, params.Granter, params.Grantee)
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, grants)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+	return bz, nil
+}
@@ -13,6 +13,7 @@ const (
 	costSetCoins      sdk.Gas = 100
 	costSubtractCoins sdk.Gas = 10
 	costAddCoins      sdk.Gas = 10
+	costDelegateCoins sdk.Gas = 10
 )
 
 // Keeper defines a module interface that facilitates the transfer of coins
@@ -22,6 +23,9 @@ type Keeper interface {
 	SetCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) sdk.Error
 	SubtractCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error)
 	AddCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error)
+
+	DelegateCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error)
+	UndelegateCoins(ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error)
 }
 
 var _ Keeper = (*BaseKeeper)(nil)
@@ -68,6 +72,24 @@ func (keeper BaseKeeper) AddCoins(
 	return addCoins(ctx, keeper.am, addr, amt)
 }
 
+// DelegateCoins subtracts the delegated amt from the coins at the addr, locked
+// vesting coins included, and tracks the delegation of vesting accounts.
+func (keeper BaseKeeper) DelegateCoins(
+	ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins,
+) (sdk.Tags, sdk.Error) {
+
+	return delegateCoins(ctx, keeper.am, addr, amt)
+}
+
+// UndelegateCoins adds the undelegated amt to the coins at the addr and tracks
+// the undelegation of vesting accounts.
+func (keeper BaseKeeper) UndelegateCoins(
+	ctx sdk.Context, addr sdk.AccAddress, amt sdk.Coins,
+) (sdk.Tags, sdk.Error) {
+
+	return undelegateCoins(ctx, keeper.am, addr, amt)
+}
+
 // SendCoins moves coins from one account to another
 func (keeper BaseKeeper) SendCoins(
 	ctx sdk.Context, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins,
@@ -193,14 +215,29 @@ func hasCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress, amt s
 	return getCoins(ctx, am, addr).IsGTE(amt)
 }
 
+// getSpendableCoins returns the coins at the addr which are not locked by vesting.
+func getSpendableCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress) sdk.Coins {
+	ctx.GasMeter().ConsumeGas(costGetCoins, "getSpendableCoins")
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		return sdk.Coins{}
+	}
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		return vacc.SpendableCoins(ctx.BlockHeader().Time)
+	}
+	return acc.GetCoins()
+}
+
 // SubtractCoins subtracts amt from the coins at the addr.
+// Locked vesting coins cannot be subtracted.
 func subtractCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress, amt sdk.Coins) (sdk.Coins, sdk.Tags, sdk.Error) {
 	ctx.GasMeter().ConsumeGas(costSubtractCoins, "subtractCoins")
+	spendableCoins := getSpendableCoins(ctx, am, addr)
+	if !spendableCoins.Minus(amt).IsNotNegative() {
+		return amt, nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", spendableCoins, amt))
+	}
 	oldCoins := getCoins(ctx, am, addr)
 	newCoins := oldCoins.Minus(amt)
-	if !newCoins.IsNotNegative() {
-		return amt, nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", oldCoins, amt))
-	}
 	err := setCoins(ctx, am, addr, newCoins)
 	tags := sdk.NewTags("sender", []byte(addr.String()))
 	return newCoins, tags, err
@@ -219,6 +256,57 @@ func addCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress, amt s
 	return newCoins, tags, err
 }
 
+// delegateCoins subtracts the delegated amt from the coins at the addr, which
+// may include locked vesting coins.
+func delegateCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	ctx.GasMeter().ConsumeGas(costDelegateCoins, "delegateCoins")
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		return nil, sdk.ErrUnknownAddress(fmt.Sprintf("account %s does not exist", addr))
+	}
+	oldCoins := acc.GetCoins()
+	newCoins := oldCoins.Minus(amt)
+	if !newCoins.IsNotNegative() {
+		return nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", oldCoins, amt))
+	}
+
+	// the delegation is tracked before the coins are subtracted
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		vacc.TrackDelegation(ctx.BlockHeader().Time, amt)
+	}
+	err := acc.SetCoins(newCoins)
+	if err != nil {
+		// Handle w/ #870
+		panic(err)
+	}
+	am.SetAccount(ctx, acc)
+	return sdk.NewTags("delegator", []byte(addr.String())), nil
+}
+
+// undelegateCoins adds the undelegated amt to the coins at the addr.
+func undelegateCoins(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error) {
+	ctx.GasMeter().ConsumeGas(costDelegateCoins, "undelegateCoins")
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		acc = am.NewAccountWithAddress(ctx, addr)
+	}
+	newCoins := acc.GetCoins().Plus(amt)
+	if !newCoins.IsNotNegative() {
+		return nil, sdk.ErrInsufficientCoins(fmt.Sprintf("%s < %s", acc.GetCoins(), amt))
+	}
+
+	if vacc, ok := acc.(auth.VestingAccount); ok {
+		vacc.TrackUndelegation(amt)
+	}
+	err := acc.SetCoins(newCoins)
+	if err != nil {
+		// Handle w/ #870
+		panic(err)
+	}
+	am.SetAccount(ctx, acc)
+	return sdk.NewTags("delegator", []byte(addr.String())), nil
+}
+
 // SendCoins moves coins from one account to another
 // NOTE: Make sure to revert state changes from tx on error
 func sendCoins(ctx sdk.Context, am auth.AccountMapper, fromAddr sdk.AccAddress, toAddr sdk.AccAddress, amt sdk.Coins) (sdk.Tags, sdk.Error) {
@@ -2,6 +2,7 @@ package bank
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -207,3 +208,98 @@ func TestViewKeeper(t *testing.T) {
 	require.False(t, viewKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("foocoin", 15)}))
 	require.False(t, viewKeeper.HasCoins(ctx, addr, sdk.Coins{sdk.NewInt64Coin("barcoin", 5)}))
 }
+
+func TestVestingAccountSend(t *testing.T) {
+	ms, authKey := setupMultiStore()
+
+	cdc := codec.New()
+	auth.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	now := time.Now()
+	ctx := sdk.NewContext(ms, abci.Header{Time: now}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, auth.ProtoBaseAccount)
+	bankKeeper := NewBaseKeeper(accountMapper)
+
+	origCoins := sdk.Coins{sdk.NewInt64Coin("steak", 100)}
+	sendCoins := sdk.Coins{sdk.NewInt64Coin("steak", 50)}
+	addr1 := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+
+	bacc := auth.NewBaseAccountWithAddress(addr1)
+	bacc.SetCoins(origCoins)
+	vacc := auth.NewContinuousVestingAccount(&bacc, now, now.Add(24*time.Hour))
+	accountMapper.SetAccount(ctx, vacc)
+
+	// require that no coins be sendable at the beginning of the vesting schedule
+	_, err := bankKeeper.SendCoins(ctx, addr1, addr2, sendCoins)
+	require.NotNil(t, err)
+	_, _, err = bankKeeper.SubtractCoins(ctx, addr1, sendCoins)
+	require.NotNil(t, err)
+
+	// receive some coins, which are sendable
+	bankKeeper.AddCoins(ctx, addr1, sendCoins)
+	_, err = bankKeeper.SendCoins(ctx, addr1, addr2, sendCoins)
+	require.Nil(t, err)
+
+	// require that all vested coins be sendable halfway through the vesting schedule
+	ctx = ctx.WithBlockHeader(abci.Header{Time: now.Add(12 * time.Hour)})
+	_, err = bankKeeper.SendCoins(ctx, addr1, addr2, sendCoins)
+	require.Nil(t, err)
+	_, err = bankKeeper.SendCoins(ctx, addr1, addr2, sendCoins)
+	require.NotNil(t, err)
+
+	// require that the vesting account coins were sent
+	vacc = accountMapper.GetAccount(ctx, addr1).(*auth.ContinuousVestingAccount)
+	require.True(t, vacc.GetCoins().IsEqual(sendCoins))
+	require.True(t, bankKeeper.GetCoins(ctx, addr2).IsEqual(origCoins))
+}
+
+func TestDelegateCoins(t *testing.T) {
+	ms, authKey := setupMultiStore()
+
+	cdc := codec.New()
+	auth.RegisterCodec(cdc)
+	codec.RegisterCrypto(cdc)
+
+	now := time.Now()
+	ctx := sdk.NewContext(ms, abci.Header{Time: now}, false, log.NewNopLogger())
+	accountMapper := auth.NewAccountMapper(cdc, authKey, auth.ProtoBaseAccount)
+	bankKeeper := NewBaseKeeper(accountMapper)
+
+	origCoins := sdk.Coins{sdk.NewInt64Coin("steak", 100)}
+	delCoins := sdk.Coins{sdk.NewInt64Coin("steak", 50)}
+	addr1 := sdk.AccAddress([]byte("addr1"))
+	addr2 := sdk.AccAddress([]byte("addr2"))
+
+	bacc := auth.NewBaseAccountWithAddress(addr1)
+	bacc.SetCoins(origCoins)
+	vacc := auth.NewDelayedVestingAccount(&bacc, now.Add(24*time.Hour))
+	accountMapper.SetAccount(ctx, vacc)
+	bankKeeper.SetCoins(ctx, addr2, origCoins)
+
+	// require the ability for a non-vesting account to delegate
+	_, err := bankKeeper.DelegateCoins(ctx, addr2, delCoins)
+	require.Nil(t, err)
+	require.True(t, bankKeeper.GetCoins(ctx, addr2).IsEqual(delCoins))
+
+	// require the ability for a vesting account to delegate its locked coins
+	_, err = bankKeeper.DelegateCoins(ctx, addr1, delCoins)
+	require.Nil(t, err)
+	vacc = accountMapper.GetAccount(ctx, addr1).(*auth.DelayedVestingAccount)
+	require.True(t, vacc.GetCoins().IsEqual(delCoins))
+	require.True(t, vacc.GetDelegatedVesting().IsEqual(delCoins))
+
+	// require that delegating more than the coins of the account fails
+	_, err = bankKeeper.DelegateCoins(ctx, addr1, origCoins)
+	require.NotNil(t, err)
+
+	// require that undelegated locked coins remain locked
+	_, err = bankKeeper.UndelegateCoins(ctx, addr1, delCoins)
+	require.Nil(t, err)
+	vacc = accountMapper.GetAccount(ctx, addr1).(*auth.DelayedVestingAccount)
+	require.True(t, vacc.GetCoins().IsEqual(origCoins))
+	require.True(t, vacc.GetDelegatedVesting().IsZero())
+	_, err = bankKeeper.SendCoins(ctx, addr1, addr2, delCoins)
+	require.NotNil(t, err)
+}
@@ -0,0 +1,33 @@
+package distribution
+
+import (
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/keeper"
+)
+
+// distribute the fees collected in the previous block and record the
+// proposer of this block for the next allocation
+func BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock, k keeper.Keeper) {
+
+	// determine the total number of signed power
+	totalPower, sumPrecommitPower := int64(0), int64(0)
+	for _, voteInfo := range req.LastCommitInfo.GetValidators() {
+		totalPower += voteInfo.Validator.Power
+		if voteInfo.SignedLastBlock {
+			sumPrecommitPower += voteInfo.Validator.Power
+		}
+	}
+
+	// allocate the fees collected during the previous block, which is only
+	// possible once the previous proposer has been recorded
+	if ctx.BlockHeight() > 1 && totalPower > 0 {
+		fractionVotes := sdk.NewDec(sumPrecommitPower).Quo(sdk.NewDec(totalPower))
+		previousProposer := k.GetPreviousProposerConsAddr(ctx)
+		k.AllocateFees(ctx, fractionVotes, previousProposer)
+	}
+
+	consAddr := sdk.ConsAddress(req.Header.Proposer.Address)
+	k.SetPreviousProposerConsAddr(ctx, consAddr)
+}
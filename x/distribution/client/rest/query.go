@@ -0,0 +1,94 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution"
+
+	"github.com/gorilla/mux"
+)
+
+const queryRoute = "distr"
+
+func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec) {
+
+	// Get the current distribution parameter values
+	r.HandleFunc(
+		"/distribution/parameters",
+		queryHandlerFn(cliCtx, cdc, distribution.QueryParams, nil),
+	).Methods("GET")
+
+	// Get the current state of the global fee pool
+	r.HandleFunc(
+		"/distribution/fee_pool",
+		queryHandlerFn(cliCtx, cdc, distribution.QueryFeePool, nil),
+	).Methods("GET")
+
+	// Get the distribution info of a validator
+	r.HandleFunc(
+		"/distribution/validators/{validatorAddr}",
+		queryHandlerFn(cliCtx, cdc, distribution.QueryValidatorDistInfo, func(vars map[string]string) (interface{}, error) {
+			valAddr, err := sdk.ValAddressFromBech32(vars["validatorAddr"])
+			return distribution.QueryValidatorParams{ValidatorAddr: valAddr}, err
+		}),
+	).Methods("GET")
+
+	// Get the distribution info of a delegation
+	r.HandleFunc(
+		"/distribution/delegators/{delegatorAddr}/delegations/{validatorAddr}",
+		queryHandlerFn(cliCtx, cdc, distribution.QueryDelegationDistInfo, func(vars map[string]string) (interface{}, error) {
+			delAddr, err := sdk.AccAddressFromBech32(vars["delegatorAddr"])
+			if err != nil {
+				return nil, err
+			}
+			valAddr, err := sdk.ValAddressFromBech32(vars["validatorAddr"])
+			return distribution.QueryDelegationParams{DelegatorAddr: delAddr, ValidatorAddr: valAddr}, err
+		}),
+	).Methods("GET")
+
+	// Get the address which receives a delegator's rewards
+	r.HandleFunc(
+		"/distribution/delegators/{delegatorAddr}/withdraw_addr",
+		queryHandlerFn(cliCtx, cdc, distribution.QueryWithdrawAddr, func(vars map[string]string) (interface{}, error) {
+			delAddr, err := sdk.AccAddressFromBech32(vars["delegatorAddr"])
+			return distribution.QueryDelegatorParams{DelegatorAddr: delAddr}, err
+		}),
+	).Methods("GET")
+}
+
+// HTTP request handler to query the distribution querier, the optional
+// parseParams function builds the query params from the request path
+func queryHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec, endpoint string,
+	parseParams func(vars map[string]string) (interface{}, error)) http.HandlerFunc {
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		var bz []byte
+		if parseParams != nil {
+			params, err := parseParams(mux.Vars(r))
+			if err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+
+			bz, err = cdc.MarshalJSON(params)
+			if err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+		}
+
+		res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, endpoint), bz)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(res)
+	}
+}
@@ -0,0 +1,220 @@
+package rest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/distribution"
+
+	"github.com/gorilla/mux"
+)
+
+func registerTxRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec, kb keys.Keybase) {
+
+	// Withdraw the rewards of all of a delegator's delegations, or of a
+	// single delegation if a validator address is provided
+	r.HandleFunc(
+		"/distribution/delegators/{delegatorAddr}/rewards",
+		withdrawDelegatorRewardsHandlerFn(cdc, kb, cliCtx),
+	).Methods("POST")
+
+	// Withdraw a validator's commission and self-delegation rewards
+	r.HandleFunc(
+		"/distribution/validators/{validatorAddr}/rewards",
+		withdrawValidatorRewardsHandlerFn(cdc, kb, cliCtx),
+	).Methods("POST")
+
+	// Replace the address which receives a delegator's rewards
+	r.HandleFunc(
+		"/distribution/delegators/{delegatorAddr}/withdraw_addr",
+		setWithdrawAddrHandlerFn(cdc, kb, cliCtx),
+	).Methods("POST")
+}
+
+// common distribution TX body
+type baseBody struct {
+	LocalAccountName string `json:"name"`
+	Password         string `json:"password"`
+	ChainID          string `json:"chain_id"`
+	AccountNumber    int64  `json:"account_number"`
+	Sequence         int64  `json:"sequence"`
+	Gas              int64  `json:"gas"`
+	GasAdjustment    string `json:"gas_adjustment"`
+}
+
+// Withdraw delegator rewards TX body
+type WithdrawDelegatorRewardsBody struct {
+	baseBody
+	ValidatorAddr string `json:"validator_addr"` // optional, withdraws from all delegations if empty
+}
+
+// Withdraw validator rewards TX body
+type WithdrawValidatorRewardsBody struct {
+	baseBody
+}
+
+// Set withdraw address TX body
+type SetWithdrawAddrBody struct {
+	baseBody
+	WithdrawAddr string `json:"withdraw_addr"`
+}
+
+func withdrawDelegatorRewardsHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var m WithdrawDelegatorRewardsBody
+		if !readBody(w, r, &m) {
+			return
+		}
+
+		delAddr, err := sdk.AccAddressFromBech32(mux.Vars(r)["delegatorAddr"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		var msg sdk.Msg = distribution.NewMsgWithdrawDelegatorRewardsAll(delAddr)
+		if m.ValidatorAddr != "" {
+			valAddr, err := sdk.ValAddressFromBech32(m.ValidatorAddr)
+			if err != nil {
+				utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			msg = distribution.NewMsgWithdrawDelegatorReward(delAddr, valAddr)
+		}
+
+		signAndBroadcast(w, r, cdc, kb, cliCtx, m.baseBody, delAddr, msg)
+	}
+}
+
+func withdrawValidatorRewardsHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var m WithdrawValidatorRewardsBody
+		if !readBody(w, r, &m) {
+			return
+		}
+
+		valAddr, err := sdk.ValAddressFromBech32(mux.Vars(r)["validatorAddr"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := distribution.NewMsgWithdrawValidatorRewardsAll(valAddr)
+		signAndBroadcast(w, r, cdc, kb, cliCtx, m.baseBody, sdk.AccAddress(valAddr.Bytes()), msg)
+	}
+}
+
+func setWithdrawAddrHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var m SetWithdrawAddrBody
+		if !readBody(w, r, &m) {
+			return
+		}
+
+		delAddr, err := sdk.AccAddressFromBech32(mux.Vars(r)["delegatorAddr"])
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		withdrawAddr, err := sdk.AccAddressFromBech32(m.WithdrawAddr)
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		msg := distribution.NewMsgSetWithdrawAddress(delAddr, withdrawAddr)
+		signAndBroadcast(w, r, cdc, kb, cliCtx, m.baseBody, delAddr, msg)
+	}
+}
+
+// read and decode the JSON request body, writing an error response on failure
+func readBody(w http.ResponseWriter, r *http.Request, m interface{}) bool {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	err = json.Unmarshal(body, m)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return false
+	}
+	return true
+}
+
+// build, sign and broadcast a transaction containing the provided msg, which
+// must be signed by the given signer address
+func signAndBroadcast(w http.ResponseWriter, r *http.Request, cdc *codec.Codec, kb keys.Keybase,
+	cliCtx context.CLIContext, m baseBody, signer sdk.AccAddress, msg sdk.Msg) {
+
+	info, err := kb.Get(m.LocalAccountName)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	if !sdk.AccAddress(info.GetPubKey().Address()).Equals(signer) {
+		utils.WriteErrorResponse(w, http.StatusUnauthorized, "Must use own address")
+		return
+	}
+
+	adjustment, ok := utils.ParseFloat64OrReturnBadRequest(w, m.GasAdjustment, client.DefaultGasAdjustment)
+	if !ok {
+		return
+	}
+	txBldr := authtxb.TxBuilder{
+		Codec:         cdc,
+		ChainID:       m.ChainID,
+		AccountNumber: m.AccountNumber,
+		Sequence:      m.Sequence,
+		Gas:           m.Gas,
+		GasAdjustment: adjustment,
+	}
+
+	if utils.HasDryRunArg(r) || m.Gas == 0 {
+		newCtx, err := utils.EnrichCtxWithGas(txBldr, cliCtx, m.LocalAccountName, []sdk.Msg{msg})
+		if err != nil {
+			utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		if utils.HasDryRunArg(r) {
+			utils.WriteSimulationResponse(w, txBldr.Gas)
+			return
+		}
+		txBldr = newCtx
+	}
+
+	if utils.HasGenerateOnlyArg(r) {
+		utils.WriteGenerateStdTxResponse(w, txBldr, []sdk.Msg{msg})
+		return
+	}
+
+	txBytes, err := txBldr.BuildAndSign(m.LocalAccountName, m.Password, []sdk.Msg{msg})
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusUnauthorized, err.Error())
+		return
+	}
+
+	res, err := cliCtx.BroadcastTx(txBytes)
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	output, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		utils.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Write(output)
+}
@@ -0,0 +1,98 @@
+// nolint
+package distribution
+
+import (
+	"github.com/cosmos/cosmos-sdk/x/distribution/keeper"
+	"github.com/cosmos/cosmos-sdk/x/distribution/tags"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+type (
+	Keeper = keeper.Keeper
+	Hooks  = keeper.Hooks
+
+	DecCoin  = types.DecCoin
+	DecCoins = types.DecCoins
+
+	DelegationDistInfo    = types.DelegationDistInfo
+	ValidatorDistInfo     = types.ValidatorDistInfo
+	DelegatorWithdrawInfo = types.DelegatorWithdrawInfo
+	TotalAccum            = types.TotalAccum
+	FeePool               = types.FeePool
+
+	MsgSetWithdrawAddress          = types.MsgSetWithdrawAddress
+	MsgWithdrawDelegatorRewardsAll = types.MsgWithdrawDelegatorRewardsAll
+	MsgWithdrawDelegatorReward     = types.MsgWithdrawDelegatorReward
+	MsgWithdrawValidatorRewardsAll = types.MsgWithdrawValidatorRewardsAll
+
+	GenesisState = types.GenesisState
+
+	QueryParamsResult     = keeper.QueryParamsResult
+	QueryValidatorParams  = keeper.QueryValidatorParams
+	QueryDelegationParams = keeper.QueryDelegationParams
+	QueryDelegatorParams  = keeper.QueryDelegatorParams
+)
+
+var (
+	NewKeeper      = keeper.NewKeeper
+	NewQuerier     = keeper.NewQuerier
+	RegisterParams = keeper.RegisterParams
+
+	GetValidatorDistInfoKey     = keeper.GetValidatorDistInfoKey
+	GetDelegationDistInfoKey    = keeper.GetDelegationDistInfoKey
+	GetDelegationDistInfosKey   = keeper.GetDelegationDistInfosKey
+	GetDelegatorWithdrawAddrKey = keeper.GetDelegatorWithdrawAddrKey
+	FeePoolKey                  = keeper.FeePoolKey
+	ValidatorDistInfoKey        = keeper.ValidatorDistInfoKey
+	DelegationDistInfoKey       = keeper.DelegationDistInfoKey
+	DelegatorWithdrawInfoKey    = keeper.DelegatorWithdrawInfoKey
+	ProposerKey                 = keeper.ProposerKey
+
+	InitialFeePool       = types.InitialFeePool
+	NewGenesisState      = types.NewGenesisState
+	DefaultGenesisState  = types.DefaultGenesisState
+	NewDecCoins          = types.NewDecCoins
+	NewDecCoin           = types.NewDecCoin
+	NewDecCoinFromCoin   = types.NewDecCoinFromCoin
+	NewTotalAccum        = types.NewTotalAccum
+	NewValidatorDistInfo = types.NewValidatorDistInfo
+
+	NewMsgSetWithdrawAddress          = types.NewMsgSetWithdrawAddress
+	NewMsgWithdrawDelegatorRewardsAll = types.NewMsgWithdrawDelegatorRewardsAll
+	NewMsgWithdrawDelegatorReward     = types.NewMsgWithdrawDelegatorReward
+	NewMsgWithdrawValidatorRewardsAll = types.NewMsgWithdrawValidatorRewardsAll
+
+	RegisterCodec = types.RegisterCodec
+	MsgCdc        = types.MsgCdc
+)
+
+const (
+	DefaultCodespace = types.DefaultCodespace
+	CodeInvalidInput = types.CodeInvalidInput
+	MsgType          = types.MsgType
+
+	QueryParams             = keeper.QueryParams
+	QueryFeePool            = keeper.QueryFeePool
+	QueryValidatorDistInfo  = keeper.QueryValidatorDistInfo
+	QueryDelegationDistInfo = keeper.QueryDelegationDistInfo
+	QueryWithdrawAddr       = keeper.QueryWithdrawAddr
+)
+
+var (
+	ErrNilDelegatorAddr     = types.ErrNilDelegatorAddr
+	ErrNilWithdrawAddr      = types.ErrNilWithdrawAddr
+	ErrNilValidatorAddr     = types.ErrNilValidatorAddr
+	ErrNoDelegationDistInfo = types.ErrNoDelegationDistInfo
+	ErrNoValidatorDistInfo  = types.ErrNoValidatorDistInfo
+)
+
+var (
+	ActionModifyWithdrawAddress       = tags.ActionModifyWithdrawAddress
+	ActionWithdrawDelegatorRewardsAll = tags.ActionWithdrawDelegatorRewardsAll
+	ActionWithdrawDelegatorReward     = tags.ActionWithdrawDelegatorReward
+	ActionWithdrawValidatorRewardsAll = tags.ActionWithdrawValidatorRewardsAll
+
+	TagAction    = tags.Action
+	TagValidator = tags.Validator
+	TagDelegator = tags.Delegator
+)
@@ -0,0 +1,53 @@
+package distribution
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/keeper"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// InitGenesis sets distribution information for genesis
+func InitGenesis(ctx sdk.Context, keeper keeper.Keeper, data types.GenesisState) {
+	keeper.SetFeePool(ctx, data.FeePool)
+	keeper.SetCommunityTax(ctx, data.CommunityTax)
+	keeper.SetBaseProposerReward(ctx, data.BaseProposerReward)
+	keeper.SetBonusProposerReward(ctx, data.BonusProposerReward)
+
+	for _, vdi := range data.ValidatorDistInfos {
+		keeper.SetValidatorDistInfo(ctx, vdi)
+	}
+	for _, ddi := range data.DelegationDistInfos {
+		keeper.SetDelegationDistInfo(ctx, ddi)
+	}
+	for _, dw := range data.DelegatorWithdrawInfos {
+		keeper.SetDelegatorWithdrawAddr(ctx, dw.DelegatorAddr, dw.WithdrawAddr)
+	}
+}
+
+// WriteGenesis returns a GenesisState for a given context and keeper. The
+// GenesisState will contain the pool, and validator/delegator distribution info's
+func WriteGenesis(ctx sdk.Context, keeper keeper.Keeper) types.GenesisState {
+	feePool := keeper.GetFeePool(ctx)
+	communityTax := keeper.GetCommunityTax(ctx)
+	baseProposerRewards := keeper.GetBaseProposerReward(ctx)
+	bonusProposerRewards := keeper.GetBonusProposerReward(ctx)
+
+	var vdis []types.ValidatorDistInfo
+	keeper.IterateValidatorDistInfos(ctx, func(_ int64, vdi types.ValidatorDistInfo) (stop bool) {
+		vdis = append(vdis, vdi)
+		return false
+	})
+	var ddis []types.DelegationDistInfo
+	keeper.IterateDelegationDistInfos(ctx, func(_ int64, ddi types.DelegationDistInfo) (stop bool) {
+		ddis = append(ddis, ddi)
+		return false
+	})
+	var dwis []types.DelegatorWithdrawInfo
+	keeper.IterateDelegatorWithdrawAddrs(ctx, func(_ int64, dwi types.DelegatorWithdrawInfo) (stop bool) {
+		dwis = append(dwis, dwi)
+		return false
+	})
+
+	return types.NewGenesisState(feePool, communityTax, baseProposerRewards,
+		bonusProposerRewards, vdis, ddis, dwis)
+}
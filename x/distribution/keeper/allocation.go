@@ -0,0 +1,54 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// Allocate fees handles distribution of the collected fees
+func (k Keeper) AllocateFees(ctx sdk.Context, percentVotes sdk.Dec, proposer sdk.ConsAddress) {
+
+	// get the fees which have been getting collected through all the
+	// transactions in the block
+	feesCollected := k.feeCollectionKeeper.GetCollectedFees(ctx)
+	feesCollectedDec := types.NewDecCoins(feesCollected)
+	feePool := k.GetFeePool(ctx)
+
+	// without any bonded power there is no one to reward, the fees are
+	// funded to the community pool instead
+	proposerValidator := k.stakeKeeper.ValidatorByConsAddr(ctx, proposer)
+	if k.stakeKeeper.TotalPower(ctx).IsZero() || proposerValidator == nil {
+		feePool.CommunityPool = feePool.CommunityPool.Plus(feesCollectedDec)
+		k.SetFeePool(ctx, feePool)
+		k.feeCollectionKeeper.ClearCollectedFees(ctx)
+		return
+	}
+
+	// allocated rewards to proposer
+	baseProposerReward := k.GetBaseProposerReward(ctx)
+	bonusProposerReward := k.GetBonusProposerReward(ctx)
+	proposerMultiplier := baseProposerReward.Add(bonusProposerReward.Mul(percentVotes))
+	proposerReward := feesCollectedDec.MulDec(proposerMultiplier)
+
+	// apply commission
+	commission := proposerReward.MulDec(proposerValidator.GetCommission())
+	remaining := proposerReward.Minus(commission)
+	proposerDist := k.GetValidatorDistInfo(ctx, proposerValidator.GetOperator())
+	proposerDist.ValCommission = proposerDist.ValCommission.Plus(commission)
+	proposerDist.DelPool = proposerDist.DelPool.Plus(remaining)
+	k.SetValidatorDistInfo(ctx, proposerDist)
+
+	// allocate community funding
+	communityTax := k.GetCommunityTax(ctx)
+	communityFunding := feesCollectedDec.MulDec(communityTax)
+	feePool.CommunityPool = feePool.CommunityPool.Plus(communityFunding)
+
+	// set the global pool within the distribution module
+	poolReceived := feesCollectedDec.Minus(proposerReward).Minus(communityFunding)
+	feePool.ValPool = feePool.ValPool.Plus(poolReceived)
+
+	k.SetFeePool(ctx, feePool)
+
+	// clear the now distributed fees
+	k.feeCollectionKeeper.ClearCollectedFees(ctx)
+}
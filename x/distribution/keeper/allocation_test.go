@@ -0,0 +1,117 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+var (
+	denom = "steak"
+
+	commissionMsgZero = stake.NewCommissionMsg(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec())
+)
+
+func TestAllocateFeesBasic(t *testing.T) {
+
+	// no community tax on inputs
+	ctx, _, keeper, sk, fck := CreateTestInputAdvanced(t, false, 100, sdk.ZeroDec())
+	stakeHandler := stake.NewHandler(sk)
+
+	//first make a validator
+	msgCreateValidator := stake.NewMsgCreateValidator(valOpAddr1, valConsPk1,
+		sdk.NewCoin(denom, sdk.NewInt(10)), stake.Description{}, commissionMsgZero)
+	got := stakeHandler(ctx, msgCreateValidator)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+
+	// verify everything has been set in staking correctly
+	validator, found := sk.GetValidator(ctx, valOpAddr1)
+	require.True(t, found)
+	require.Equal(t, sdk.Bonded, validator.Status)
+	require.True(sdk.DecEq(t, sdk.NewDec(10), validator.Tokens))
+	require.True(sdk.DecEq(t, sdk.NewDec(10), validator.DelegatorShares))
+	bondedTokens := sk.TotalPower(ctx)
+	require.True(sdk.DecEq(t, sdk.NewDec(10), bondedTokens))
+
+	// initial fee pool should be empty
+	feePool := keeper.GetFeePool(ctx)
+	require.True(t, feePool.ValPool.IsZero())
+
+	// allocate 100 denom of fees
+	feeInputs := sdk.NewInt(100)
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, feeInputs)})
+	require.Equal(t, feeInputs, fck.GetCollectedFees(ctx).AmountOf(denom))
+	keeper.AllocateFees(ctx, sdk.OneDec(), valConsAddr1)
+
+	// verify that these fees have been received by the feePool
+	percentProposer := sdk.NewDecWithPrec(5, 2)
+	percentRemaining := sdk.OneDec().Sub(percentProposer)
+	feePool = keeper.GetFeePool(ctx)
+	expRes := sdk.NewDecFromInt(feeInputs).Mul(percentRemaining)
+	require.Equal(t, 1, len(feePool.ValPool))
+	require.True(sdk.DecEq(t, expRes, feePool.ValPool[0].Amount))
+
+	// the proposer should have received its bonus in the delegation pool
+	valInfo := keeper.GetValidatorDistInfo(ctx, valOpAddr1)
+	expProposer := sdk.NewDecFromInt(feeInputs).Mul(percentProposer)
+	require.True(sdk.DecEq(t, expProposer, valInfo.DelPool.AmountOf(denom)))
+	require.True(t, valInfo.ValCommission.IsZero())
+
+	// the collected fees should now be cleared
+	require.True(t, fck.GetCollectedFees(ctx).IsZero())
+}
+
+func TestAllocateFeesWithCommunityTax(t *testing.T) {
+	ctx, _, keeper, sk, fck := CreateTestInputAdvanced(t, false, 100, sdk.NewDecWithPrec(1, 2)) //1%
+	stakeHandler := stake.NewHandler(sk)
+
+	//first make a validator
+	msgCreateValidator := stake.NewMsgCreateValidator(valOpAddr1, valConsPk1,
+		sdk.NewCoin(denom, sdk.NewInt(10)), stake.Description{}, commissionMsgZero)
+	got := stakeHandler(ctx, msgCreateValidator)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+
+	// allocate 100 denom of fees
+	feeInputs := sdk.NewInt(100)
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, feeInputs)})
+	keeper.AllocateFees(ctx, sdk.OneDec(), valConsAddr1)
+
+	// verify that these fees have been received by the feePool
+	feePool := keeper.GetFeePool(ctx)
+	// 5% goes to proposer, 1% community tax
+	percentProposer := sdk.NewDecWithPrec(5, 2)
+	percentRemaining := sdk.OneDec().Sub(sdk.NewDecWithPrec(1, 2).Add(percentProposer))
+	expRes := sdk.NewDecFromInt(feeInputs).Mul(percentRemaining)
+	require.Equal(t, 1, len(feePool.ValPool))
+	require.True(sdk.DecEq(t, expRes, feePool.ValPool[0].Amount))
+	require.True(sdk.DecEq(t, sdk.NewDec(1), feePool.CommunityPool.AmountOf(denom)))
+}
+
+func TestAllocateFeesWithPartialPrecommitPower(t *testing.T) {
+	ctx, _, keeper, sk, fck := CreateTestInputAdvanced(t, false, 100, sdk.NewDecWithPrec(1, 2)) //1%
+	stakeHandler := stake.NewHandler(sk)
+
+	//first make a validator
+	msgCreateValidator := stake.NewMsgCreateValidator(valOpAddr1, valConsPk1,
+		sdk.NewCoin(denom, sdk.NewInt(10)), stake.Description{}, commissionMsgZero)
+	got := stakeHandler(ctx, msgCreateValidator)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+
+	// allocate 100 denom of fees
+	feeInputs := sdk.NewInt(100)
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, feeInputs)})
+	percentPrecommitVotes := sdk.NewDecWithPrec(25, 2)
+	keeper.AllocateFees(ctx, percentPrecommitVotes, valConsAddr1)
+
+	// verify that these fees have been received by the feePool
+	feePool := keeper.GetFeePool(ctx)
+	// 1% + 4%*0.25 to proposer + 1% community tax = 97%
+	percentProposer := sdk.NewDecWithPrec(2, 2)
+	percentRemaining := sdk.OneDec().Sub(sdk.NewDecWithPrec(1, 2).Add(percentProposer))
+	expRes := sdk.NewDecFromInt(feeInputs).Mul(percentRemaining)
+	require.Equal(t, 1, len(feePool.ValPool))
+	require.True(sdk.DecEq(t, expRes, feePool.ValPool[0].Amount))
+}
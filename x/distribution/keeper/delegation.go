@@ -0,0 +1,186 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// check whether a delegation distribution info exists
+func (k Keeper) HasDelegationDistInfo(ctx sdk.Context, delAddr sdk.AccAddress,
+	valOperatorAddr sdk.ValAddress) (has bool) {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(GetDelegationDistInfoKey(delAddr, valOperatorAddr))
+}
+
+// get the delegator distribution info
+func (k Keeper) GetDelegationDistInfo(ctx sdk.Context, delAddr sdk.AccAddress,
+	valOperatorAddr sdk.ValAddress) (ddi types.DelegationDistInfo) {
+
+	store := ctx.KVStore(k.storeKey)
+
+	b := store.Get(GetDelegationDistInfoKey(delAddr, valOperatorAddr))
+	if b == nil {
+		panic("Stored delegation-distribution info should not have been nil")
+	}
+
+	k.cdc.MustUnmarshalBinary(b, &ddi)
+	return
+}
+
+// set the delegator distribution info
+func (k Keeper) SetDelegationDistInfo(ctx sdk.Context, ddi types.DelegationDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(ddi)
+	store.Set(GetDelegationDistInfoKey(ddi.DelegatorAddr, ddi.ValOperatorAddr), b)
+}
+
+// remove a delegator distribution info
+func (k Keeper) RemoveDelegationDistInfo(ctx sdk.Context, delAddr sdk.AccAddress,
+	valOperatorAddr sdk.ValAddress) {
+
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetDelegationDistInfoKey(delAddr, valOperatorAddr))
+}
+
+// iterate over all the delegation distribution infos
+func (k Keeper) IterateDelegationDistInfos(ctx sdk.Context,
+	fn func(index int64, distInfo types.DelegationDistInfo) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, DelegationDistInfoKey)
+	defer iter.Close()
+	index := int64(0)
+	for ; iter.Valid(); iter.Next() {
+		var ddi types.DelegationDistInfo
+		k.cdc.MustUnmarshalBinary(iter.Value(), &ddi)
+		if fn(index, ddi) {
+			return
+		}
+		index++
+	}
+}
+
+//___________________________________________________________________________________________
+
+// get the address which should receive a delegator's rewards
+func (k Keeper) GetDelegatorWithdrawAddr(ctx sdk.Context, delAddr sdk.AccAddress) sdk.AccAddress {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetDelegatorWithdrawAddrKey(delAddr))
+	if b == nil {
+		return delAddr
+	}
+	return sdk.AccAddress(b)
+}
+
+// set the address which should receive a delegator's rewards
+func (k Keeper) SetDelegatorWithdrawAddr(ctx sdk.Context, delAddr, withdrawAddr sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetDelegatorWithdrawAddrKey(delAddr), withdrawAddr.Bytes())
+}
+
+// remove a delegator's withdraw address, restoring the default
+func (k Keeper) RemoveDelegatorWithdrawAddr(ctx sdk.Context, delAddr sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetDelegatorWithdrawAddrKey(delAddr))
+}
+
+// iterate over all the delegator withdraw addresses which have been set
+func (k Keeper) IterateDelegatorWithdrawAddrs(ctx sdk.Context,
+	fn func(index int64, dwi types.DelegatorWithdrawInfo) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, DelegatorWithdrawInfoKey)
+	defer iter.Close()
+	index := int64(0)
+	for ; iter.Valid(); iter.Next() {
+		dwi := types.DelegatorWithdrawInfo{
+			DelegatorAddr: sdk.AccAddress(iter.Key()[1:]),
+			WithdrawAddr:  sdk.AccAddress(iter.Value()),
+		}
+		if fn(index, dwi) {
+			return
+		}
+		index++
+	}
+}
+
+//___________________________________________________________________________________________
+
+// withdraw all the rewards for a single delegation
+func (k Keeper) WithdrawDelegationReward(ctx sdk.Context, delegatorAddr sdk.AccAddress,
+	validatorAddr sdk.ValAddress) sdk.Error {
+
+	if !k.HasDelegationDistInfo(ctx, delegatorAddr, validatorAddr) {
+		return types.ErrNoDelegationDistInfo(k.codespace)
+	}
+
+	fp, withdraw := k.withdrawDelegationReward(ctx, k.GetFeePool(ctx), delegatorAddr, validatorAddr)
+	k.payout(ctx, fp, delegatorAddr, withdraw)
+	return nil
+}
+
+// withdraw all the rewards for all of a delegator's delegations
+func (k Keeper) WithdrawDelegationRewardsAll(ctx sdk.Context, delegatorAddr sdk.AccAddress) {
+	withdraw := k.getDelegatorRewardsAll(ctx, delegatorAddr)
+	k.payout(ctx, k.GetFeePool(ctx), delegatorAddr, withdraw)
+}
+
+// return all rewards for all of a delegator's delegations, persisting the
+// updated distribution infos but leaving the payout to the caller
+func (k Keeper) getDelegatorRewardsAll(ctx sdk.Context, delAddr sdk.AccAddress) types.DecCoins {
+	withdraw := types.DecCoins{}
+	operationAtDelegation := func(_ int64, del sdk.Delegation) (stop bool) {
+		if !k.HasDelegationDistInfo(ctx, delAddr, del.GetValidator()) {
+			return false
+		}
+		fp, diWithdraw := k.withdrawDelegationReward(ctx, k.GetFeePool(ctx), delAddr, del.GetValidator())
+		k.SetFeePool(ctx, fp)
+		withdraw = withdraw.Plus(diWithdraw)
+		return false
+	}
+	k.stakeKeeper.IterateDelegations(ctx, delAddr, operationAtDelegation)
+	return withdraw
+}
+
+// withdraw the rewards of a single delegation, persisting the delegation and
+// validator distribution infos, and return the updated fee pool along with the
+// withdrawn rewards
+func (k Keeper) withdrawDelegationReward(ctx sdk.Context, fp types.FeePool, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress) (types.FeePool, types.DecCoins) {
+
+	height := ctx.BlockHeight()
+	delegation := k.stakeKeeper.Delegation(ctx, delAddr, valAddr)
+	validator := k.stakeKeeper.Validator(ctx, valAddr)
+	if delegation == nil || validator == nil {
+		return fp, types.DecCoins{}
+	}
+
+	totalBonded := k.stakeKeeper.TotalPower(ctx)
+	vdi := k.GetValidatorDistInfo(ctx, valAddr)
+	ddi := k.GetDelegationDistInfo(ctx, delAddr, valAddr)
+
+	ddi, vdi, fp, withdraw := ddi.WithdrawRewards(fp, vdi, height, totalBonded,
+		validator.GetPower(), validator.GetDelegatorShares(), delegation.GetShares(),
+		validator.GetCommission())
+
+	k.SetValidatorDistInfo(ctx, vdi)
+	k.SetDelegationDistInfo(ctx, ddi)
+	return fp, withdraw
+}
+
+// send the truncated rewards to the delegator's withdraw address, the
+// remaining decimal change is added to the community pool
+func (k Keeper) payout(ctx sdk.Context, fp types.FeePool, delAddr sdk.AccAddress, withdraw types.DecCoins) {
+	truncated, change := withdraw.TruncateDecimal()
+	fp.CommunityPool = fp.CommunityPool.Plus(change)
+	k.SetFeePool(ctx, fp)
+
+	if truncated.IsZero() {
+		return
+	}
+	withdrawAddr := k.GetDelegatorWithdrawAddr(ctx, delAddr)
+	_, _, err := k.bankKeeper.AddCoins(ctx, withdrawAddr, truncated)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,113 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// Create a new validator distribution record, unless one was already set
+// from genesis
+func (k Keeper) onValidatorCreated(ctx sdk.Context, addr sdk.ValAddress) {
+	if k.HasValidatorDistInfo(ctx, addr) {
+		return
+	}
+	vdi := types.NewValidatorDistInfo(addr, ctx.BlockHeight())
+	k.SetValidatorDistInfo(ctx, vdi)
+}
+
+// Withdraw the validator's rewards from the fee pool before its power,
+// delegator shares or commission change
+func (k Keeper) onValidatorModified(ctx sdk.Context, addr sdk.ValAddress) {
+	if !k.HasValidatorDistInfo(ctx, addr) {
+		return
+	}
+	k.takeValidatorFeePoolRewards(ctx, addr)
+}
+
+// Withdraw the commission to the operator and move any remaining delegator
+// rewards to the community pool before the validator is removed
+func (k Keeper) onValidatorRemoved(ctx sdk.Context, addr sdk.ValAddress) {
+	if !k.HasValidatorDistInfo(ctx, addr) {
+		return
+	}
+	k.takeValidatorFeePoolRewards(ctx, addr)
+
+	vdi := k.GetValidatorDistInfo(ctx, addr)
+	fp := k.GetFeePool(ctx)
+	fp.CommunityPool = fp.CommunityPool.Plus(vdi.DelPool)
+	k.payout(ctx, fp, sdk.AccAddress(addr.Bytes()), vdi.ValCommission)
+	k.RemoveValidatorDistInfo(ctx, addr)
+}
+
+//_________________________________________________________________________________________
+
+// Create a new delegation distribution record, unless one was already set
+// from genesis
+func (k Keeper) onDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress) {
+
+	if k.HasDelegationDistInfo(ctx, delAddr, valAddr) {
+		return
+	}
+	ddi := types.NewDelegationDistInfo(delAddr, valAddr, ctx.BlockHeight())
+	k.SetDelegationDistInfo(ctx, ddi)
+}
+
+// Withdrawal all validator rewards before the delegation's shares change
+func (k Keeper) onDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress) {
+
+	if !k.HasDelegationDistInfo(ctx, delAddr, valAddr) {
+		return
+	}
+	if err := k.WithdrawDelegationReward(ctx, delAddr, valAddr); err != nil {
+		panic(err)
+	}
+}
+
+// Withdrawal all validator rewards and remove the delegation distribution
+// record before the delegation is removed
+func (k Keeper) onDelegationRemoved(ctx sdk.Context, delAddr sdk.AccAddress,
+	valAddr sdk.ValAddress) {
+
+	k.onDelegationSharesModified(ctx, delAddr, valAddr)
+	k.RemoveDelegationDistInfo(ctx, delAddr, valAddr)
+}
+
+//_________________________________________________________________________________________
+
+// Wrapper struct
+type Hooks struct {
+	k Keeper
+}
+
+var _ sdk.StakingHooks = Hooks{}
+
+// Return the wrapper struct
+func (k Keeper) Hooks() Hooks {
+	return Hooks{k}
+}
+
+// nolint
+func (h Hooks) OnValidatorCreated(ctx sdk.Context, addr sdk.ValAddress) {
+	h.k.onValidatorCreated(ctx, addr)
+}
+func (h Hooks) OnValidatorModified(ctx sdk.Context, addr sdk.ValAddress) {
+	h.k.onValidatorModified(ctx, addr)
+}
+func (h Hooks) OnValidatorRemoved(ctx sdk.Context, addr sdk.ValAddress) {
+	h.k.onValidatorRemoved(ctx, addr)
+}
+func (h Hooks) OnDelegationCreated(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	h.k.onDelegationCreated(ctx, delAddr, valAddr)
+}
+func (h Hooks) OnDelegationSharesModified(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	h.k.onDelegationSharesModified(ctx, delAddr, valAddr)
+}
+func (h Hooks) OnDelegationRemoved(ctx sdk.Context, delAddr sdk.AccAddress, valAddr sdk.ValAddress) {
+	h.k.onDelegationRemoved(ctx, delAddr, valAddr)
+}
+
+// nolint - unused hooks
+func (h Hooks) OnValidatorBonded(_ sdk.Context, _ sdk.ConsAddress)         {}
+func (h Hooks) OnValidatorBeginUnbonding(_ sdk.Context, _ sdk.ConsAddress) {}
@@ -0,0 +1,136 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// keeper of the distribution store
+type Keeper struct {
+	storeKey            sdk.StoreKey
+	cdc                 *codec.Codec
+	ps                  params.Setter
+	bankKeeper          types.BankKeeper
+	stakeKeeper         types.StakeKeeper
+	feeCollectionKeeper types.FeeCollectionKeeper
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, ps params.Setter, ck types.BankKeeper,
+	sk types.StakeKeeper, fck types.FeeCollectionKeeper, codespace sdk.CodespaceType) Keeper {
+
+	keeper := Keeper{
+		storeKey:            key,
+		cdc:                 cdc,
+		ps:                  ps,
+		bankKeeper:          ck,
+		stakeKeeper:         sk,
+		feeCollectionKeeper: fck,
+		codespace:           codespace,
+	}
+	return keeper
+}
+
+//______________________________________________________________________
+
+// get the global fee pool distribution info
+func (k Keeper) GetFeePool(ctx sdk.Context) (feePool types.FeePool) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(FeePoolKey)
+	if b == nil {
+		panic("Stored fee pool should not have been nil")
+	}
+	k.cdc.MustUnmarshalBinary(b, &feePool)
+	return
+}
+
+// set the global fee pool distribution info
+func (k Keeper) SetFeePool(ctx sdk.Context, feePool types.FeePool) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(feePool)
+	store.Set(FeePoolKey, b)
+}
+
+// get the total validator accum for the ctx height
+// in the fee pool
+func (k Keeper) GetFeePoolValAccum(ctx sdk.Context) sdk.Dec {
+	height := ctx.BlockHeight()
+	totalPower := k.stakeKeeper.TotalPower(ctx)
+	fp := k.GetFeePool(ctx)
+	return fp.TotalValAccum.UpdateForNewHeight(height, totalPower).Accum
+}
+
+//______________________________________________________________________
+
+// get the consensus address of the proposer of the previous block
+func (k Keeper) GetPreviousProposerConsAddr(ctx sdk.Context) (consAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+
+	b := store.Get(ProposerKey)
+	if b == nil {
+		panic("Previous proposer not set")
+	}
+
+	k.cdc.MustUnmarshalBinary(b, &consAddr)
+	return
+}
+
+// set the consensus address of the proposer of the current block
+func (k Keeper) SetPreviousProposerConsAddr(ctx sdk.Context, consAddr sdk.ConsAddress) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(consAddr)
+	store.Set(ProposerKey, b)
+}
+
+//______________________________________________________________________
+
+// Returns the current CommunityTax rate from the global param store
+func (k Keeper) GetCommunityTax(ctx sdk.Context) sdk.Dec {
+	return k.ps.GetDecWithDefault(ctx, ParamStoreKeyCommunityTax, sdk.ZeroDec())
+}
+
+// Sets the CommunityTax rate in the global param store
+func (k Keeper) SetCommunityTax(ctx sdk.Context, percent sdk.Dec) {
+	k.ps.SetDec(ctx, ParamStoreKeyCommunityTax, percent)
+}
+
+// Returns the current BaseProposerReward rate from the global param store
+func (k Keeper) GetBaseProposerReward(ctx sdk.Context) sdk.Dec {
+	return k.ps.GetDecWithDefault(ctx, ParamStoreKeyBaseProposerReward, sdk.ZeroDec())
+}
+
+// Sets the BaseProposerReward rate in the global param store
+func (k Keeper) SetBaseProposerReward(ctx sdk.Context, percent sdk.Dec) {
+	k.ps.SetDec(ctx, ParamStoreKeyBaseProposerReward, percent)
+}
+
+// Returns the current BonusProposerReward rate from the global param store
+func (k Keeper) GetBonusProposerReward(ctx sdk.Context) sdk.Dec {
+	return k.ps.GetDecWithDefault(ctx, ParamStoreKeyBonusProposerReward, sdk.ZeroDec())
+}
+
+// Sets the BonusProposerReward rate in the global param store
+func (k Keeper) SetBonusProposerReward(ctx sdk.Context, percent sdk.Dec) {
+	k.ps.SetDec(ctx, ParamStoreKeyBonusProposerReward, percent)
+}
+
+// registers the distribution rates as governable params
+func RegisterParams(registry params.Registry) {
+	registry.Register(ParamStoreKeyCommunityTax, sdk.Dec{}, validateRate)
+	registry.Register(ParamStoreKeyBaseProposerReward, sdk.Dec{}, validateRate)
+	registry.Register(ParamStoreKeyBonusProposerReward, sdk.Dec{}, validateRate)
+}
+
+func validateRate(value interface{}) error {
+	rate := value.(sdk.Dec)
+	if rate.LT(sdk.ZeroDec()) || rate.GT(sdk.OneDec()) {
+		return fmt.Errorf("rate must be within [0, 1], is %v", rate)
+	}
+	return nil
+}
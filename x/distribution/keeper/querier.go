@@ -0,0 +1,146 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// query endpoints supported by the distribution Querier
+const (
+	QueryParams             = "params"
+	QueryFeePool            = "fee_pool"
+	QueryValidatorDistInfo  = "validator_dist_info"
+	QueryDelegationDistInfo = "delegation_dist_info"
+	QueryWithdrawAddr       = "withdraw_addr"
+)
+
+// distribution parameters as returned by the params query
+type QueryParamsResult struct {
+	CommunityTax        sdk.Dec `json:"community_tax"`
+	BaseProposerReward  sdk.Dec `json:"base_proposer_reward"`
+	BonusProposerReward sdk.Dec `json:"bonus_proposer_reward"`
+}
+
+// defines the params for the following queries:
+// - 'custom/distr/validator_dist_info'
+type QueryValidatorParams struct {
+	ValidatorAddr sdk.ValAddress
+}
+
+// defines the params for the following queries:
+// - 'custom/distr/delegation_dist_info'
+type QueryDelegationParams struct {
+	DelegatorAddr sdk.AccAddress
+	ValidatorAddr sdk.ValAddress
+}
+
+// defines the params for the following queries:
+// - 'custom/distr/withdraw_addr'
+type QueryDelegatorParams struct {
+	DelegatorAddr sdk.AccAddress
+}
+
+// creates a querier for distribution REST endpoints
+func NewQuerier(k Keeper, cdc *codec.Codec) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryParams:
+			return queryParams(ctx, cdc, k)
+		case QueryFeePool:
+			return queryFeePool(ctx, cdc, k)
+		case QueryValidatorDistInfo:
+			return queryValidatorDistInfo(ctx, cdc, req, k)
+		case QueryDelegationDistInfo:
+			return queryDelegationDistInfo(ctx, cdc, req, k)
+		case QueryWithdrawAddr:
+			return queryWithdrawAddr(ctx, cdc, req, k)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown distr query endpoint")
+		}
+	}
+}
+
+func queryParams(ctx sdk.Context, cdc *codec.Codec, k Keeper) (res []byte, err sdk.Error) {
+	params := QueryParamsResult{
+		CommunityTax:        k.GetCommunityTax(ctx),
+		BaseProposerReward:  k.GetBaseProposerReward(ctx),
+		BonusProposerReward: k.GetBonusProposerReward(ctx),
+	}
+
+	res, errRes := codec.MarshalJSONIndent(cdc, params)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryFeePool(ctx sdk.Context, cdc *codec.Codec, k Keeper) (res []byte, err sdk.Error) {
+	feePool := k.GetFeePool(ctx)
+
+	res, errRes := codec.MarshalJSONIndent(cdc, feePool)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryValidatorDistInfo(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryValidatorParams
+
+	errRes := cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownAddress(fmt.Sprintf("incorrectly formatted request address: %s", errRes.Error()))
+	}
+
+	if !k.HasValidatorDistInfo(ctx, params.ValidatorAddr) {
+		return []byte{}, types.ErrNoValidatorDistInfo(types.DefaultCodespace)
+	}
+	vdi := k.GetValidatorDistInfo(ctx, params.ValidatorAddr)
+
+	res, errRes = codec.MarshalJSONIndent(cdc, vdi)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryDelegationDistInfo(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryDelegationParams
+
+	errRes := cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("incorrectly formatted request address: %s", errRes.Error()))
+	}
+
+	if !k.HasDelegationDistInfo(ctx, params.DelegatorAddr, params.ValidatorAddr) {
+		return []byte{}, types.ErrNoDelegationDistInfo(types.DefaultCodespace)
+	}
+	ddi := k.GetDelegationDistInfo(ctx, params.DelegatorAddr, params.ValidatorAddr)
+
+	res, errRes = codec.MarshalJSONIndent(cdc, ddi)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", errRes.Error()))
+	}
+	return res, nil
+}
+
+func queryWithdrawAddr(ctx sdk.Context, cdc *codec.Codec, req abci.RequestQuery, k Keeper) (res []byte, err sdk.Error) {
+	var params QueryDelegatorParams
+
+	errRes := cdc.UnmarshalJSON(req.Data, &params)
+	if errRes != nil {
+		return []byte{}, sdk.ErrUnknownAddress(fmt.Sprintf("incorrectly formatted request address: %s", errRes.Error()))
+	}
+
+	withdrawAddr := k.GetDelegatorWithdrawAddr(ctx, params.DelegatorAddr)
+
+	res, errRes = codec.MarshalJSONIndent(cdc, withdrawAddr)
+	if errRes != nil {
+		return nil, sdk.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", errRes.Error()))
+	}
+	return res, nil
+}
@@ -0,0 +1,112 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution/types"
+)
+
+// check whether a validator has distribution info
+func (k Keeper) HasValidatorDistInfo(ctx sdk.Context,
+	operatorAddr sdk.ValAddress) (exists bool) {
+	store := ctx.KVStore(k.storeKey)
+	return store.Has(GetValidatorDistInfoKey(operatorAddr))
+}
+
+// get the validator distribution info
+func (k Keeper) GetValidatorDistInfo(ctx sdk.Context,
+	operatorAddr sdk.ValAddress) (vdi types.ValidatorDistInfo) {
+
+	store := ctx.KVStore(k.storeKey)
+
+	b := store.Get(GetValidatorDistInfoKey(operatorAddr))
+	if b == nil {
+		panic("Stored validator-distribution info should not have been nil")
+	}
+
+	k.cdc.MustUnmarshalBinary(b, &vdi)
+	return
+}
+
+// set the validator distribution info
+func (k Keeper) SetValidatorDistInfo(ctx sdk.Context, vdi types.ValidatorDistInfo) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(vdi)
+	store.Set(GetValidatorDistInfoKey(vdi.OperatorAddr), b)
+}
+
+// remove a validator distribution info
+func (k Keeper) RemoveValidatorDistInfo(ctx sdk.Context, valAddr sdk.ValAddress) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetValidatorDistInfoKey(valAddr))
+}
+
+// iterate over all the validator distribution infos
+func (k Keeper) IterateValidatorDistInfos(ctx sdk.Context,
+	fn func(index int64, distInfo types.ValidatorDistInfo) (stop bool)) {
+
+	store := ctx.KVStore(k.storeKey)
+	iter := sdk.KVStorePrefixIterator(store, ValidatorDistInfoKey)
+	defer iter.Close()
+	index := int64(0)
+	for ; iter.Valid(); iter.Next() {
+		var vdi types.ValidatorDistInfo
+		k.cdc.MustUnmarshalBinary(iter.Value(), &vdi)
+		if fn(index, vdi) {
+			return
+		}
+		index++
+	}
+}
+
+//______________________________________________________________________
+
+// move any rewards owed to the validator from the fee pool into its
+// distribution info, updating the validator's delegator accumulation
+func (k Keeper) takeValidatorFeePoolRewards(ctx sdk.Context, operatorAddr sdk.ValAddress) {
+	height := ctx.BlockHeight()
+	validator := k.stakeKeeper.Validator(ctx, operatorAddr)
+	if validator == nil {
+		return
+	}
+
+	vdi := k.GetValidatorDistInfo(ctx, operatorAddr)
+	fp := k.GetFeePool(ctx)
+	totalBonded := k.stakeKeeper.TotalPower(ctx)
+
+	vdi = vdi.UpdateTotalDelAccum(height, validator.GetDelegatorShares())
+	vdi, fp = vdi.TakeFeePoolRewards(fp, height, totalBonded, validator.GetPower(), validator.GetCommission())
+
+	k.SetValidatorDistInfo(ctx, vdi)
+	k.SetFeePool(ctx, fp)
+}
+
+// withdraw all the rewards for a single validator, both the commission and
+// the rewards owed to the validator operator's own delegations
+func (k Keeper) WithdrawValidatorRewardsAll(ctx sdk.Context, operatorAddr sdk.ValAddress) sdk.Error {
+	if !k.HasValidatorDistInfo(ctx, operatorAddr) {
+		return types.ErrNoValidatorDistInfo(k.codespace)
+	}
+	validator := k.stakeKeeper.Validator(ctx, operatorAddr)
+	if validator == nil {
+		return types.ErrNoValidatorDistInfo(k.codespace)
+	}
+
+	// withdraw self-delegation
+	accAddr := sdk.AccAddress(operatorAddr.Bytes())
+	withdraw := k.getDelegatorRewardsAll(ctx, accAddr)
+
+	// withdraw validator commission rewards
+	height := ctx.BlockHeight()
+	totalBonded := k.stakeKeeper.TotalPower(ctx)
+	vdi := k.GetValidatorDistInfo(ctx, operatorAddr)
+	fp := k.GetFeePool(ctx)
+
+	vdi = vdi.UpdateTotalDelAccum(height, validator.GetDelegatorShares())
+	vdi, fp, commission := vdi.WithdrawCommission(fp, height, totalBonded,
+		validator.GetPower(), validator.GetCommission())
+	withdraw = withdraw.Plus(commission)
+	k.SetValidatorDistInfo(ctx, vdi)
+
+	k.payout(ctx, fp, accAddr, withdraw)
+	return nil
+}
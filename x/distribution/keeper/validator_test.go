@@ -0,0 +1,78 @@
+package keeper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+)
+
+func TestWithdrawValidatorRewardsAllNoDelegator(t *testing.T) {
+	ctx, accMapper, keeper, sk, fck := CreateTestInputAdvanced(t, false, 100, sdk.ZeroDec())
+	stakeHandler := stake.NewHandler(sk)
+
+	//first make a validator
+	msgCreateValidator := stake.NewMsgCreateValidator(valOpAddr1, valConsPk1,
+		sdk.NewCoin(denom, sdk.NewInt(10)), stake.Description{}, commissionMsgZero)
+	got := stakeHandler(ctx, msgCreateValidator)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+
+	// allocate 100 denom of fees
+	feeInputs := sdk.NewInt(100)
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, feeInputs)})
+	require.Equal(t, feeInputs, fck.GetCollectedFees(ctx).AmountOf(denom))
+	keeper.AllocateFees(ctx, sdk.OneDec(), valConsAddr1)
+
+	// withdraw the rewards one block later, the sole validator receives
+	// both the proposer reward and the entire validator pool
+	ctx = ctx.WithBlockHeight(1)
+	err := keeper.WithdrawValidatorRewardsAll(ctx, valOpAddr1)
+	require.Nil(t, err)
+
+	amt := accMapper.GetAccount(ctx, valAccAddr1).GetCoins().AmountOf(denom)
+	expRes := sdk.NewInt(90).Add(feeInputs)
+	require.True(t, expRes.Equal(amt), "expected %v got %v", expRes, amt)
+	require.True(t, keeper.GetFeePool(ctx).ValPool.IsZero())
+}
+
+func TestWithdrawValidatorRewardsAllDelegatorNoCommission(t *testing.T) {
+	ctx, accMapper, keeper, sk, fck := CreateTestInputAdvanced(t, false, 100, sdk.ZeroDec())
+	stakeHandler := stake.NewHandler(sk)
+
+	//first make a validator
+	msgCreateValidator := stake.NewMsgCreateValidator(valOpAddr1, valConsPk1,
+		sdk.NewCoin(denom, sdk.NewInt(10)), stake.Description{}, commissionMsgZero)
+	got := stakeHandler(ctx, msgCreateValidator)
+	require.True(t, got.IsOK(), "expected msg to be ok, got %v", got)
+
+	// delegate
+	msgDelegate := stake.NewMsgDelegate(delAddr1, valOpAddr1, sdk.NewCoin(denom, sdk.NewInt(10)))
+	got = stakeHandler(ctx, msgDelegate)
+	require.True(t, got.IsOK())
+	amt := accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+	require.Equal(t, int64(90), amt.Int64())
+
+	// allocate 100 denom of fees
+	feeInputs := sdk.NewInt(100)
+	fck.SetCollectedFees(sdk.Coins{sdk.NewCoin(denom, feeInputs)})
+	keeper.AllocateFees(ctx, sdk.OneDec(), valConsAddr1)
+
+	// withdraw the validator's rewards, it owns half of the delegator shares
+	ctx = ctx.WithBlockHeight(1)
+	err := keeper.WithdrawValidatorRewardsAll(ctx, valOpAddr1)
+	require.Nil(t, err)
+
+	amt = accMapper.GetAccount(ctx, valAccAddr1).GetCoins().AmountOf(denom)
+	expRes := sdk.NewInt(90).Add(feeInputs.Div(sdk.NewInt(2)))
+	require.True(t, expRes.Equal(amt), "expected %v got %v", expRes, amt)
+
+	// the delegator can then withdraw the other half
+	err = keeper.WithdrawDelegationReward(ctx, delAddr1, valOpAddr1)
+	require.Nil(t, err)
+
+	amt = accMapper.GetAccount(ctx, delAddr1).GetCoins().AmountOf(denom)
+	expRes = sdk.NewInt(90).Add(feeInputs.Div(sdk.NewInt(2)))
+	require.True(t, expRes.Equal(amt), "expected %v got %v", expRes, amt)
+}
@@ -0,0 +1,215 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Coins which can have additional decimal points
+type DecCoin struct {
+	Denom  string  `json:"denom"`
+	Amount sdk.Dec `json:"amount"`
+}
+
+func NewDecCoin(denom string, amount int64) DecCoin {
+	return DecCoin{
+		Denom:  denom,
+		Amount: sdk.NewDec(amount),
+	}
+}
+
+func NewDecCoinFromCoin(coin sdk.Coin) DecCoin {
+	return DecCoin{
+		Denom:  coin.Denom,
+		Amount: sdk.NewDecFromInt(coin.Amount),
+	}
+}
+
+// Adds amounts of two coins with same denom
+func (coin DecCoin) Plus(coinB DecCoin) DecCoin {
+	if coin.Denom != coinB.Denom {
+		panic(fmt.Sprintf("coin denom different: %v %v\n", coin.Denom, coinB.Denom))
+	}
+	return DecCoin{coin.Denom, coin.Amount.Add(coinB.Amount)}
+}
+
+// Subtracts amounts of two coins with same denom
+func (coin DecCoin) Minus(coinB DecCoin) DecCoin {
+	if coin.Denom != coinB.Denom {
+		panic(fmt.Sprintf("coin denom different: %v %v\n", coin.Denom, coinB.Denom))
+	}
+	return DecCoin{coin.Denom, coin.Amount.Sub(coinB.Amount)}
+}
+
+// return the decimal coins with trunctated decimals, and return the change
+func (coin DecCoin) TruncateDecimal() (sdk.Coin, DecCoin) {
+	truncated := coin.Amount.TruncateInt()
+	change := coin.Amount.Sub(sdk.NewDecFromInt(truncated))
+	return sdk.NewCoin(coin.Denom, truncated), DecCoin{coin.Denom, change}
+}
+
+//_______________________________________________________________________
+
+// coins with decimal
+type DecCoins []DecCoin
+
+func NewDecCoins(coins sdk.Coins) DecCoins {
+	dcs := make(DecCoins, len(coins))
+	for i, coin := range coins {
+		dcs[i] = NewDecCoinFromCoin(coin)
+	}
+	return dcs
+}
+
+func (coins DecCoins) String() string {
+	if len(coins) == 0 {
+		return ""
+	}
+	out := make([]string, len(coins))
+	for i, coin := range coins {
+		out[i] = fmt.Sprintf("%v%v", coin.Amount, coin.Denom)
+	}
+	return strings.Join(out, ",")
+}
+
+// return the coins with trunctated decimals, and return the change
+func (coins DecCoins) TruncateDecimal() (sdk.Coins, DecCoins) {
+	changeSum := DecCoins{}
+	out := make(sdk.Coins, 0, len(coins))
+	for _, coin := range coins {
+		truncated, change := coin.TruncateDecimal()
+		if !truncated.IsZero() {
+			out = append(out, truncated)
+		}
+		changeSum = changeSum.Plus(DecCoins{change})
+	}
+	return out, changeSum
+}
+
+// Plus combines two sets of coins
+// CONTRACT: Plus will never return Coins where one Coin has a 0 amount.
+func (coins DecCoins) Plus(coinsB DecCoins) DecCoins {
+	sum := ([]DecCoin)(nil)
+	indexA, indexB := 0, 0
+	lenA, lenB := len(coins), len(coinsB)
+	for {
+		if indexA == lenA {
+			if indexB == lenB {
+				return sum
+			}
+			return append(sum, removeZeroDecCoins(coinsB[indexB:])...)
+		} else if indexB == lenB {
+			return append(sum, removeZeroDecCoins(coins[indexA:])...)
+		}
+		coinA, coinB := coins[indexA], coinsB[indexB]
+		switch strings.Compare(coinA.Denom, coinB.Denom) {
+		case -1:
+			if !coinA.Amount.IsZero() {
+				sum = append(sum, coinA)
+			}
+			indexA++
+		case 0:
+			if coinA.Amount.Add(coinB.Amount).IsZero() {
+				// ignore 0 sum coin type
+			} else {
+				sum = append(sum, coinA.Plus(coinB))
+			}
+			indexA++
+			indexB++
+		case 1:
+			if !coinB.Amount.IsZero() {
+				sum = append(sum, coinB)
+			}
+			indexB++
+		}
+	}
+}
+
+// Negative returns a set of coins with all amount negative
+func (coins DecCoins) Negative() DecCoins {
+	res := make([]DecCoin, 0, len(coins))
+	for _, coin := range coins {
+		res = append(res, DecCoin{
+			Denom:  coin.Denom,
+			Amount: coin.Amount.Neg(),
+		})
+	}
+	return res
+}
+
+// Minus subtracts a set of coins from another (adds the inverse)
+func (coins DecCoins) Minus(coinsB DecCoins) DecCoins {
+	return coins.Plus(coinsB.Negative())
+}
+
+// multiply all the coins by a decimal
+func (coins DecCoins) MulDec(d sdk.Dec) DecCoins {
+	res := make([]DecCoin, 0, len(coins))
+	for _, coin := range coins {
+		product := DecCoin{
+			Denom:  coin.Denom,
+			Amount: coin.Amount.Mul(d),
+		}
+		if !product.Amount.IsZero() {
+			res = append(res, product)
+		}
+	}
+	return res
+}
+
+// divide all the coins by a decimal
+func (coins DecCoins) QuoDec(d sdk.Dec) DecCoins {
+	res := make([]DecCoin, 0, len(coins))
+	for _, coin := range coins {
+		quotient := DecCoin{
+			Denom:  coin.Denom,
+			Amount: coin.Amount.Quo(d),
+		}
+		if !quotient.Amount.IsZero() {
+			res = append(res, quotient)
+		}
+	}
+	return res
+}
+
+// returns the amount of a denom from deccoins
+func (coins DecCoins) AmountOf(denom string) sdk.Dec {
+	for _, coin := range coins {
+		if coin.Denom == denom {
+			return coin.Amount
+		}
+	}
+	return sdk.ZeroDec()
+}
+
+// has a negative DecCoin amount
+func (coins DecCoins) HasNegative() bool {
+	for _, coin := range coins {
+		if coin.Amount.LT(sdk.ZeroDec()) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsZero returns true if there are no coins or all coins are zero.
+func (coins DecCoins) IsZero() bool {
+	for _, coin := range coins {
+		if !coin.Amount.IsZero() {
+			return false
+		}
+	}
+	return true
+}
+
+func removeZeroDecCoins(coins DecCoins) DecCoins {
+	res := make([]DecCoin, 0, len(coins))
+	for _, coin := range coins {
+		if !coin.Amount.IsZero() {
+			res = append(res, coin)
+		}
+	}
+	return res
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestPlusDecCoins(t *testing.T) {
+	one := sdk.NewDec(1)
+	zero := sdk.NewDec(0)
+	negone := sdk.NewDec(-1)
+	two := sdk.NewDec(2)
+
+	cases := []struct {
+		inputOne DecCoins
+		inputTwo DecCoins
+		expected DecCoins
+	}{
+		{DecCoins{{"A", one}, {"B", one}}, DecCoins{{"A", one}, {"B", one}}, DecCoins{{"A", two}, {"B", two}}},
+		{DecCoins{{"A", zero}, {"B", one}}, DecCoins{{"A", zero}, {"B", zero}}, DecCoins{{"B", one}}},
+		{DecCoins{{"A", zero}, {"B", zero}}, DecCoins{{"A", zero}, {"B", zero}}, DecCoins(nil)},
+		{DecCoins{{"A", one}, {"B", zero}}, DecCoins{{"A", negone}, {"B", zero}}, DecCoins(nil)},
+		{DecCoins{{"A", negone}, {"B", zero}}, DecCoins{{"A", zero}, {"B", zero}}, DecCoins{{"A", negone}}},
+	}
+
+	for tcIndex, tc := range cases {
+		res := tc.inputOne.Plus(tc.inputTwo)
+		require.Equal(t, tc.expected, res, "sum of coins is incorrect, tc #%d", tcIndex)
+	}
+}
+
+func TestMinusDecCoins(t *testing.T) {
+	coins := DecCoins{{"A", sdk.NewDec(3)}, {"B", sdk.NewDec(2)}}
+	res := coins.Minus(DecCoins{{"A", sdk.NewDec(1)}, {"B", sdk.NewDec(2)}})
+	require.Equal(t, DecCoins{{"A", sdk.NewDec(2)}}, res)
+	require.False(t, res.HasNegative())
+
+	res = res.Minus(DecCoins{{"A", sdk.NewDec(3)}})
+	require.True(t, res.HasNegative())
+}
+
+func TestMulQuoDecCoins(t *testing.T) {
+	coins := DecCoins{{"A", sdk.NewDec(10)}, {"B", sdk.NewDec(4)}}
+
+	res := coins.MulDec(sdk.NewDecWithPrec(5, 1))
+	require.Equal(t, DecCoins{{"A", sdk.NewDec(5)}, {"B", sdk.NewDec(2)}}, res)
+
+	res = coins.QuoDec(sdk.NewDec(4))
+	require.Equal(t, DecCoins{{"A", sdk.NewDecWithPrec(25, 1)}, {"B", sdk.NewDec(1)}}, res)
+
+	// zero results are removed
+	require.Equal(t, DecCoins{}, coins.MulDec(sdk.ZeroDec()))
+}
+
+func TestTruncateDecimalDecCoins(t *testing.T) {
+	coins := DecCoins{{"A", sdk.NewDecWithPrec(25, 1)}, {"B", sdk.NewDecWithPrec(5, 1)}, {"C", sdk.NewDec(3)}}
+
+	truncated, change := coins.TruncateDecimal()
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin("A", 2), sdk.NewInt64Coin("C", 3)}, truncated)
+	require.Equal(t, DecCoins{{"A", sdk.NewDecWithPrec(5, 1)}, {"B", sdk.NewDecWithPrec(5, 1)}}, change)
+}
+
+func TestNewDecCoins(t *testing.T) {
+	coins := sdk.Coins{sdk.NewInt64Coin("A", 2), sdk.NewInt64Coin("B", 7)}
+	decCoins := NewDecCoins(coins)
+	require.Equal(t, DecCoins{NewDecCoin("A", 2), NewDecCoin("B", 7)}, decCoins)
+	require.True(t, decCoins.AmountOf("B").Equal(sdk.NewDec(7)))
+	require.True(t, decCoins.AmountOf("C").IsZero())
+}
@@ -0,0 +1,60 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// distribution info for a delegation - used to determine entitled rewards
+type DelegationDistInfo struct {
+	DelegatorAddr    sdk.AccAddress `json:"delegator_addr"`
+	ValOperatorAddr  sdk.ValAddress `json:"val_operator_addr"`
+	WithdrawalHeight int64          `json:"withdrawal_height"` // last time this delegation withdrew rewards
+}
+
+func NewDelegationDistInfo(delegatorAddr sdk.AccAddress, valOperatorAddr sdk.ValAddress,
+	currentHeight int64) DelegationDistInfo {
+
+	return DelegationDistInfo{
+		DelegatorAddr:    delegatorAddr,
+		ValOperatorAddr:  valOperatorAddr,
+		WithdrawalHeight: currentHeight,
+	}
+}
+
+// withdraw rewards from delegator
+func (di DelegationDistInfo) WithdrawRewards(fp FeePool, vi ValidatorDistInfo,
+	height int64, totalBonded, vdTokens, totalDelShares, delegatorShares,
+	commissionRate sdk.Dec) (DelegationDistInfo, ValidatorDistInfo, FeePool, DecCoins) {
+
+	vi = vi.UpdateTotalDelAccum(height, totalDelShares)
+	vi, fp = vi.TakeFeePoolRewards(fp, height, totalBonded, vdTokens, commissionRate)
+
+	blocks := height - di.WithdrawalHeight
+	di.WithdrawalHeight = height
+	if vi.DelAccum.Accum.IsZero() {
+		return di, vi, fp, DecCoins{}
+	}
+
+	accum := delegatorShares.Mul(sdk.NewDec(blocks))
+	if accum.GT(vi.DelAccum.Accum) {
+		panic("individual accum should never be greater than the total")
+	}
+	withdrawalTokens := vi.DelPool
+	if !accum.Equal(vi.DelAccum.Accum) {
+		withdrawalTokens = vi.DelPool.MulDec(accum).QuoDec(vi.DelAccum.Accum)
+	}
+	remainingTokens := vi.DelPool.Minus(withdrawalTokens)
+
+	vi.DelPool = remainingTokens
+	vi.DelAccum.Accum = vi.DelAccum.Accum.Sub(accum)
+
+	return di, vi, fp, withdrawalTokens
+}
+
+//_____________________________________________________________________
+
+// withdraw address for the delegation rewards
+type DelegatorWithdrawInfo struct {
+	DelegatorAddr sdk.AccAddress `json:"delegator_addr"`
+	WithdrawAddr  sdk.AccAddress `json:"withdraw_addr"`
+}
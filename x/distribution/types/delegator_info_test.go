@@ -0,0 +1,67 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// dummy addresses used for testing
+var (
+	delAddr1 = sdk.AccAddress([]byte("delegator1__________"))
+	delAddr2 = sdk.AccAddress([]byte("delegator2__________"))
+	valAddr1 = sdk.ValAddress([]byte("validator1__________"))
+	valAddr2 = sdk.ValAddress([]byte("validator2__________"))
+)
+
+func TestWithdrawRewards(t *testing.T) {
+
+	// initialize
+	height := int64(0)
+	fp := InitialFeePool()
+	vi := NewValidatorDistInfo(valAddr1, height)
+	commissionRate := sdk.NewDecWithPrec(2, 2)
+	validatorTokens := sdk.NewDec(10)
+	validatorDelShares := sdk.NewDec(10)
+	totalBondedTokens := validatorTokens.Add(sdk.NewDec(10)) // validator-1 is 50% of total power
+
+	di1 := NewDelegationDistInfo(delAddr1, valAddr1, height)
+	di1Shares := sdk.NewDec(5) // this delegator has half the shares in the validator
+
+	di2 := NewDelegationDistInfo(delAddr2, valAddr1, height)
+	di2Shares := sdk.NewDec(5)
+
+	// simulate adding some stake for inflation
+	height = 10
+	fp.ValPool = DecCoins{NewDecCoin("stake", 1000)}
+
+	// withdraw rewards
+	di1, vi, fp, rewardRecv1 := di1.WithdrawRewards(fp, vi, height, totalBondedTokens,
+		validatorTokens, validatorDelShares, di1Shares, commissionRate)
+
+	assert := require.New(t)
+	assert.Equal(height, di1.WithdrawalHeight)
+	assert.True(sdk.NewDec(100).Equal(fp.TotalValAccum.Accum), fp.TotalValAccum.Accum.String())
+	assert.True(sdk.NewDec(500).Equal(fp.ValPool[0].Amount))
+	assert.True(sdk.NewDec(245).Equal(vi.DelPool[0].Amount))
+	assert.True(sdk.NewDec(10).Equal(vi.ValCommission[0].Amount))
+	assert.True(sdk.NewDec(245).Equal(rewardRecv1[0].Amount))
+
+	// add more fees to the pool and withdraw the rewards of the second delegator
+	fp.ValPool = fp.ValPool.Plus(DecCoins{NewDecCoin("stake", 1000)})
+	height = 20
+	di2, vi, fp, rewardRecv2 := di2.WithdrawRewards(fp, vi, height, totalBondedTokens,
+		validatorTokens, validatorDelShares, di2Shares, commissionRate)
+
+	// the validator took 10*10/(100+200) of the 1500 pool, 490 of which is for
+	// the delegators, the second delegator is owed 100/150 of the delegator
+	// pool of 735
+	assert.Equal(height, di2.WithdrawalHeight)
+	assert.True(sdk.NewDec(200).Equal(fp.TotalValAccum.Accum), fp.TotalValAccum.Accum.String())
+	assert.True(sdk.NewDec(1000).Equal(fp.ValPool[0].Amount))
+	assert.True(sdk.NewDec(20).Equal(vi.ValCommission[0].Amount))
+	assert.True(sdk.NewDec(490).Equal(rewardRecv2[0].Amount), rewardRecv2[0].Amount.String())
+	assert.True(sdk.NewDec(245).Equal(vi.DelPool[0].Amount), vi.DelPool[0].Amount.String())
+}
@@ -0,0 +1,32 @@
+// nolint
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type CodeType = sdk.CodeType
+
+const (
+	DefaultCodespace sdk.CodespaceType = 7
+
+	CodeInvalidInput   CodeType = 103
+	CodeNoDistribution CodeType = 104
+	CodeInvalidAddress CodeType = sdk.CodeInvalidAddress
+)
+
+func ErrNilDelegatorAddr(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, "delegator address is nil")
+}
+func ErrNilWithdrawAddr(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, "withdraw address is nil")
+}
+func ErrNilValidatorAddr(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidInput, "validator address is nil")
+}
+func ErrNoDelegationDistInfo(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeNoDistribution, "no delegation distribution info")
+}
+func ErrNoValidatorDistInfo(codespace sdk.CodespaceType) sdk.Error {
+	return sdk.NewError(codespace, CodeNoDistribution, "no validator distribution info")
+}
@@ -0,0 +1,28 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// global fee pool for distribution
+type FeePool struct {
+	TotalValAccum TotalAccum `json:"val_accum"`      // total valdator accum held by validators
+	ValPool       DecCoins   `json:"val_pool"`       // funds for all validators which have yet to be withdrawn
+	CommunityPool DecCoins   `json:"community_pool"` // pool for community funds yet to be spent
+}
+
+// update total validator accumulation factor
+// CONTRACT: the total bonded tokens must have been constant since the last update
+func (f FeePool) UpdateTotalValAccum(height int64, totalBondedTokens sdk.Dec) FeePool {
+	f.TotalValAccum = f.TotalValAccum.UpdateForNewHeight(height, totalBondedTokens)
+	return f
+}
+
+// zero fee pool
+func InitialFeePool() FeePool {
+	return FeePool{
+		TotalValAccum: NewTotalAccum(0),
+		ValPool:       DecCoins{},
+		CommunityPool: DecCoins{},
+	}
+}
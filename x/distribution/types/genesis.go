@@ -0,0 +1,40 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all distribution state that must be provided at genesis
+type GenesisState struct {
+	FeePool                FeePool                 `json:"fee_pool"`
+	CommunityTax           sdk.Dec                 `json:"community_tax"`
+	BaseProposerReward     sdk.Dec                 `json:"base_proposer_reward"`
+	BonusProposerReward    sdk.Dec                 `json:"bonus_proposer_reward"`
+	ValidatorDistInfos     []ValidatorDistInfo     `json:"validator_dist_infos"`
+	DelegationDistInfos    []DelegationDistInfo    `json:"delegator_dist_infos"`
+	DelegatorWithdrawInfos []DelegatorWithdrawInfo `json:"delegator_withdraw_infos"`
+}
+
+func NewGenesisState(feePool FeePool, communityTax, baseProposerReward, bonusProposerReward sdk.Dec,
+	vdis []ValidatorDistInfo, ddis []DelegationDistInfo, dwis []DelegatorWithdrawInfo) GenesisState {
+
+	return GenesisState{
+		FeePool:                feePool,
+		CommunityTax:           communityTax,
+		BaseProposerReward:     baseProposerReward,
+		BonusProposerReward:    bonusProposerReward,
+		ValidatorDistInfos:     vdis,
+		DelegationDistInfos:    ddis,
+		DelegatorWithdrawInfos: dwis,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		FeePool:             InitialFeePool(),
+		CommunityTax:        sdk.NewDecWithPrec(2, 2), // 2%
+		BaseProposerReward:  sdk.NewDecWithPrec(1, 2), // 1%
+		BonusProposerReward: sdk.NewDecWithPrec(4, 2), // 4%
+	}
+}
@@ -0,0 +1,30 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// total accumulation tracker
+type TotalAccum struct {
+	UpdateHeight int64   `json:"update_height"`
+	Accum        sdk.Dec `json:"accum"`
+}
+
+func NewTotalAccum(height int64) TotalAccum {
+	return TotalAccum{
+		UpdateHeight: height,
+		Accum:        sdk.ZeroDec(),
+	}
+}
+
+// update total validator accumulation factor for the new height
+// CONTRACT: accumCreatedPerBlock must have been constant since the last update
+func (ta TotalAccum) UpdateForNewHeight(height int64, accumCreatedPerBlock sdk.Dec) TotalAccum {
+	blocks := height - ta.UpdateHeight
+	if blocks < 0 {
+		panic("reverse updated for new height")
+	}
+	ta.Accum = ta.Accum.Add(accumCreatedPerBlock.Mul(sdk.NewDec(blocks)))
+	ta.UpdateHeight = height
+	return ta
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestTakeFeePoolRewards(t *testing.T) {
+
+	// initialize
+	height := int64(0)
+	fp := InitialFeePool()
+	vi1 := NewValidatorDistInfo(valAddr1, height)
+	vi2 := NewValidatorDistInfo(valAddr2, height)
+	commissionRate := sdk.NewDecWithPrec(2, 2)
+	validatorTokens := sdk.NewDec(100)
+	totalBondedTokens := sdk.NewDec(200)
+
+	// simulate adding some stake for inflation
+	height = 10
+	fp.ValPool = DecCoins{NewDecCoin("stake", 1000)}
+
+	vi1, fp = vi1.TakeFeePoolRewards(fp, height, totalBondedTokens, validatorTokens, commissionRate)
+	require.True(sdk.DecEq(t, sdk.NewDec(1000), fp.TotalValAccum.Accum))
+	require.True(sdk.DecEq(t, sdk.NewDec(500), fp.ValPool[0].Amount))
+	require.True(sdk.DecEq(t, sdk.NewDec(490), vi1.DelPool[0].Amount))
+	require.True(sdk.DecEq(t, sdk.NewDec(10), vi1.ValCommission[0].Amount))
+	require.Equal(t, height, vi1.FeePoolWithdrawalHeight)
+
+	// the second validator takes the rest of the pool
+	vi2, fp = vi2.TakeFeePoolRewards(fp, height, totalBondedTokens, validatorTokens, commissionRate)
+	require.True(sdk.DecEq(t, sdk.ZeroDec(), fp.TotalValAccum.Accum))
+	require.True(t, fp.ValPool.IsZero())
+	require.True(sdk.DecEq(t, sdk.NewDec(490), vi2.DelPool[0].Amount))
+	require.True(sdk.DecEq(t, sdk.NewDec(10), vi2.ValCommission[0].Amount))
+
+	// taking again within the same block does not change anything
+	vi2Again, fpAgain := vi2.TakeFeePoolRewards(fp, height, totalBondedTokens, validatorTokens, commissionRate)
+	require.Equal(t, vi2, vi2Again)
+	require.Equal(t, fp, fpAgain)
+}
+
+func TestWithdrawCommission(t *testing.T) {
+
+	// initialize
+	height := int64(0)
+	fp := InitialFeePool()
+	vi := NewValidatorDistInfo(valAddr1, height)
+	commissionRate := sdk.NewDecWithPrec(2, 2)
+	validatorTokens := sdk.NewDec(100)
+	totalBondedTokens := sdk.NewDec(200)
+
+	// simulate adding some stake for inflation
+	height = 10
+	fp.ValPool = DecCoins{NewDecCoin("stake", 1000)}
+
+	// for a more fun staring condition, have an non-withdraw update
+	vi, fp = vi.TakeFeePoolRewards(fp, height, totalBondedTokens, validatorTokens, commissionRate)
+
+	// add more fees to the pool and withdraw the commission
+	fp.ValPool = fp.ValPool.Plus(DecCoins{NewDecCoin("stake", 500)})
+	height = 20
+	vi, fp, commissionRecv := vi.WithdrawCommission(fp, height, totalBondedTokens, validatorTokens, commissionRate)
+
+	// fee pool accum was 1000 at height 10 and grew by 200*10 to 3000,
+	// the validator then takes 100*10/3000 = 1/3 of the pool of 1000
+	require.True(sdk.DecEq(t, sdk.NewDec(2000), fp.TotalValAccum.Accum))
+	require.True(t, fp.ValPool[0].Amount.Sub(sdk.NewDecWithPrec(6666666667, 7)).Abs().LT(sdk.NewDecWithPrec(1, 6)))
+	require.True(t, vi.ValCommission.IsZero())
+	require.True(t, commissionRecv[0].Amount.Sub(sdk.NewDecWithPrec(1666666667, 8)).Abs().LT(sdk.NewDecWithPrec(1, 6)))
+}
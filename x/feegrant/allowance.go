@@ -0,0 +1,97 @@
+package feegrant
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// FeeAllowance is the allowance of fees a granter gives to a grantee, out of
+// which the granter pays the fees of the txs of the grantee.
+type FeeAllowance struct {
+	SpendLimit      sdk.Coins `json:"spend_limit"`       // fees left to pay, no limit if empty
+	Expiration      time.Time `json:"expiration"`        // time from which no fee is paid, never if zero
+	AllowedMsgTypes []string  `json:"allowed_msg_types"` // types of the msgs of the txs paid for, any type if empty
+}
+
+// The allowed msg types are the routes and the names of the msgs, e.g.
+// bank/send, so that an allowance for a msg does not pay for the other msgs of
+// its route.
+func msgTypeOf(msg sdk.Msg) string {
+	return msg.Type() + "/" + msg.Name()
+}
+
+// FeeGrant is the fee allowance a granter gives to a grantee.
+type FeeGrant struct {
+	Granter   sdk.AccAddress `json:"granter"`
+	Grantee   sdk.AccAddress `json:"grantee"`
+	Allowance FeeAllowance   `json:"allowance"`
+}
+
+// ValidateBasic checks the allowance independently of the state
+func (a FeeAllowance) ValidateBasic() sdk.Error {
+	if !a.SpendLimit.IsValid() || !a.SpendLimit.IsNotNegative() {
+		return sdk.ErrInvalidCoins(a.SpendLimit.String())
+	}
+	for _, msgType := range a.AllowedMsgTypes {
+		parts := strings.Split(msgType, "/")
+		if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+			return ErrInvalidFeeAllowance(DefaultCodespace, fmt.Sprintf("invalid allowed msg type %q, expected <route>/<name>", msgType))
+		}
+	}
+	return nil
+}
+
+// Accept charges the fee of a tx of the msgs run at the block time to the
+// allowance. It returns the remaining allowance, and whether the allowance is
+// exhausted and may be removed.
+func (a FeeAllowance) Accept(fee sdk.Coins, blockTime time.Time, msgs []sdk.Msg) (remaining FeeAllowance, exhausted bool, err sdk.Error) {
+	if !a.Expiration.IsZero() && !blockTime.Before(a.Expiration) {
+		return a, false, ErrFeeAllowanceExpired(DefaultCodespace, a.Expiration)
+	}
+	if len(a.AllowedMsgTypes) != 0 {
+		for _, msg := range msgs {
+			if !a.allows(msgTypeOf(msg)) {
+				return a, false, ErrMsgTypeNotAllowed(DefaultCodespace, msgTypeOf(msg))
+			}
+		}
+	}
+	if a.SpendLimit.IsZero() {
+		return a, false, nil
+	}
+	left := a.SpendLimit.Minus(fee)
+	if !left.IsNotNegative() {
+		return a, false, ErrFeeLimitExceeded(DefaultCodespace, fee, a.SpendLimit)
+	}
+	a.SpendLimit = left
+	return a, left.IsZero(), nil
+}
+
+func (a FeeAllowance) allows(msgType string) bool {
+	for _, allowed := range a.AllowedMsgTypes {
+		if allowed == msgType {
+			return true
+		}
+	}
+	return false
+}
+
+func (a FeeAllowance) String() string {
+	spendLimit, expiration := "none", "never"
+	if !a.SpendLimit.IsZero() {
+		spendLimit = a.SpendLimit.String()
+	}
+	if !a.Expiration.IsZero() {
+		expiration = a.Expiration.String()
+	}
+	msgTypes := "any"
+	if len(a.AllowedMsgTypes) != 0 {
+		msgTypes = strings.Join(a.AllowedMsgTypes, ", ")
+	}
+	return fmt.Sprintf(`Fee Allowance:
+  Spend Limit:       %s
+  Expiration:        %s
+  Allowed Msg Types: %s`, spendLimit, expiration, msgTypes)
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+)
+
+// GetCmdQueryFeeAllowance implements the query fee allowance command.
+func GetCmdQueryFeeAllowance(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "allowance",
+		Short: "query the fee allowance a granter gave to a grantee",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			granterAddr, err := sdk.AccAddressFromBech32(viper.GetString(flagGranter))
+			if err != nil {
+				return err
+			}
+
+			granteeAddr, err := sdk.AccAddressFromBech32(viper.GetString(flagGrantee))
+			if err != nil {
+				return err
+			}
+
+			params := feegrant.QueryFeeAllowanceParams{
+				Granter: granterAddr,
+				Grantee: granteeAddr,
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, feegrant.QueryFeeAllowance), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagGranter, "", "bech32 address of the granter")
+	cmd.Flags().String(flagGrantee, "", "bech32 address of the grantee")
+
+	return cmd
+}
+
+// GetCmdQueryFeeGrants implements the query fee grants command.
+func GetCmdQueryFeeGrants(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grants",
+		Short: "query the fee allowances given to a grantee",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			granteeAddr, err := sdk.AccAddressFromBech32(viper.GetString(flagGrantee))
+			if err != nil {
+				return err
+			}
+
+			params := feegrant.QueryFeeGrantsParams{
+				Grantee: granteeAddr,
+			}
+			bz, err := cdc.MarshalJSON(params)
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", queryRoute, feegrant.QueryFeeGrants), bz)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println(string(res))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagGrantee, "", "bech32 address of the grantee")
+
+	return cmd
+}
@@ -0,0 +1,138 @@
+package cli
+
+import (
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/utils"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
+	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
+	"github.com/cosmos/cosmos-sdk/x/feegrant"
+)
+
+const (
+	flagGranter         = "granter"
+	flagGrantee         = "grantee"
+	flagSpendLimit      = "spend-limit"
+	flagExpiration      = "expiration"
+	flagAllowedMsgTypes = "allowed-msg-types"
+)
+
+// GetCmdGrantFeeAllowance implements the command granting a fee allowance.
+func GetCmdGrantFeeAllowance(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant",
+		Short: "allow a grantee to have its tx fees paid by the sender, replacing any previous allowance",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			granterAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			granteeAddr, err := sdk.AccAddressFromBech32(viper.GetString(flagGrantee))
+			if err != nil {
+				return err
+			}
+
+			spendLimit, err := sdk.ParseCoins(viper.GetString(flagSpendLimit))
+			if err != nil {
+				return err
+			}
+
+			var expiration time.Time
+			if s := viper.GetString(flagExpiration); s != "" {
+				expiration, err = time.Parse(time.RFC3339, s)
+				if err != nil {
+					return err
+				}
+			}
+
+			var msgTypes []string
+			if s := viper.GetString(flagAllowedMsgTypes); s != "" {
+				msgTypes = strings.Split(s, ",")
+			}
+
+			allowance := feegrant.FeeAllowance{
+				SpendLimit:      spendLimit,
+				Expiration:      expiration,
+				AllowedMsgTypes: msgTypes,
+			}
+			msg := feegrant.NewMsgGrantFeeAllowance(granterAddr, granteeAddr, allowance)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+
+			// Build and sign the transaction, then broadcast to a Tendermint
+			// node.
+			return utils.SendTx(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagGrantee, "", "bech32 address of the grantee")
+	cmd.Flags().String(flagSpendLimit, "", "total fees the allowance pays, no limit if empty")
+	cmd.Flags().String(flagExpiration, "", "RFC3339 time from which the allowance pays no fees, never if empty")
+	cmd.Flags().String(flagAllowedMsgTypes, "", "comma-separated types of the msgs of the txs the allowance pays for, as <route>/<name> e.g. bank/send,stake/delegate; any type if empty")
+
+	return cmd
+}
+
+// GetCmdRevokeFeeAllowance implements the command revoking a fee allowance.
+func GetCmdRevokeFeeAllowance(cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke",
+		Short: "revoke the fee allowance the sender gave to a grantee",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txBldr := authtxb.NewTxBuilderFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(authcmd.GetAccountDecoder(cdc))
+
+			granterAddr, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			granteeAddr, err := sdk.AccAddressFromBech32(viper.GetString(flagGrantee))
+			if err != nil {
+				return err
+			}
+
+			msg := feegrant.NewMsgRevokeFeeAllowance(granterAddr, granteeAddr)
+			err = msg.ValidateBasic()
+			if err != nil {
+				return err
+			}
+
+			if cliCtx.GenerateOnly {
+				return utils.PrintUnsignedStdTx(txBldr, cliCtx, []sdk.Msg{msg})
+			}
+
+			// Build and sign the transaction, then broadcast to a Tendermint
+			// node.
+			return utils.SendTx(txBldr, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagGrantee, "", "bech32 address of the grantee")
+
+	return cmd
+}
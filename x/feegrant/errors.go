@@ -0,0 +1,47 @@
+//nolint
+package feegrant
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	DefaultCodespace sdk.CodespaceType = 11
+
+	CodeFeeAllowanceNotFound sdk.CodeType = 1
+	CodeFeeAllowanceExpired  sdk.CodeType = 2
+	CodeFeeLimitExceeded     sdk.CodeType = 3
+	CodeMsgTypeNotAllowed    sdk.CodeType = 4
+	CodeInvalidFeeAllowance  sdk.CodeType = 5
+	CodeInvalidGrantee       sdk.CodeType = 6
+)
+
+//----------------------------------------
+// Error constructors
+
+func ErrFeeAllowanceNotFound(codespace sdk.CodespaceType, granter, grantee sdk.AccAddress) sdk.Error {
+	return sdk.NewError(codespace, CodeFeeAllowanceNotFound, fmt.Sprintf("%s granted no fee allowance to %s", granter, grantee))
+}
+
+func ErrFeeAllowanceExpired(codespace sdk.CodespaceType, expiration time.Time) sdk.Error {
+	return sdk.NewError(codespace, CodeFeeAllowanceExpired, fmt.Sprintf("fee allowance expired at %v", expiration))
+}
+
+func ErrFeeLimitExceeded(codespace sdk.CodespaceType, fee, spendLimit sdk.Coins) sdk.Error {
+	return sdk.NewError(codespace, CodeFeeLimitExceeded, fmt.Sprintf("fee %s exceeds the spend limit %s", fee, spendLimit))
+}
+
+func ErrMsgTypeNotAllowed(codespace sdk.CodespaceType, msgType string) sdk.Error {
+	return sdk.NewError(codespace, CodeMsgTypeNotAllowed, fmt.Sprintf("fee allowance does not pay for %s msgs", msgType))
+}
+
+func ErrInvalidFeeAllowance(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidFeeAllowance, msg)
+}
+
+func ErrInvalidGrantee(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidGrantee, msg)
+}
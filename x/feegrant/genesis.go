@@ -0,0 +1,47 @@
+package feegrant
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - the fee allowances given at genesis
+type GenesisState struct {
+	FeeGrants []FeeGrant `json:"fee_grants"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// InitGenesis - store the fee allowances
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	for _, grant := range data.FeeGrants {
+		k.GrantFeeAllowance(ctx, grant.Granter, grant.Grantee, grant.Allowance)
+	}
+}
+
+// WriteGenesis - output the fee allowances
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	var grants []FeeGrant
+	k.IterateFeeGrants(ctx, func(grant FeeGrant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	return GenesisState{grants}
+}
+
+// ValidateGenesis checks the fee allowances
+func ValidateGenesis(data GenesisState) error {
+	for _, grant := range data.FeeGrants {
+		if err := validateGrant(grant.Granter, grant.Grantee); err != nil {
+			return fmt.Errorf("invalid fee grant of %s to %s: %v", grant.Granter, grant.Grantee, err.Result().Log)
+		}
+		if err := grant.Allowance.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid fee grant of %s to %s: %v", grant.Granter, grant.Grantee, err.Result().Log)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package feegrant
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Handle all "feegrant" type messages.
+func NewHandler(keeper Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgGrantFeeAllowance:
+			return handleMsgGrantFeeAllowance(ctx, keeper, msg)
+		case MsgRevokeFeeAllowance:
+			return handleMsgRevokeFeeAllowance(ctx, keeper, msg)
+		default:
+			errMsg := "Unrecognized feegrant msg type"
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+func handleMsgGrantFeeAllowance(ctx sdk.Context, keeper Keeper, msg MsgGrantFeeAllowance) sdk.Result {
+	err := keeper.ValidateFeeAllowance(msg.Allowance)
+	if err != nil {
+		return err.Result()
+	}
+	keeper.GrantFeeAllowance(ctx, msg.Granter, msg.Grantee, msg.Allowance)
+	return sdk.Result{
+		Tags: grantTags(msg.Granter, msg.Grantee),
+	}
+}
+
+func handleMsgRevokeFeeAllowance(ctx sdk.Context, keeper Keeper, msg MsgRevokeFeeAllowance) sdk.Result {
+	err := keeper.RevokeFeeAllowance(ctx, msg.Granter, msg.Grantee)
+	if err != nil {
+		return err.Result()
+	}
+	return sdk.Result{
+		Tags: grantTags(msg.Granter, msg.Grantee),
+	}
+}
+
+func grantTags(granter, grantee sdk.AccAddress) sdk.Tags {
+	return sdk.NewTags(
+		TagGranter, []byte(granter.String()),
+		TagGrantee, []byte(grantee.String()),
+	)
+}
+
+// nolint
+var (
+	TagGranter = "granter"
+	TagGrantee = "grantee"
+)
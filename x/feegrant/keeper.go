@@ -0,0 +1,140 @@
+package feegrant
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// Keeper of the fee allowances
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *codec.Codec
+	router   baseapp.Router // routes of the msgs the allowances may pay for
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+var _ auth.FeeGrantKeeper = Keeper{}
+
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, router baseapp.Router, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:  key,
+		cdc:       cdc,
+		router:    router,
+		codespace: codespace,
+	}
+}
+
+// Keys for fee grant store items
+var (
+	FeeAllowanceKeyPrefix = []byte{0x00} // prefix for the fee allowances by grantee and granter
+)
+
+// gets the prefix of the fee allowances given to a grantee
+func GetFeeAllowancesKey(grantee sdk.AccAddress) []byte {
+	return append(FeeAllowanceKeyPrefix, grantee.Bytes()...)
+}
+
+// gets the key of the fee allowance a granter gave to a grantee
+func GetFeeAllowanceKey(granter, grantee sdk.AccAddress) []byte {
+	return append(GetFeeAllowancesKey(grantee), granter.Bytes()...)
+}
+
+//______________________________________________________________________
+
+// GetFeeAllowance returns the fee allowance the granter gave to the grantee
+func (k Keeper) GetFeeAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress) (allowance FeeAllowance, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(GetFeeAllowanceKey(granter, grantee))
+	if bz == nil {
+		return allowance, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &allowance)
+	return allowance, true
+}
+
+// GrantFeeAllowance sets the fee allowance the granter gives to the grantee,
+// replacing any previous one
+func (k Keeper) GrantFeeAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress, allowance FeeAllowance) {
+	ctx.KVStore(k.storeKey).Set(GetFeeAllowanceKey(granter, grantee), k.cdc.MustMarshalBinary(allowance))
+}
+
+// ValidateFeeAllowance checks that the allowed msg types of the allowance are
+// routed by the app
+func (k Keeper) ValidateFeeAllowance(allowance FeeAllowance) sdk.Error {
+	if err := allowance.ValidateBasic(); err != nil {
+		return err
+	}
+	for _, msgType := range allowance.AllowedMsgTypes {
+		route := strings.Split(msgType, "/")[0]
+		if k.router.Route(route) == nil {
+			return ErrInvalidFeeAllowance(k.codespace, fmt.Sprintf("unknown allowed msg type %q", msgType))
+		}
+	}
+	return nil
+}
+
+// RevokeFeeAllowance removes the fee allowance the granter gave to the grantee
+func (k Keeper) RevokeFeeAllowance(ctx sdk.Context, granter, grantee sdk.AccAddress) sdk.Error {
+	store := ctx.KVStore(k.storeKey)
+	key := GetFeeAllowanceKey(granter, grantee)
+	if !store.Has(key) {
+		return ErrFeeAllowanceNotFound(k.codespace, granter, grantee)
+	}
+	store.Delete(key)
+	return nil
+}
+
+// GetFeeGrants returns the fee allowances given to the grantee
+func (k Keeper) GetFeeGrants(ctx sdk.Context, grantee sdk.AccAddress) (grants []FeeGrant) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), GetFeeAllowancesKey(grantee))
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		grants = append(grants, k.feeGrantFromEntry(iterator.Key(), iterator.Value()))
+	}
+	return grants
+}
+
+// IterateFeeGrants iterates over all the fee allowances until the function
+// returns true
+func (k Keeper) IterateFeeGrants(ctx sdk.Context, fn func(grant FeeGrant) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), FeeAllowanceKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		if fn(k.feeGrantFromEntry(iterator.Key(), iterator.Value())) {
+			break
+		}
+	}
+}
+
+func (k Keeper) feeGrantFromEntry(key, value []byte) (grant FeeGrant) {
+	addrs := key[len(FeeAllowanceKeyPrefix):]
+	grant.Grantee = sdk.AccAddress(addrs[:sdk.AddrLen])
+	grant.Granter = sdk.AccAddress(addrs[sdk.AddrLen:])
+	k.cdc.MustUnmarshalBinary(value, &grant.Allowance)
+	return grant
+}
+
+// UseGrantedFees charges the fee of a tx of the msgs paid by the granter to
+// the allowance it gave to the grantee, the exhausted allowances being
+// removed. It implements auth.FeeGrantKeeper.
+func (k Keeper) UseGrantedFees(ctx sdk.Context, granter, grantee sdk.AccAddress, fee sdk.Coins, msgs []sdk.Msg) sdk.Error {
+	allowance, found := k.GetFeeAllowance(ctx, granter, grantee)
+	if !found {
+		return ErrFeeAllowanceNotFound(k.codespace, granter, grantee)
+	}
+	remaining, exhausted, err := allowance.Accept(fee, ctx.BlockHeader().Time, msgs)
+	if err != nil {
+		return err
+	}
+	if exhausted {
+		return k.RevokeFeeAllowance(ctx, granter, grantee)
+	}
+	k.GrantFeeAllowance(ctx, granter, grantee, remaining)
+	return nil
+}
@@ -0,0 +1,158 @@
+package feegrant
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	granter  = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	granter2 = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	grantee  = sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+)
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	keyFeeGrant := sdk.NewKVStoreKey("feegrant")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyFeeGrant, sdk.StoreTypeIAVL, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 10, Time: time.Unix(1000, 0).UTC()}, false, log.NewNopLogger())
+	noop := func(ctx sdk.Context, msg sdk.Msg) sdk.Result { return sdk.Result{} }
+	router := baseapp.NewRouter().AddRoute("bank", noop).AddRoute(MsgType, noop)
+	return ctx, NewKeeper(codec.New(), keyFeeGrant, router, DefaultCodespace)
+}
+
+func TestFeeAllowanceAccept(t *testing.T) {
+	blockTime := time.Unix(1000, 0).UTC()
+	msgs := []sdk.Msg{NewMsgRevokeFeeAllowance(grantee, granter)}
+	fee := sdk.Coins{sdk.NewInt64Coin("atom", 10)}
+
+	tests := []struct {
+		allowance FeeAllowance
+		remaining sdk.Coins
+		exhausted bool
+		code      sdk.CodeType
+	}{
+		{FeeAllowance{}, nil, false, sdk.CodeOK},
+		{FeeAllowance{SpendLimit: sdk.Coins{sdk.NewInt64Coin("atom", 15)}}, sdk.Coins{sdk.NewInt64Coin("atom", 5)}, false, sdk.CodeOK},
+		{FeeAllowance{SpendLimit: sdk.Coins{sdk.NewInt64Coin("atom", 10)}}, sdk.Coins{}, true, sdk.CodeOK},
+		{FeeAllowance{SpendLimit: sdk.Coins{sdk.NewInt64Coin("atom", 5)}}, nil, false, CodeFeeLimitExceeded},
+		{FeeAllowance{SpendLimit: sdk.Coins{sdk.NewInt64Coin("steak", 50)}}, nil, false, CodeFeeLimitExceeded},
+		{FeeAllowance{Expiration: blockTime.Add(time.Second)}, nil, false, sdk.CodeOK},
+		{FeeAllowance{Expiration: blockTime}, nil, false, CodeFeeAllowanceExpired},
+		{FeeAllowance{AllowedMsgTypes: []string{"bank/send", "feegrant/revoke_fee_allowance"}}, nil, false, sdk.CodeOK},
+		{FeeAllowance{AllowedMsgTypes: []string{"feegrant/grant_fee_allowance"}}, nil, false, CodeMsgTypeNotAllowed},
+		{FeeAllowance{AllowedMsgTypes: []string{"bank/send"}}, nil, false, CodeMsgTypeNotAllowed},
+	}
+
+	for i, tc := range tests {
+		remaining, exhausted, err := tc.allowance.Accept(fee, blockTime, msgs)
+		if tc.code != sdk.CodeOK {
+			require.NotNil(t, err, "test: %v", i)
+			require.Equal(t, tc.code, err.Code(), "test: %v", i)
+			require.Equal(t, tc.allowance, remaining, "test: %v", i)
+			continue
+		}
+		require.Nil(t, err, "test: %v", i)
+		require.Equal(t, tc.exhausted, exhausted, "test: %v", i)
+		if tc.remaining != nil {
+			require.True(t, tc.remaining.IsEqual(remaining.SpendLimit), "test: %v", i)
+		}
+	}
+}
+
+func TestGrantAndRevokeFeeAllowance(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	allowance := FeeAllowance{SpendLimit: sdk.Coins{sdk.NewInt64Coin("atom", 100)}}
+
+	_, found := keeper.GetFeeAllowance(ctx, granter, grantee)
+	require.False(t, found)
+	require.NotNil(t, keeper.RevokeFeeAllowance(ctx, granter, grantee))
+
+	keeper.GrantFeeAllowance(ctx, granter, grantee, allowance)
+	keeper.GrantFeeAllowance(ctx, granter2, grantee, FeeAllowance{})
+	keeper.GrantFeeAllowance(ctx, grantee, granter, FeeAllowance{})
+	stored, found := keeper.GetFeeAllowance(ctx, granter, grantee)
+	require.True(t, found)
+	require.True(t, allowance.SpendLimit.IsEqual(stored.SpendLimit))
+	require.Len(t, keeper.GetFeeGrants(ctx, grantee), 2)
+	require.Len(t, WriteGenesis(ctx, keeper).FeeGrants, 3)
+
+	require.Nil(t, keeper.RevokeFeeAllowance(ctx, granter, grantee))
+	_, found = keeper.GetFeeAllowance(ctx, granter, grantee)
+	require.False(t, found)
+	grants := keeper.GetFeeGrants(ctx, grantee)
+	require.Len(t, grants, 1)
+	require.Equal(t, granter2, grants[0].Granter)
+	require.Equal(t, grantee, grants[0].Grantee)
+}
+
+func TestUseGrantedFees(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+	msgs := []sdk.Msg{NewMsgRevokeFeeAllowance(grantee, granter)}
+	fee := sdk.Coins{sdk.NewInt64Coin("atom", 40)}
+
+	err := keeper.UseGrantedFees(ctx, granter, grantee, fee, msgs)
+	require.Equal(t, CodeFeeAllowanceNotFound, err.Code())
+
+	// the fees are charged to the allowance until it is exhausted
+	keeper.GrantFeeAllowance(ctx, granter, grantee, FeeAllowance{SpendLimit: sdk.Coins{sdk.NewInt64Coin("atom", 80)}})
+	require.Nil(t, keeper.UseGrantedFees(ctx, granter, grantee, fee, msgs))
+	allowance, found := keeper.GetFeeAllowance(ctx, granter, grantee)
+	require.True(t, found)
+	require.True(t, fee.IsEqual(allowance.SpendLimit))
+
+	require.Nil(t, keeper.UseGrantedFees(ctx, granter, grantee, fee, msgs))
+	_, found = keeper.GetFeeAllowance(ctx, granter, grantee)
+	require.False(t, found)
+
+	// a rejected fee leaves the allowance unchanged
+	allowance = FeeAllowance{SpendLimit: sdk.Coins{sdk.NewInt64Coin("atom", 30)}}
+	keeper.GrantFeeAllowance(ctx, granter, grantee, allowance)
+	err = keeper.UseGrantedFees(ctx, granter, grantee, fee, msgs)
+	require.Equal(t, CodeFeeLimitExceeded, err.Code())
+	stored, found := keeper.GetFeeAllowance(ctx, granter, grantee)
+	require.True(t, found)
+	require.True(t, allowance.SpendLimit.IsEqual(stored.SpendLimit))
+}
+
+func TestValidateFeeAllowance(t *testing.T) {
+	_, keeper := createTestInput(t)
+
+	tests := []struct {
+		allowance  FeeAllowance
+		expectPass bool
+	}{
+		{FeeAllowance{}, true},
+		{FeeAllowance{AllowedMsgTypes: []string{"bank/send", "feegrant/grant_fee_allowance"}}, true},
+		{FeeAllowance{AllowedMsgTypes: []string{"bank"}}, false},
+		{FeeAllowance{AllowedMsgTypes: []string{"bank/"}}, false},
+		{FeeAllowance{AllowedMsgTypes: []string{"bank/send/all"}}, false},
+		{FeeAllowance{AllowedMsgTypes: []string{"stake/delegate"}}, false},
+		{FeeAllowance{SpendLimit: sdk.Coins{sdk.NewInt64Coin("atom", -1)}}, false},
+	}
+
+	for i, tc := range tests {
+		if tc.expectPass {
+			require.Nil(t, keeper.ValidateFeeAllowance(tc.allowance), "test: %v", i)
+		} else {
+			require.NotNil(t, keeper.ValidateFeeAllowance(tc.allowance), "test: %v", i)
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package feegrant
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// name to identify transaction types
+const MsgType = "feegrant"
+
+var _, _ sdk.Msg = MsgGrantFeeAllowance{}, MsgRevokeFeeAllowance{}
+
+//-----------------------------------------------------------
+// MsgGrantFeeAllowance
+type MsgGrantFeeAllowance struct {
+	Granter   sdk.AccAddress `json:"granter"`   // address paying the fees of the grantee
+	Grantee   sdk.AccAddress `json:"grantee"`   // address whose fees are paid
+	Allowance FeeAllowance   `json:"allowance"` // allowance replacing any previous one
+}
+
+func NewMsgGrantFeeAllowance(granter, grantee sdk.AccAddress, allowance FeeAllowance) MsgGrantFeeAllowance {
+	return MsgGrantFeeAllowance{
+		Granter:   granter,
+		Grantee:   grantee,
+		Allowance: allowance,
+	}
+}
+
+// Implements Msg.
+func (msg MsgGrantFeeAllowance) Type() string { return MsgType }
+func (msg MsgGrantFeeAllowance) Name() string { return "grant_fee_allowance" }
+
+// Implements Msg.
+func (msg MsgGrantFeeAllowance) ValidateBasic() sdk.Error {
+	if err := validateGrant(msg.Granter, msg.Grantee); err != nil {
+		return err
+	}
+	return msg.Allowance.ValidateBasic()
+}
+
+func (msg MsgGrantFeeAllowance) String() string {
+	return fmt.Sprintf("MsgGrantFeeAllowance{%s, %s, %v}", msg.Granter, msg.Grantee, msg.Allowance)
+}
+
+// Implements Msg.
+func (msg MsgGrantFeeAllowance) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgGrantFeeAllowance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+//-----------------------------------------------------------
+// MsgRevokeFeeAllowance
+type MsgRevokeFeeAllowance struct {
+	Granter sdk.AccAddress `json:"granter"` // address which gave the allowance
+	Grantee sdk.AccAddress `json:"grantee"` // address the allowance was given to
+}
+
+func NewMsgRevokeFeeAllowance(granter, grantee sdk.AccAddress) MsgRevokeFeeAllowance {
+	return MsgRevokeFeeAllowance{
+		Granter: granter,
+		Grantee: grantee,
+	}
+}
+
+// Implements Msg.
+func (msg MsgRevokeFeeAllowance) Type() string { return MsgType }
+func (msg MsgRevokeFeeAllowance) Name() string { return "revoke_fee_allowance" }
+
+// Implements Msg.
+func (msg MsgRevokeFeeAllowance) ValidateBasic() sdk.Error {
+	return validateGrant(msg.Granter, msg.Grantee)
+}
+
+func (msg MsgRevokeFeeAllowance) String() string {
+	return fmt.Sprintf("MsgRevokeFeeAllowance{%s, %s}", msg.Granter, msg.Grantee)
+}
+
+// Implements Msg.
+func (msg MsgRevokeFeeAllowance) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgRevokeFeeAllowance) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+// a granter cannot grant fees to itself
+func validateGrant(granter, grantee sdk.AccAddress) sdk.Error {
+	if len(granter) == 0 {
+		return sdk.ErrInvalidAddress(granter.String())
+	}
+	if len(grantee) == 0 {
+		return sdk.ErrInvalidAddress(grantee.String())
+	}
+	if bytes.Equal(granter, grantee) {
+		return ErrInvalidGrantee(DefaultCodespace, "cannot grant fees to oneself")
+	}
+	return nil
+}
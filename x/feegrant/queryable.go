@@ -0,0 +1,73 @@
+package feegrant
+
+import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// query endpoints supported by the fee grant Querier
+const (
+	QueryFeeAllowance = "allowance"
+	QueryFeeGrants    = "grants"
+)
+
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) (res []byte, err sdk.Error) {
+		switch path[0] {
+		case QueryFeeAllowance:
+			return queryFeeAllowance(ctx, req, keeper)
+		case QueryFeeGrants:
+			return queryFeeGrants(ctx, req, keeper)
+		default:
+			return nil, sdk.ErrUnknownRequest("unknown feegrant query endpoint")
+		}
+	}
+}
+
+// Params for query 'custom/feegrant/allowance'
+type QueryFeeAllowanceParams struct {
+	Granter sdk.AccAddress
+	Grantee sdk.AccAddress
+}
+
+func queryFeeAllowance(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryFeeAllowanceParams
+	err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("incorrectly formatted request data - %s", err2.Error()))
+	}
+
+	allowance, found := keeper.GetFeeAllowance(ctx, params.Granter, params.Grantee)
+	if !found {
+		return []byte{}, ErrFeeAllowanceNotFound(DefaultCodespace, params.Granter, params.Grantee)
+	}
+
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, allowance)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+	return bz, nil
+}
+
+// Params for query 'custom/feegrant/grants'
+type QueryFeeGrantsParams struct {
+	Grantee sdk.AccAddress
+}
+
+func queryFeeGrants(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) (res []byte, err sdk.Error) {
+	var params QueryFeeGrantsParams
+	err2 := keeper.cdc.UnmarshalJSON(req.Data, &params)
+	if err2 != nil {
+		return []byte{}, sdk.ErrUnknownRequest(fmt.Sprintf("incorrectly formatted request data - %s", err2.Error()))
+	}
+
+	grants := keeper.GetFeeGrants(ctx, params.Grantee)
+	bz, err2 := codec.MarshalJSONIndent(keeper.cdc, grants)
+	if err2 != nil {
+		panic("could not marshal result to JSON")
+	}
+	return bz, nil
+}
@@ -11,6 +11,7 @@ import (
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
 	authtxb "github.com/cosmos/cosmos-sdk/x/auth/client/txbuilder"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 
 	"encoding/json"
 	"io/ioutil"
@@ -35,10 +36,12 @@ const (
 )
 
 type proposal struct {
-	Title       string
-	Description string
-	Type        string
-	Deposit     string
+	Title        string
+	Description  string
+	Type         string
+	Deposit      string
+	ParamChanges []gov.ParamChange `json:"param_changes"`
+	UpgradePlan  *upgrade.Plan     `json:"upgrade_plan"`
 }
 
 var proposalFlags = []string{
@@ -70,6 +73,30 @@ where proposal.json contains:
 is equivalent to
 
 $ gaiacli gov submit-proposal --title="Test Proposal" --description="My awesome proposal" --type="Text" --deposit="1000test"
+
+ParameterChange proposals can only be given through a proposal JSON file, listing the
+changed params along with their new JSON encoded values:
+
+{
+  "title": "Longer voting period",
+  "description": "Double the voting period",
+  "type": "ParameterChange",
+  "deposit": "1000test",
+  "param_changes": [
+    {"key": "gov/votingprocedure", "value": "{\"voting_period\":\"345600000000000\"}"}
+  ]
+}
+
+SoftwareUpgrade proposals are also given through a proposal JSON file, with the
+plan to schedule once the proposal passes:
+
+{
+  "title": "Upgrade to v2",
+  "description": "Switch to the v2 binary",
+  "type": "SoftwareUpgrade",
+  "deposit": "1000test",
+  "upgrade_plan": {"name": "v2", "height": "100000", "info": "https://example.com/v2"}
+}
 `),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			proposal, err := parseSubmitProposalFlags()
@@ -99,6 +126,8 @@ $ gaiacli gov submit-proposal --title="Test Proposal" --description="My awesome
 			}
 
 			msg := gov.NewMsgSubmitProposal(proposal.Title, proposal.Description, proposalType, fromAddr, amount)
+			msg.ParamChanges = proposal.ParamChanges
+			msg.UpgradePlan = proposal.UpgradePlan
 			err = msg.ValidateBasic()
 			if err != nil {
 				return err
@@ -9,6 +9,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/gov"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 
 	"github.com/gorilla/mux"
 	"github.com/pkg/errors"
@@ -41,12 +42,14 @@ func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *codec.Codec)
 }
 
 type postProposalReq struct {
-	BaseReq        baseReq          `json:"base_req"`
-	Title          string           `json:"title"`           //  Title of the proposal
-	Description    string           `json:"description"`     //  Description of the proposal
-	ProposalType   gov.ProposalKind `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Proposer       sdk.AccAddress   `json:"proposer"`        //  Address of the proposer
-	InitialDeposit sdk.Coins        `json:"initial_deposit"` // Coins to add to the proposal's deposit
+	BaseReq        baseReq           `json:"base_req"`
+	Title          string            `json:"title"`           //  Title of the proposal
+	Description    string            `json:"description"`     //  Description of the proposal
+	ProposalType   gov.ProposalKind  `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Proposer       sdk.AccAddress    `json:"proposer"`        //  Address of the proposer
+	InitialDeposit sdk.Coins         `json:"initial_deposit"` // Coins to add to the proposal's deposit
+	ParamChanges   []gov.ParamChange `json:"param_changes"`   // Parameter changes, only for ParameterChange proposals
+	UpgradePlan    *upgrade.Plan     `json:"upgrade_plan"`    // Upgrade plan, only for SoftwareUpgrade proposals
 }
 
 type depositReq struct {
@@ -75,6 +78,8 @@ func postProposalHandlerFn(cdc *codec.Codec, cliCtx context.CLIContext) http.Han
 
 		// create the message
 		msg := gov.NewMsgSubmitProposal(req.Title, req.Description, req.ProposalType, req.Proposer, req.InitialDeposit)
+		msg.ParamChanges = req.ParamChanges
+		msg.UpgradePlan = req.UpgradePlan
 		err = msg.ValidateBasic()
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
@@ -8,6 +8,9 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 func TestTickExpiredDepositPeriod(t *testing.T) {
@@ -193,3 +196,149 @@ func TestTickPassedVotingPeriod(t *testing.T) {
 	require.Equal(t, StatusRejected, keeper.GetProposal(ctx, proposalID).GetStatus())
 	require.True(t, keeper.GetProposal(ctx, proposalID).GetTallyResult().Equals(EmptyTallyResult()))
 }
+
+func TestTickPassedParameterChangeProposal(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs[:2]))
+	for i, addr := range addrs[:2] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5})
+
+	votingProcedure := keeper.GetVotingProcedure(ctx)
+	newVotingProcedure := VotingProcedure{VotingPeriod: votingProcedure.VotingPeriod * 2}
+	change := NewParamChange(ParamStoreKeyVotingProcedure, string(keeper.cdc.MustMarshalJSON(newVotingProcedure)))
+
+	// changes to params which are not governable are rejected
+	invalidChange := NewParamChange("stake/params", string(keeper.cdc.MustMarshalJSON(newVotingProcedure)))
+	newProposalMsg := NewMsgSubmitParameterChangeProposal("Test", "test", []ParamChange{invalidChange}, addrs[0], sdk.Coins{sdk.NewInt64Coin("steak", 10)})
+	res := govHandler(ctx, newProposalMsg)
+	require.False(t, res.IsOK())
+
+	// changes to invalid values are rejected
+	invalidChange = NewParamChange(ParamStoreKeyVotingProcedure, string(keeper.cdc.MustMarshalJSON(VotingProcedure{})))
+	newProposalMsg = NewMsgSubmitParameterChangeProposal("Test", "test", []ParamChange{invalidChange}, addrs[0], sdk.Coins{sdk.NewInt64Coin("steak", 10)})
+	res = govHandler(ctx, newProposalMsg)
+	require.False(t, res.IsOK())
+
+	newProposalMsg = NewMsgSubmitParameterChangeProposal("Test", "test", []ParamChange{change}, addrs[0], sdk.Coins{sdk.NewInt64Coin("steak", 10)})
+	res = govHandler(ctx, newProposalMsg)
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+	require.Equal(t, StatusVotingPeriod, keeper.GetProposal(ctx, proposalID).GetStatus())
+
+	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], OptionYes)
+	require.Nil(t, err)
+
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(votingProcedure.VotingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+
+	// the params are only changed once the proposal has passed
+	require.Equal(t, votingProcedure, keeper.GetVotingProcedure(ctx))
+	EndBlocker(ctx, keeper)
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+	require.Equal(t, newVotingProcedure, keeper.GetVotingProcedure(ctx))
+}
+
+func TestTickPassedStakeParameterChangeProposal(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs[:3]))
+	for i, addr := range addrs[:3] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5, 3})
+	require.Len(t, sk.GetValidatorsBonded(ctx), 3)
+
+	stakeParams := sk.GetParams(ctx)
+	changes := []ParamChange{
+		NewParamChange(stake.ParamKeyMaxValidators, string(keeper.cdc.MustMarshalJSON(uint16(2)))),
+		NewParamChange(stake.ParamKeyUnbondingTime, string(keeper.cdc.MustMarshalJSON(time.Hour))),
+	}
+
+	// changes to invalid values are rejected
+	invalidChange := NewParamChange(stake.ParamKeyMaxValidators, string(keeper.cdc.MustMarshalJSON(uint16(0))))
+	newProposalMsg := NewMsgSubmitParameterChangeProposal("Test", "test", []ParamChange{invalidChange}, addrs[0], sdk.Coins{sdk.NewInt64Coin("steak", 10)})
+	res := govHandler(ctx, newProposalMsg)
+	require.False(t, res.IsOK())
+
+	newProposalMsg = NewMsgSubmitParameterChangeProposal("Test", "test", changes, addrs[0], sdk.Coins{sdk.NewInt64Coin("steak", 10)})
+	res = govHandler(ctx, newProposalMsg)
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+
+	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], OptionYes)
+	require.Nil(t, err)
+
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(keeper.GetVotingProcedure(ctx).VotingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+
+	// the stake params are changed through the stake keeper once the proposal
+	// has passed, the validator with the least power leaving the bonded set
+	require.Equal(t, stakeParams, sk.GetParams(ctx))
+	EndBlocker(ctx, keeper)
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+	stakeParams.MaxValidators = 2
+	stakeParams.UnbondingTime = time.Hour
+	require.Equal(t, stakeParams, sk.GetParams(ctx))
+	require.Len(t, sk.GetValidatorsBonded(ctx), 2)
+	validator, found := sk.GetValidator(ctx, valAddrs[2])
+	require.True(t, found)
+	require.NotEqual(t, sdk.Bonded, validator.Status)
+}
+
+func TestTickPassedSoftwareUpgradeProposal(t *testing.T) {
+	mapp, keeper, sk, addrs, _, _ := getMockApp(t, 10)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+	govHandler := NewHandler(keeper)
+	stakeHandler := stake.NewHandler(sk)
+
+	valAddrs := make([]sdk.ValAddress, len(addrs[:2]))
+	for i, addr := range addrs[:2] {
+		valAddrs[i] = sdk.ValAddress(addr)
+	}
+	createValidators(t, stakeHandler, ctx, valAddrs, []int64{5, 5})
+
+	plan := upgrade.NewPlan("test", 100, time.Time{}, "")
+	newProposalMsg := NewMsgSubmitSoftwareUpgradeProposal("Test", "test", plan, addrs[0], sdk.Coins{sdk.NewInt64Coin("steak", 10)})
+	res := govHandler(ctx, newProposalMsg)
+	require.True(t, res.IsOK())
+	var proposalID int64
+	keeper.cdc.UnmarshalBinaryBare(res.Data, &proposalID)
+
+	err := keeper.AddVote(ctx, proposalID, addrs[0], OptionYes)
+	require.Nil(t, err)
+	err = keeper.AddVote(ctx, proposalID, addrs[1], OptionYes)
+	require.Nil(t, err)
+
+	newHeader := ctx.BlockHeader()
+	newHeader.Time = ctx.BlockHeader().Time.Add(keeper.GetVotingProcedure(ctx).VotingPeriod)
+	ctx = ctx.WithBlockHeader(newHeader)
+
+	// the plan is only scheduled once the proposal has passed
+	_, found := keeper.uk.GetUpgradePlan(ctx)
+	require.False(t, found)
+	EndBlocker(ctx, keeper)
+	require.Equal(t, StatusPassed, keeper.GetProposal(ctx, proposalID).GetStatus())
+	scheduledPlan, found := keeper.uk.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, plan, scheduledPlan)
+}
@@ -12,6 +12,7 @@ type GenesisState struct {
 	DepositProcedure   DepositProcedure  `json:"deposit_period"`
 	VotingProcedure    VotingProcedure   `json:"voting_period"`
 	TallyingProcedure  TallyingProcedure `json:"tallying_procedure"`
+	ParamChanges       []ParamChange     `json:"param_changes"` // governable params of the global param store, applied last
 }
 
 func NewGenesisState(startingProposalID int64, dp DepositProcedure, vp VotingProcedure, tp TallyingProcedure) GenesisState {
@@ -52,6 +53,9 @@ func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
 	k.setDepositProcedure(ctx, data.DepositProcedure)
 	k.setVotingProcedure(ctx, data.VotingProcedure)
 	k.setTallyingProcedure(ctx, data.TallyingProcedure)
+	if err := k.applyParamChanges(ctx, data.ParamChanges); err != nil {
+		panic(err)
+	}
 }
 
 // WriteGenesis - output genesis parameters
@@ -66,5 +70,6 @@ func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
 		DepositProcedure:   depositProcedure,
 		VotingProcedure:    votingProcedure,
 		TallyingProcedure:  tallyingProcedure,
+		ParamChanges:       k.getStoredParamChanges(ctx),
 	}
 }
@@ -26,7 +26,23 @@ func NewHandler(keeper Keeper) sdk.Handler {
 
 func handleMsgSubmitProposal(ctx sdk.Context, keeper Keeper, msg MsgSubmitProposal) sdk.Result {
 
-	proposal := keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType)
+	var proposal Proposal
+	switch msg.ProposalType {
+	case ProposalTypeParameterChange:
+		err := keeper.ValidateParamChanges(msg.ParamChanges)
+		if err != nil {
+			return err.Result()
+		}
+		proposal = keeper.NewParameterChangeProposal(ctx, msg.Title, msg.Description, msg.ParamChanges)
+	case ProposalTypeSoftwareUpgrade:
+		err := keeper.uk.ValidatePlan(ctx, *msg.UpgradePlan)
+		if err != nil {
+			return err.Result()
+		}
+		proposal = keeper.NewSoftwareUpgradeProposal(ctx, msg.Title, msg.Description, *msg.UpgradePlan)
+	default:
+		proposal = keeper.NewTextProposal(ctx, msg.Title, msg.Description, msg.ProposalType)
+	}
 
 	err, votingStarted := keeper.AddDeposit(ctx, proposal.GetProposalID(), msg.Proposer, msg.InitialDeposit)
 	if err != nil {
@@ -141,6 +157,12 @@ func EndBlocker(ctx sdk.Context, keeper Keeper) (resTags sdk.Tags) {
 			keeper.RefundDeposits(ctx, activeProposal.GetProposalID())
 			activeProposal.SetStatus(StatusPassed)
 			action = tags.ActionProposalPassed
+
+			err := keeper.executeProposal(ctx, activeProposal)
+			if err != nil {
+				logger.Error(fmt.Sprintf("proposal %d (%s) passed but could not be executed: %v",
+					activeProposal.GetProposalID(), activeProposal.GetTitle(), err))
+			}
 		} else {
 			keeper.DeleteDeposits(ctx, activeProposal.GetProposalID())
 			activeProposal.SetStatus(StatusRejected)
@@ -1,10 +1,13 @@
 package gov
 
 import (
+	"fmt"
+
 	codec "github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 // nolint
@@ -19,6 +22,12 @@ type Keeper struct {
 	// The reference to the ParamSetter to get and set Global Params
 	ps params.Setter
 
+	// The registry of global params which may be changed by proposals
+	paramRegistry params.Registry
+
+	// The reference to the UpgradeKeeper to schedule software upgrades
+	uk upgrade.Keeper
+
 	// The reference to the CoinKeeper to modify balances
 	ck bank.Keeper
 
@@ -43,15 +52,21 @@ type Keeper struct {
 // - depositing funds into proposals, and activating upon sufficient funds being deposited
 // - users voting on proposals, with weight proportional to stake in the system
 // - and tallying the result of the vote.
-func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, ps params.Setter, ck bank.Keeper, ds sdk.DelegationSet, codespace sdk.CodespaceType) Keeper {
+// - applying the parameter changes of passed proposals to the global params registered in paramRegistry.
+// - scheduling the upgrade plans of passed software upgrade proposals.
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, ps params.Setter, paramRegistry params.Registry,
+	uk upgrade.Keeper, ck bank.Keeper, ds sdk.DelegationSet, codespace sdk.CodespaceType) Keeper {
+
 	return Keeper{
-		storeKey:  key,
-		ps:        ps,
-		ck:        ck,
-		ds:        ds,
-		vs:        ds.GetValidatorSet(),
-		cdc:       cdc,
-		codespace: codespace,
+		storeKey:      key,
+		ps:            ps,
+		paramRegistry: paramRegistry,
+		uk:            uk,
+		ck:            ck,
+		ds:            ds,
+		vs:            ds.GetValidatorSet(),
+		cdc:           cdc,
+		codespace:     codespace,
 	}
 }
 
@@ -79,6 +94,54 @@ func (keeper Keeper) NewTextProposal(ctx sdk.Context, title string, description
 	return proposal
 }
 
+// Creates a new ParameterChangeProposal, the changes must have been validated with ValidateParamChanges
+func (keeper Keeper) NewParameterChangeProposal(ctx sdk.Context, title string, description string, changes []ParamChange) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var proposal Proposal = &ParameterChangeProposal{
+		TextProposal: TextProposal{
+			ProposalID:   proposalID,
+			Title:        title,
+			Description:  description,
+			ProposalType: ProposalTypeParameterChange,
+			Status:       StatusDepositPeriod,
+			TallyResult:  EmptyTallyResult(),
+			TotalDeposit: sdk.Coins{},
+			SubmitTime:   ctx.BlockHeader().Time,
+		},
+		Changes: changes,
+	}
+	keeper.SetProposal(ctx, proposal)
+	keeper.InactiveProposalQueuePush(ctx, proposal)
+	return proposal
+}
+
+// Creates a new SoftwareUpgradeProposal, the plan must have been validated with the UpgradeKeeper
+func (keeper Keeper) NewSoftwareUpgradeProposal(ctx sdk.Context, title string, description string, plan upgrade.Plan) Proposal {
+	proposalID, err := keeper.getNewProposalID(ctx)
+	if err != nil {
+		return nil
+	}
+	var proposal Proposal = &SoftwareUpgradeProposal{
+		TextProposal: TextProposal{
+			ProposalID:   proposalID,
+			Title:        title,
+			Description:  description,
+			ProposalType: ProposalTypeSoftwareUpgrade,
+			Status:       StatusDepositPeriod,
+			TallyResult:  EmptyTallyResult(),
+			TotalDeposit: sdk.Coins{},
+			SubmitTime:   ctx.BlockHeader().Time,
+		},
+		Plan: plan,
+	}
+	keeper.SetProposal(ctx, proposal)
+	keeper.InactiveProposalQueuePush(ctx, proposal)
+	return proposal
+}
+
 // Get Proposal from store by ProposalID
 func (keeper Keeper) GetProposal(ctx sdk.Context, proposalID int64) Proposal {
 	store := ctx.KVStore(keeper.storeKey)
@@ -245,6 +308,86 @@ func (keeper Keeper) setTallyingProcedure(ctx sdk.Context, tallyingProcedure Tal
 	keeper.ps.Set(ctx, ParamStoreKeyTallyingProcedure, &tallyingProcedure)
 }
 
+// =====================================================
+// Proposal Execution
+
+// Executes a passed proposal, text proposals have no effect
+func (keeper Keeper) executeProposal(ctx sdk.Context, proposal Proposal) sdk.Error {
+	switch proposal := proposal.(type) {
+	case *ParameterChangeProposal:
+		return keeper.applyParamChanges(ctx, proposal.Changes)
+	case *SoftwareUpgradeProposal:
+		return keeper.uk.ScheduleUpgrade(ctx, proposal.Plan)
+	default:
+		return nil
+	}
+}
+
+// =====================================================
+// Parameter Changes
+
+// Checks that all the changed params are governable and that the new values decode and validate
+func (keeper Keeper) ValidateParamChanges(changes []ParamChange) sdk.Error {
+	for _, change := range changes {
+		if !keeper.paramRegistry.Has(change.Key) {
+			return ErrInvalidParamChange(keeper.codespace, fmt.Sprintf("parameter %s is not governable", change.Key))
+		}
+		if _, err := keeper.paramRegistry.Parse(keeper.cdc, change.Key, change.Value); err != nil {
+			return ErrInvalidParamChange(keeper.codespace, err.Error())
+		}
+	}
+	return nil
+}
+
+// Applies the changes to the global param store, or through the modules keeping the params
+// registered with an ApplyFn, either all changes are applied or none are
+func (keeper Keeper) applyParamChanges(ctx sdk.Context, changes []ParamChange) sdk.Error {
+	cacheCtx, write := ctx.CacheContext()
+	for _, change := range changes {
+		param, err := keeper.paramRegistry.Parse(keeper.cdc, change.Key, change.Value)
+		if err != nil {
+			return ErrInvalidParamChange(keeper.codespace, err.Error())
+		}
+		applied, err := keeper.paramRegistry.Apply(cacheCtx, change.Key, param)
+		if err != nil {
+			return ErrInvalidParamChange(keeper.codespace, err.Error())
+		}
+		if applied {
+			continue
+		}
+		if err := keeper.ps.Set(cacheCtx, change.Key, param); err != nil {
+			return ErrInvalidParamChange(keeper.codespace, err.Error())
+		}
+	}
+	write()
+	return nil
+}
+
+// Gets the governable params set in the global param store as changes, so that they can be
+// exported. The procedures of the module and the params applied through an ApplyFn are
+// skipped as they are exported with the genesis state of their modules.
+func (keeper Keeper) getStoredParamChanges(ctx sdk.Context) (changes []ParamChange) {
+	for _, key := range keeper.paramRegistry.Keys() {
+		switch {
+		case keeper.paramRegistry.HasApply(key),
+			key == ParamStoreKeyDepositProcedure,
+			key == ParamStoreKeyVotingProcedure,
+			key == ParamStoreKeyTallyingProcedure:
+			continue
+		}
+		bz := keeper.ps.GetRaw(ctx, key)
+		if bz == nil {
+			continue
+		}
+		value, err := keeper.paramRegistry.JSONFromBinary(keeper.cdc, key, bz)
+		if err != nil {
+			panic(err)
+		}
+		changes = append(changes, NewParamChange(key, value))
+	}
+	return changes
+}
+
 // =====================================================
 // Votes
 
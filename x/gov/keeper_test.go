@@ -248,3 +248,27 @@ func TestProposalQueues(t *testing.T) {
 	require.Equal(t, keeper.ActiveProposalQueuePeek(ctx).GetProposalID(), proposal4.GetProposalID())
 	require.Equal(t, keeper.ActiveProposalQueuePop(ctx).GetProposalID(), proposal4.GetProposalID())
 }
+
+func TestExportParamChanges(t *testing.T) {
+	mapp, keeper, _, _, _, _ := getMockApp(t, 0)
+	keeper.paramRegistry.Register("test/param", int64(0), nil)
+	mapp.BeginBlock(abci.RequestBeginBlock{})
+	ctx := mapp.BaseApp.NewContext(false, abci.Header{})
+
+	// the unset params are not exported
+	require.Empty(t, WriteGenesis(ctx, keeper).ParamChanges)
+
+	// the changed params are exported, without the procedures exported on their own
+	change := NewParamChange("test/param", `"10"`)
+	require.Nil(t, keeper.applyParamChanges(ctx, []ParamChange{change}))
+	data := WriteGenesis(ctx, keeper)
+	require.Equal(t, []ParamChange{change}, data.ParamChanges)
+
+	// the exported params are applied again at genesis
+	require.Nil(t, keeper.applyParamChanges(ctx, []ParamChange{NewParamChange("test/param", `"20"`)}))
+	ctx.KVStore(keeper.storeKey).Delete(KeyNextProposalID)
+	InitGenesis(ctx, keeper, data)
+	var value int64
+	require.Nil(t, keeper.ps.Get(ctx, "test/param", &value))
+	require.Equal(t, int64(10), value)
+}
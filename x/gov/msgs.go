@@ -4,6 +4,7 @@ import (
 	"fmt"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 // name to idetify transaction types
@@ -14,11 +15,13 @@ var _, _, _ sdk.Msg = MsgSubmitProposal{}, MsgDeposit{}, MsgVote{}
 //-----------------------------------------------------------
 // MsgSubmitProposal
 type MsgSubmitProposal struct {
-	Title          string         `json:"title"`           //  Title of the proposal
-	Description    string         `json:"description"`     //  Description of the proposal
-	ProposalType   ProposalKind   `json:"proposal_type"`   //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
-	Proposer       sdk.AccAddress `json:"proposer"`        //  Address of the proposer
-	InitialDeposit sdk.Coins      `json:"initial_deposit"` //  Initial deposit paid by sender. Must be strictly positive.
+	Title          string         `json:"title"`                   //  Title of the proposal
+	Description    string         `json:"description"`             //  Description of the proposal
+	ProposalType   ProposalKind   `json:"proposal_type"`           //  Type of proposal. Initial set {PlainTextProposal, SoftwareUpgradeProposal}
+	Proposer       sdk.AccAddress `json:"proposer"`                //  Address of the proposer
+	InitialDeposit sdk.Coins      `json:"initial_deposit"`         //  Initial deposit paid by sender. Must be strictly positive.
+	ParamChanges   []ParamChange  `json:"param_changes,omitempty"` //  Parameter changes, only set for ParameterChange proposals
+	UpgradePlan    *upgrade.Plan  `json:"upgrade_plan,omitempty"`  //  Upgrade plan, only set for SoftwareUpgrade proposals
 }
 
 func NewMsgSubmitProposal(title string, description string, proposalType ProposalKind, proposer sdk.AccAddress, initialDeposit sdk.Coins) MsgSubmitProposal {
@@ -31,6 +34,28 @@ func NewMsgSubmitProposal(title string, description string, proposalType Proposa
 	}
 }
 
+func NewMsgSubmitParameterChangeProposal(title string, description string, changes []ParamChange, proposer sdk.AccAddress, initialDeposit sdk.Coins) MsgSubmitProposal {
+	return MsgSubmitProposal{
+		Title:          title,
+		Description:    description,
+		ProposalType:   ProposalTypeParameterChange,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+		ParamChanges:   changes,
+	}
+}
+
+func NewMsgSubmitSoftwareUpgradeProposal(title string, description string, plan upgrade.Plan, proposer sdk.AccAddress, initialDeposit sdk.Coins) MsgSubmitProposal {
+	return MsgSubmitProposal{
+		Title:          title,
+		Description:    description,
+		ProposalType:   ProposalTypeSoftwareUpgrade,
+		Proposer:       proposer,
+		InitialDeposit: initialDeposit,
+		UpgradePlan:    &plan,
+	}
+}
+
 // Implements Msg.
 func (msg MsgSubmitProposal) Type() string { return MsgType }
 func (msg MsgSubmitProposal) Name() string { return "submit_proposal" }
@@ -55,11 +80,55 @@ func (msg MsgSubmitProposal) ValidateBasic() sdk.Error {
 	if !msg.InitialDeposit.IsNotNegative() {
 		return sdk.ErrInvalidCoins(msg.InitialDeposit.String())
 	}
+	if err := validateParamChanges(msg.ProposalType, msg.ParamChanges); err != nil {
+		return err
+	}
+	return validateUpgradePlan(msg.ProposalType, msg.UpgradePlan)
+}
+
+// parameter changes must be given for, and only for, ParameterChange proposals
+func validateParamChanges(proposalType ProposalKind, changes []ParamChange) sdk.Error {
+	if proposalType != ProposalTypeParameterChange {
+		if len(changes) != 0 {
+			return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("%s proposals cannot change parameters", proposalType))
+		}
+		return nil
+	}
+	if len(changes) == 0 {
+		return ErrInvalidParamChange(DefaultCodespace, "no parameter changes given")
+	}
+	keys := make(map[string]bool, len(changes))
+	for _, change := range changes {
+		if len(change.Key) == 0 {
+			return ErrInvalidParamChange(DefaultCodespace, "empty parameter key")
+		}
+		if len(change.Value) == 0 {
+			return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("empty value for parameter %s", change.Key))
+		}
+		if keys[change.Key] {
+			return ErrInvalidParamChange(DefaultCodespace, fmt.Sprintf("duplicate parameter %s", change.Key))
+		}
+		keys[change.Key] = true
+	}
 	return nil
 }
 
+// an upgrade plan must be given for, and only for, SoftwareUpgrade proposals
+func validateUpgradePlan(proposalType ProposalKind, plan *upgrade.Plan) sdk.Error {
+	if proposalType != ProposalTypeSoftwareUpgrade {
+		if plan != nil {
+			return upgrade.ErrInvalidPlan(upgrade.DefaultCodespace, fmt.Sprintf("%s proposals cannot schedule upgrades", proposalType))
+		}
+		return nil
+	}
+	if plan == nil {
+		return upgrade.ErrInvalidPlan(upgrade.DefaultCodespace, "no upgrade plan given")
+	}
+	return plan.ValidateBasic()
+}
+
 func (msg MsgSubmitProposal) String() string {
-	return fmt.Sprintf("MsgSubmitProposal{%s, %s, %s, %v}", msg.Title, msg.Description, msg.ProposalType, msg.InitialDeposit)
+	return fmt.Sprintf("MsgSubmitProposal{%s, %s, %s, %v, %v, %v}", msg.Title, msg.Description, msg.ProposalType, msg.InitialDeposit, msg.ParamChanges, msg.UpgradePlan)
 }
 
 // Implements Msg.
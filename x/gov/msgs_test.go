@@ -2,11 +2,13 @@ package gov
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 var (
@@ -30,8 +32,8 @@ func TestMsgSubmitProposal(t *testing.T) {
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, true},
 		{"", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsPos, false},
 		{"Test Proposal", "", ProposalTypeText, addrs[0], coinsPos, false},
-		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, true},
-		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, true},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeParameterChange, addrs[0], coinsPos, false},
+		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeSoftwareUpgrade, addrs[0], coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", 0x05, addrs[0], coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, sdk.AccAddress{}, coinsPos, false},
 		{"Test Proposal", "the purpose of this proposal is to test", ProposalTypeText, addrs[0], coinsZero, true},
@@ -49,6 +51,62 @@ func TestMsgSubmitProposal(t *testing.T) {
 	}
 }
 
+// test ValidateBasic for MsgSubmitProposal with parameter changes
+func TestMsgSubmitParameterChangeProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	change := NewParamChange("gov/votingprocedure", `{"voting_period":"1000"}`)
+	tests := []struct {
+		proposalType ProposalKind
+		changes      []ParamChange
+		expectPass   bool
+	}{
+		{ProposalTypeParameterChange, []ParamChange{change}, true},
+		{ProposalTypeParameterChange, []ParamChange{change, NewParamChange("gov/depositprocedure", "{}")}, true},
+		{ProposalTypeParameterChange, nil, false},
+		{ProposalTypeParameterChange, []ParamChange{change, change}, false},
+		{ProposalTypeParameterChange, []ParamChange{NewParamChange("", "{}")}, false},
+		{ProposalTypeParameterChange, []ParamChange{NewParamChange("gov/votingprocedure", "")}, false},
+		{ProposalTypeText, []ParamChange{change}, false},
+		{ProposalTypeSoftwareUpgrade, []ParamChange{change}, false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitParameterChangeProposal("Test Proposal", "the purpose of this proposal is to test", tc.changes, addrs[0], coinsPos)
+		msg.ProposalType = tc.proposalType
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+// test ValidateBasic for MsgSubmitProposal with an upgrade plan
+func TestMsgSubmitSoftwareUpgradeProposal(t *testing.T) {
+	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
+	tests := []struct {
+		proposalType ProposalKind
+		plan         upgrade.Plan
+		expectPass   bool
+	}{
+		{ProposalTypeSoftwareUpgrade, upgrade.NewPlan("test", 100, time.Time{}, ""), true},
+		{ProposalTypeSoftwareUpgrade, upgrade.NewPlan("test", 0, time.Unix(100, 0), ""), true},
+		{ProposalTypeSoftwareUpgrade, upgrade.NewPlan("", 100, time.Time{}, ""), false},
+		{ProposalTypeSoftwareUpgrade, upgrade.NewPlan("test", 0, time.Time{}, ""), false},
+		{ProposalTypeText, upgrade.NewPlan("test", 100, time.Time{}, ""), false},
+	}
+
+	for i, tc := range tests {
+		msg := NewMsgSubmitSoftwareUpgradeProposal("Test Proposal", "the purpose of this proposal is to test", tc.plan, addrs[0], coinsPos)
+		msg.ProposalType = tc.proposalType
+		if tc.expectPass {
+			require.Nil(t, msg.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, msg.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
 // test ValidateBasic for MsgDeposit
 func TestMsgDeposit(t *testing.T) {
 	_, addrs, _, _ := mock.CreateGenAccounts(1, sdk.Coins{})
@@ -1,9 +1,11 @@
 package gov
 
 import (
+	"fmt"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 // Procedure around Deposits for governance
@@ -23,3 +25,43 @@ type TallyingProcedure struct {
 type VotingProcedure struct {
 	VotingPeriod time.Duration `json:"voting_period"` //  Length of the voting period.
 }
+
+// RegisterParams registers the governance procedures as governable params
+func RegisterParams(registry params.Registry) {
+	registry.Register(ParamStoreKeyDepositProcedure, DepositProcedure{}, validateDepositProcedure)
+	registry.Register(ParamStoreKeyVotingProcedure, VotingProcedure{}, validateVotingProcedure)
+	registry.Register(ParamStoreKeyTallyingProcedure, TallyingProcedure{}, validateTallyingProcedure)
+}
+
+func validateDepositProcedure(value interface{}) error {
+	depositProcedure := value.(DepositProcedure)
+	if !depositProcedure.MinDeposit.IsValid() || !depositProcedure.MinDeposit.IsNotNegative() {
+		return fmt.Errorf("invalid minimum deposit %v", depositProcedure.MinDeposit)
+	}
+	if depositProcedure.MaxDepositPeriod <= 0 {
+		return fmt.Errorf("maximum deposit period must be positive, is %v", depositProcedure.MaxDepositPeriod)
+	}
+	return nil
+}
+
+func validateVotingProcedure(value interface{}) error {
+	votingProcedure := value.(VotingProcedure)
+	if votingProcedure.VotingPeriod <= 0 {
+		return fmt.Errorf("voting period must be positive, is %v", votingProcedure.VotingPeriod)
+	}
+	return nil
+}
+
+func validateTallyingProcedure(value interface{}) error {
+	tallyingProcedure := value.(TallyingProcedure)
+	if !tallyingProcedure.Threshold.GT(sdk.ZeroDec()) || tallyingProcedure.Threshold.GT(sdk.OneDec()) {
+		return fmt.Errorf("threshold must be within (0, 1], is %v", tallyingProcedure.Threshold)
+	}
+	if !tallyingProcedure.Veto.GT(sdk.ZeroDec()) || tallyingProcedure.Veto.GT(sdk.OneDec()) {
+		return fmt.Errorf("veto must be within (0, 1], is %v", tallyingProcedure.Veto)
+	}
+	if tallyingProcedure.GovernancePenalty.LT(sdk.ZeroDec()) || tallyingProcedure.GovernancePenalty.GT(sdk.OneDec()) {
+		return fmt.Errorf("governance penalty must be within [0, 1], is %v", tallyingProcedure.GovernancePenalty)
+	}
+	return nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 //-----------------------------------------------------------
@@ -99,6 +100,56 @@ func (tp *TextProposal) SetVotingStartTime(votingStartTime time.Time) {
 	tp.VotingStartTime = votingStartTime
 }
 
+//-----------------------------------------------------------
+// Parameter Change Proposals
+
+// A single change of a governable global parameter, the value is JSON encoded
+type ParamChange struct {
+	Key   string `json:"key"`   //  Key of the parameter in the global param store
+	Value string `json:"value"` //  JSON encoded new value of the parameter
+}
+
+// NewParamChange creates a new ParamChange
+func NewParamChange(key, value string) ParamChange {
+	return ParamChange{
+		Key:   key,
+		Value: value,
+	}
+}
+
+func (pc ParamChange) String() string {
+	return fmt.Sprintf("%s=%s", pc.Key, pc.Value)
+}
+
+// Proposal which, once passed, applies a set of changes to the global params
+type ParameterChangeProposal struct {
+	TextProposal
+
+	Changes []ParamChange `json:"changes"` //  Parameter changes applied when the proposal passes
+}
+
+// Implements Proposal Interface
+var _ Proposal = (*ParameterChangeProposal)(nil)
+
+// nolint
+func (pcp ParameterChangeProposal) GetChanges() []ParamChange { return pcp.Changes }
+
+//-----------------------------------------------------------
+// Software Upgrade Proposals
+
+// Proposal which, once passed, schedules a software upgrade plan
+type SoftwareUpgradeProposal struct {
+	TextProposal
+
+	Plan upgrade.Plan `json:"plan"` //  Upgrade plan scheduled when the proposal passes
+}
+
+// Implements Proposal Interface
+var _ Proposal = (*SoftwareUpgradeProposal)(nil)
+
+// nolint
+func (sup SoftwareUpgradeProposal) GetPlan() upgrade.Plan { return sup.Plan }
+
 //-----------------------------------------------------------
 // ProposalQueue
 type ProposalQueue []int64
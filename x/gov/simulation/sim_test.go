@@ -14,6 +14,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 // TestGovWithRandomMessages
@@ -30,14 +31,18 @@ func TestGovWithRandomMessages(t *testing.T) {
 	paramKey := sdk.NewKVStoreKey("params")
 	paramKeeper := params.NewKeeper(mapp.Cdc, paramKey)
 	govKey := sdk.NewKVStoreKey("gov")
-	govKeeper := gov.NewKeeper(mapp.Cdc, govKey, paramKeeper.Setter(), bankKeeper, stakeKeeper, gov.DefaultCodespace)
+	paramRegistry := params.NewRegistry()
+	gov.RegisterParams(paramRegistry)
+	upgradeKey := sdk.NewKVStoreKey("upgrade")
+	upgradeKeeper := upgrade.NewKeeper(mapp.Cdc, upgradeKey, upgrade.DefaultCodespace)
+	govKeeper := gov.NewKeeper(mapp.Cdc, govKey, paramKeeper.Setter(), paramRegistry, upgradeKeeper, bankKeeper, stakeKeeper, gov.DefaultCodespace)
 	mapp.Router().AddRoute("gov", gov.NewHandler(govKeeper))
 	mapp.SetEndBlocker(func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 		gov.EndBlocker(ctx, govKeeper)
 		return abci.ResponseEndBlock{}
 	})
 
-	err := mapp.CompleteSetup(stakeKey, stakeTKey, paramKey, govKey)
+	err := mapp.CompleteSetup(stakeKey, stakeTKey, paramKey, govKey, upgradeKey)
 	if err != nil {
 		panic(err)
 	}
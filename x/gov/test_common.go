@@ -17,6 +17,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/bank"
 	"github.com/cosmos/cosmos-sdk/x/mock"
 	"github.com/cosmos/cosmos-sdk/x/stake"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
 )
 
 // initialize the mock application for this module
@@ -30,17 +31,22 @@ func getMockApp(t *testing.T, numGenAccs int) (*mock.App, Keeper, stake.Keeper,
 	keyStake := sdk.NewKVStoreKey("stake")
 	tkeyStake := sdk.NewTransientStoreKey("transient_stake")
 	keyGov := sdk.NewKVStoreKey("gov")
+	keyUpgrade := sdk.NewKVStoreKey("upgrade")
 
 	pk := params.NewKeeper(mapp.Cdc, keyGlobalParams)
 	ck := bank.NewBaseKeeper(mapp.AccountMapper)
 	sk := stake.NewKeeper(mapp.Cdc, keyStake, tkeyStake, ck, mapp.RegisterCodespace(stake.DefaultCodespace))
-	keeper := NewKeeper(mapp.Cdc, keyGov, pk.Setter(), ck, sk, DefaultCodespace)
+	paramRegistry := params.NewRegistry()
+	RegisterParams(paramRegistry)
+	stake.RegisterParams(paramRegistry, sk)
+	uk := upgrade.NewKeeper(mapp.Cdc, keyUpgrade, upgrade.DefaultCodespace)
+	keeper := NewKeeper(mapp.Cdc, keyGov, pk.Setter(), paramRegistry, uk, ck, sk, DefaultCodespace)
 	mapp.Router().AddRoute("gov", NewHandler(keeper))
 
 	mapp.SetEndBlocker(getEndBlocker(keeper))
 	mapp.SetInitChainer(getInitChainer(mapp, keeper, sk))
 
-	require.NoError(t, mapp.CompleteSetup(keyStake, keyGov, keyGlobalParams, tkeyStake))
+	require.NoError(t, mapp.CompleteSetup(keyStake, keyGov, keyGlobalParams, keyUpgrade, tkeyStake))
 
 	genAccs, addrs, pubKeys, privKeys := mock.CreateGenAccounts(numGenAccs, sdk.Coins{sdk.NewInt64Coin("steak", 42)})
 
@@ -68,12 +68,15 @@ func TestIBCMsgs(t *testing.T) {
 		IBCPacket: packet,
 		Relayer:   addr1,
 		Sequence:  0,
+		Height:    1,
+		Proof:     []byte("proof"),
 	}
 
 	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{transferMsg}, []int64{0}, []int64{0}, true, true, priv1)
 	mock.CheckBalance(t, mapp, addr1, emptyCoins)
 	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{transferMsg}, []int64{0}, []int64{1}, false, false, priv1)
-	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{receiveMsg}, []int64{0}, []int64{2}, true, true, priv1)
-	mock.CheckBalance(t, mapp, addr1, coins)
+
+	// packets are not received without a proof verified by the light client of the source chain
 	mock.SignCheckDeliver(t, mapp.BaseApp, []sdk.Msg{receiveMsg}, []int64{0}, []int64{2}, false, false, priv1)
+	mock.CheckBalance(t, mapp, addr1, emptyCoins)
 }
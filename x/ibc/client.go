@@ -0,0 +1,295 @@
+package ibc
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+// ------------------------------
+// Header
+
+// Header is a signed header of a counterparty chain, along with the validator
+// set which signed it.
+type Header struct {
+	SignedHeader tmtypes.SignedHeader  `json:"signed_header"`
+	Validators   *tmtypes.ValidatorSet `json:"validators"`
+}
+
+func NewHeader(signedHeader tmtypes.SignedHeader, validators *tmtypes.ValidatorSet) Header {
+	return Header{
+		SignedHeader: signedHeader,
+		Validators:   validators,
+	}
+}
+
+// nolint
+func (h Header) ChainID() string { return h.SignedHeader.Header.ChainID }
+func (h Header) Height() int64   { return h.SignedHeader.Header.Height }
+func (h Header) AppHash() []byte { return h.SignedHeader.Header.AppHash }
+func (h Header) Time() time.Time { return h.SignedHeader.Header.Time }
+
+// ValidateBasic checks that the header is well formed, that the validator set
+// matches the one committed to by the header and that the commit is for the
+// header. It does not verify the signatures of the commit.
+func (h Header) ValidateBasic() sdk.Error {
+	if h.SignedHeader.Header == nil || h.SignedHeader.Commit == nil || h.Validators == nil {
+		return ErrInvalidHeader(DefaultCodespace, "header, commit and validators must be given")
+	}
+	if len(h.ChainID()) == 0 {
+		return ErrInvalidHeader(DefaultCodespace, "chain-id cannot be empty")
+	}
+	if h.Height() <= 0 {
+		return ErrInvalidHeader(DefaultCodespace, "height must be positive")
+	}
+	if !bytes.Equal(h.SignedHeader.Header.ValidatorsHash, h.Validators.Hash()) {
+		return ErrInvalidHeader(DefaultCodespace, "validators do not match the validators hash of the header")
+	}
+	if !bytes.Equal(h.SignedHeader.Commit.BlockID.Hash, h.SignedHeader.Header.Hash()) {
+		return ErrInvalidHeader(DefaultCodespace, "commit is not for the header")
+	}
+	return nil
+}
+
+// verify that the commit of the header is signed by more than 2/3 of its validators
+func (h Header) verifyCommit() error {
+	commit := h.SignedHeader.Commit
+	return h.Validators.VerifyCommit(h.ChainID(), commit.BlockID, h.Height(), commit)
+}
+
+// ------------------------------
+// ConsensusState
+
+// ConsensusState is the state of the light client of a counterparty chain,
+// it holds the latest verified height and time of the chain and the validator
+// set which is trusted to sign the following headers until the trusting period
+// elapses.
+type ConsensusState struct {
+	ChainID        string                `json:"chain_id"`
+	Height         int64                 `json:"height"`
+	Time           time.Time             `json:"time"`
+	AppHash        []byte                `json:"app_hash"`
+	Validators     *tmtypes.ValidatorSet `json:"validators"`
+	TrustingPeriod time.Duration         `json:"trusting_period"`
+}
+
+func NewConsensusState(header Header, trustingPeriod time.Duration) ConsensusState {
+	return ConsensusState{
+		ChainID:        header.ChainID(),
+		Height:         header.Height(),
+		Time:           header.Time(),
+		AppHash:        header.AppHash(),
+		Validators:     header.Validators,
+		TrustingPeriod: trustingPeriod,
+	}
+}
+
+// ValidateBasic checks that the trusted consensus state of a light client is
+// well formed.
+func (cs ConsensusState) ValidateBasic() sdk.Error {
+	if len(cs.ChainID) == 0 {
+		return ErrInvalidConsensusState(DefaultCodespace, "chain-id cannot be empty")
+	}
+	if cs.Height <= 0 {
+		return ErrInvalidConsensusState(DefaultCodespace, "height must be positive")
+	}
+	if cs.Validators == nil || cs.Validators.Size() == 0 {
+		return ErrInvalidConsensusState(DefaultCodespace, "validators cannot be empty")
+	}
+	if cs.TrustingPeriod <= 0 {
+		return ErrInvalidConsensusState(DefaultCodespace, "trusting period must be positive")
+	}
+	return nil
+}
+
+// Expired returns whether the trusting period of the validators elapsed since
+// the latest verified header, after which the light client cannot be updated.
+func (cs ConsensusState) Expired(now time.Time) bool {
+	return !now.Before(cs.Time.Add(cs.TrustingPeriod))
+}
+
+// CheckHeader verifies that a header of the chain can be trusted based on the
+// consensus state. If the validator set changed since the last verified height,
+// more than 2/3 of the trusted validators must have signed the header as well.
+func (cs ConsensusState) CheckHeader(header Header) error {
+	if header.ChainID() != cs.ChainID {
+		return fmt.Errorf("header chain-id %s does not match chain-id %s", header.ChainID(), cs.ChainID)
+	}
+	if header.Height() <= cs.Height {
+		return fmt.Errorf("header height %d is not above the latest verified height %d", header.Height(), cs.Height)
+	}
+	if !header.Time().After(cs.Time) {
+		return fmt.Errorf("header time %v is not after the latest verified time %v", header.Time(), cs.Time)
+	}
+
+	commit := header.SignedHeader.Commit
+	if bytes.Equal(cs.Validators.Hash(), header.Validators.Hash()) {
+		return header.verifyCommit()
+	}
+	return cs.Validators.VerifyCommitAny(header.Validators, cs.ChainID, commit.BlockID, header.Height(), commit)
+}
+
+// ------------------------------
+// Client registry
+
+// CreateClient registers the light client of a counterparty chain, trusting
+// the validators of the given consensus state. As nothing proves that the
+// validators are those of the chain, clients are only created from the
+// genesis state, never by a msg.
+func (ibcm Mapper) CreateClient(ctx sdk.Context, cs ConsensusState) sdk.Error {
+	if _, found := ibcm.GetConsensusState(ctx, cs.ChainID); found {
+		return ErrClientExists(ibcm.codespace, cs.ChainID)
+	}
+	if err := cs.ValidateBasic(); err != nil {
+		return err
+	}
+
+	ibcm.setConsensusState(ctx, cs)
+	ibcm.setAppHash(ctx, cs.ChainID, cs.Height, cs.AppHash)
+	return nil
+}
+
+// UpdateClient verifies a new header of a counterparty chain against its light
+// client and records the app hash committed to by the header. Expired clients
+// can no longer be updated.
+func (ibcm Mapper) UpdateClient(ctx sdk.Context, header Header) sdk.Error {
+	chainID := header.ChainID()
+	cs, found := ibcm.GetConsensusState(ctx, chainID)
+	if !found {
+		return ErrClientNotFound(ibcm.codespace, chainID)
+	}
+	if cs.Expired(ctx.BlockHeader().Time) {
+		return ErrClientExpired(ibcm.codespace, chainID)
+	}
+	if err := cs.CheckHeader(header); err != nil {
+		return ErrInvalidHeader(ibcm.codespace, err.Error())
+	}
+
+	ibcm.setConsensusState(ctx, NewConsensusState(header, cs.TrustingPeriod))
+	ibcm.setAppHash(ctx, chainID, header.Height(), header.AppHash())
+	return nil
+}
+
+// GetConsensusState returns the light client state of a counterparty chain
+func (ibcm Mapper) GetConsensusState(ctx sdk.Context, chainID string) (cs ConsensusState, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(ClientKey(chainID))
+	if bz == nil {
+		return cs, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &cs)
+	return cs, true
+}
+
+func (ibcm Mapper) setConsensusState(ctx sdk.Context, cs ConsensusState) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(ClientKey(cs.ChainID), marshalBinaryPanic(ibcm.cdc, cs))
+}
+
+// IterateConsensusStates iterates over the light client states until the
+// function returns true
+func (ibcm Mapper) IterateConsensusStates(ctx sdk.Context, fn func(cs ConsensusState) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(ibcm.key), ClientKeyPrefix)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		var cs ConsensusState
+		unmarshalBinaryPanic(ibcm.cdc, iterator.Value(), &cs)
+		if fn(cs) {
+			break
+		}
+	}
+}
+
+// GetAppHash returns the verified app hash of a counterparty chain committed to
+// by its header at the given height
+func (ibcm Mapper) GetAppHash(ctx sdk.Context, chainID string, height int64) (appHash []byte, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(AppHashKey(chainID, height))
+	if bz == nil {
+		return nil, false
+	}
+	return bz, true
+}
+
+// an empty app hash cannot prove any packet, so it is not recorded
+func (ibcm Mapper) setAppHash(ctx sdk.Context, chainID string, height int64, appHash []byte) {
+	if len(appHash) == 0 {
+		return
+	}
+	store := ctx.KVStore(ibcm.key)
+	store.Set(AppHashKey(chainID, height), appHash)
+}
+
+// ------------------------------
+// Packet proofs
+
+// VerifyIBCPacket verifies the Merkle proof that the packet of the receive
+// message was posted to the egress queue of its source chain, against the app
+// hash of the source chain verified by its light client at the given height.
+func (ibcm Mapper) VerifyIBCPacket(ctx sdk.Context, msg IBCReceiveMsg) sdk.Error {
+	packet := msg.IBCPacket
+	if packet.DestChain != ctx.ChainID() {
+		return ErrWrongDestChain(ibcm.codespace)
+	}
+
+	key := EgressKey(packet.DestChain, msg.Sequence)
+	value := marshalBinaryPanic(ibcm.cdc, packet)
+	return ibcm.verifyProof(ctx, packet.SrcChain, msg.Height, msg.Proof, key, value)
+}
+
+// VerifyTimeout verifies the Merkle proof that the packet of the timeout message
+// was recorded as timed out by its destination chain, against the app hash of
+// the destination chain verified by its light client at the given height.
+func (ibcm Mapper) VerifyTimeout(ctx sdk.Context, msg TimeoutMsg) sdk.Error {
+	packet := msg.IBCPacket
+	key := TimeoutReceiptKey(packet.SrcChain, msg.Sequence)
+	value := marshalBinaryPanic(ibcm.cdc, packet)
+	return ibcm.verifyProof(ctx, packet.DestChain, msg.Height, msg.Proof, key, value)
+}
+
+// verify the Merkle proof of the value stored under the key against the app
+// hash of the chain at the given height. The chain is expected to keep its IBC
+// state in a store of the same name as the IBC store of this chain.
+func (ibcm Mapper) verifyProof(ctx sdk.Context, chainID string, height int64, proofBytes, key, value []byte) sdk.Error {
+	appHash, found := ibcm.GetAppHash(ctx, chainID, height)
+	if !found {
+		return ErrInvalidProof(ibcm.codespace,
+			fmt.Sprintf("no verified app hash of chain %s at height %d", chainID, height))
+	}
+
+	var proof store.MultiStoreProof
+	if err := ibcm.cdc.UnmarshalBinary(proofBytes, &proof); err != nil {
+		return ErrInvalidProof(ibcm.codespace, err.Error())
+	}
+	if proof.StoreName != ibcm.key.Name() {
+		return ErrInvalidProof(ibcm.codespace, fmt.Sprintf("proof is for store %s", proof.StoreName))
+	}
+
+	substoreCommitHash, err := store.VerifyMultiStoreCommitInfo(proof.StoreName, proof.StoreInfos, appHash)
+	if err != nil {
+		return ErrInvalidProof(ibcm.codespace, err.Error())
+	}
+
+	err = store.VerifyRangeProof(key, value, substoreCommitHash, &proof.RangeProof)
+	if err != nil {
+		return ErrInvalidProof(ibcm.codespace, err.Error())
+	}
+	return nil
+}
+
+// Prefix of the light client states, "client/".
+var ClientKeyPrefix = []byte("client/")
+
+// Stores the light client state of a counterparty chain under "client/chain_id".
+func ClientKey(chainID string) []byte {
+	return []byte(fmt.Sprintf("client/%s", chainID))
+}
+
+// Stores the verified app hashes of a counterparty chain under "apphash/chain_id/height".
+func AppHashKey(chainID string, height int64) []byte {
+	return []byte(fmt.Sprintf("apphash/%s/%d", chainID, height))
+}
@@ -18,9 +18,10 @@ import (
 )
 
 const (
-	flagTo     = "to"
-	flagAmount = "amount"
-	flagChain  = "chain"
+	flagTo      = "to"
+	flagAmount  = "amount"
+	flagChain   = "chain"
+	flagTimeout = "timeout"
 )
 
 // IBCTransferCmd implements the IBC transfer command.
@@ -54,6 +55,7 @@ func IBCTransferCmd(cdc *codec.Codec) *cobra.Command {
 	cmd.Flags().String(flagTo, "", "Address to send coins")
 	cmd.Flags().String(flagAmount, "", "Amount of coins to send")
 	cmd.Flags().String(flagChain, "", "Destination chain to send coins")
+	cmd.Flags().Int64(flagTimeout, 0, "Destination chain height after which the coins are refunded if not received, 0 to never time out")
 
 	return cmd
 }
@@ -73,7 +75,7 @@ func buildMsg(from sdk.AccAddress) (sdk.Msg, error) {
 	to := sdk.AccAddress(bz)
 
 	packet := ibc.NewIBCPacket(from, to, coins, viper.GetString(client.FlagChainID),
-		viper.GetString(flagChain))
+		viper.GetString(flagChain), viper.GetInt64(flagTimeout))
 
 	msg := ibc.IBCTransferMsg{
 		IBCPacket: packet,
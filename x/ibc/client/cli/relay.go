@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
@@ -17,6 +19,8 @@ import (
 	"github.com/spf13/viper"
 
 	"github.com/tendermint/tendermint/libs/log"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+	tmtypes "github.com/tendermint/tendermint/types"
 )
 
 // flags
@@ -121,8 +125,10 @@ OUTER:
 			panic(err)
 		}
 
+		// packets are proven against the state of the source chain at the queried
+		// height, whose app hash is committed to by the header of the next block
 		lengthKey := ibc.EgressLengthKey(toChainID)
-		egressLengthbz, err := query(fromChainNode, lengthKey, c.ibcStore)
+		egressLengthbz, _, height, err := queryWithProof(fromChainNode, lengthKey, c.ibcStore, 0)
 		if err != nil {
 			c.logger.Error("error querying outgoing packet list length", "err", err)
 			continue OUTER //TODO replace with continue (I think it should just to the correct place where OUTER is now)
@@ -135,20 +141,56 @@ OUTER:
 			panic(err)
 		}
 
+		// packets of the destination chain which timed out on the source chain
+		// and have not been refunded yet
+		timeouts, err := c.pendingTimeouts(fromChainNode, toChainNode, fromChainID, toChainID)
+		if err != nil {
+			c.logger.Error("error querying timed out packets", "err", err)
+			continue OUTER
+		}
+
+		if egressLength <= processed && len(timeouts) == 0 {
+			continue
+		}
 		if egressLength > processed {
 			c.logger.Info("Detected IBC packet", "number", egressLength-1)
 		}
 
 		seq := c.getSequence(toChainNode)
 
+		header, err := getHeader(fromChainNode, height+1)
+		if err != nil {
+			c.logger.Error("error querying source chain header", "err", err)
+			continue OUTER
+		}
+
+		clientMsg, err := c.clientMsg(toChainNode, fromChainID, header)
+		if err != nil {
+			c.logger.Error("error querying light client", "err", err)
+			continue OUTER
+		}
+
+		if clientMsg != nil {
+			err = c.broadcastTx(seq, toChainNode, c.refine(clientMsg, seq, passphrase))
+			seq++
+
+			if err != nil {
+				c.logger.Error("error broadcasting light client header", "err", err)
+				continue OUTER
+			}
+
+			c.logger.Info("Updated light client", "height", header.Height())
+		}
+
 		for i := processed; i < egressLength; i++ {
-			egressbz, err := query(fromChainNode, ibc.EgressKey(toChainID, i), c.ibcStore)
+			egressbz, proof, _, err := queryWithProof(fromChainNode, ibc.EgressKey(toChainID, i), c.ibcStore, height)
 			if err != nil {
 				c.logger.Error("error querying egress packet", "err", err)
 				continue OUTER // TODO replace to break, will break first loop then send back to the beginning (aka OUTER)
 			}
 
-			err = c.broadcastTx(seq, toChainNode, c.refine(egressbz, i, passphrase))
+			msg := c.receiveMsg(egressbz, i, header.Height(), proof)
+			err = c.broadcastTx(seq, toChainNode, c.refine(msg, seq, passphrase))
 
 			seq++
 
@@ -159,13 +201,128 @@ OUTER:
 
 			c.logger.Info("Relayed IBC packet", "number", i)
 		}
+
+		for _, i := range timeouts {
+			receiptbz, proof, _, err := queryWithProof(fromChainNode, ibc.TimeoutReceiptKey(toChainID, i), c.ibcStore, height)
+			if err != nil {
+				c.logger.Error("error querying timeout receipt", "err", err)
+				continue OUTER
+			}
+			if receiptbz == nil {
+				// recorded after the proven height, refunded in the next round
+				continue
+			}
+
+			msg := c.timeoutMsg(receiptbz, i, header.Height(), proof)
+			err = c.broadcastTx(seq, toChainNode, c.refine(msg, seq, passphrase))
+
+			seq++
+
+			if err != nil {
+				c.logger.Error("error broadcasting timeout", "err", err)
+				continue OUTER
+			}
+
+			c.logger.Info("Refunded timed out IBC packet", "number", i)
+		}
+	}
+}
+
+// returns the indices of the packets sent by the destination chain which timed
+// out on the source chain and are still awaiting their refund
+func (c relayCommander) pendingTimeouts(fromChainNode, toChainNode, fromChainID, toChainID string) ([]int64, error) {
+	prefix := ibc.TimeoutReceiptPrefix(toChainID)
+	receipts, err := context.NewCLIContext().WithNodeURI(fromChainNode).QuerySubspace(prefix, c.ibcStore)
+	if err != nil {
+		return nil, err
+	}
+
+	var timeouts []int64
+	for _, receipt := range receipts {
+		i, err := strconv.ParseInt(string(receipt.Key[len(prefix):]), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		// refunded packets are removed from the egress queue
+		egressbz, err := query(toChainNode, ibc.EgressKey(fromChainID, i), c.ibcStore)
+		if err != nil {
+			return nil, err
+		}
+		if egressbz != nil {
+			timeouts = append(timeouts, i)
+		}
 	}
+	return timeouts, nil
 }
 
 func query(node string, key []byte, storeName string) (res []byte, err error) {
 	return context.NewCLIContext().WithNodeURI(node).QueryStore(key, storeName)
 }
 
+// queries the value stored under the key along with its Merkle proof, at the
+// given height or at the latest provable height if it is zero
+func queryWithProof(node string, key []byte, storeName string, height int64) (res []byte, proof []byte, proofHeight int64, err error) {
+	client := rpcclient.NewHTTP(node, "/websocket")
+	opts := rpcclient.ABCIQueryOptions{
+		Height:  height,
+		Trusted: false,
+	}
+
+	result, err := client.ABCIQueryWithOptions(fmt.Sprintf("/store/%s/key", storeName), key, opts)
+	if err != nil {
+		return nil, nil, 0, err
+	}
+
+	resp := result.Response
+	if !resp.IsOK() {
+		return nil, nil, 0, fmt.Errorf("query failed: (%d) %s", resp.Code, resp.Log)
+	}
+	return resp.Value, resp.Proof, resp.Height, nil
+}
+
+// gets the header of the chain at the given height along with the validators which signed it
+func getHeader(node string, height int64) (ibc.Header, error) {
+	client := rpcclient.NewHTTP(node, "/websocket")
+
+	commit, err := client.Commit(&height)
+	if err != nil {
+		return ibc.Header{}, err
+	}
+
+	validators, err := client.Validators(&height)
+	if err != nil {
+		return ibc.Header{}, err
+	}
+
+	return ibc.NewHeader(commit.SignedHeader, tmtypes.NewValidatorSet(validators.Validators)), nil
+}
+
+// returns the message updating the light client of the source chain on the
+// destination chain with the header, or nil if the light client already
+// verified the header height. The relayer does not create light clients, which
+// are only created from the genesis state of the destination chain.
+func (c relayCommander) clientMsg(toChainNode, fromChainID string, header ibc.Header) (sdk.Msg, error) {
+	bz, err := query(toChainNode, ibc.ClientKey(fromChainID), c.ibcStore)
+	if err != nil {
+		return nil, err
+	}
+
+	if bz == nil {
+		return nil, fmt.Errorf("no light client of chain %s on the destination chain", fromChainID)
+	}
+
+	var cs ibc.ConsensusState
+	if err = c.cdc.UnmarshalBinary(bz, &cs); err != nil {
+		return nil, err
+	}
+
+	if cs.Height < header.Height() {
+		return ibc.NewUpdateClientMsg(header, c.address), nil
+	}
+	return nil, nil
+}
+
 // nolint: unparam
 func (c relayCommander) broadcastTx(seq int64, node string, tx []byte) error {
 	_, err := context.NewCLIContext().WithNodeURI(node).BroadcastTx(tx)
@@ -190,18 +347,37 @@ func (c relayCommander) getSequence(node string) int64 {
 	return 0
 }
 
-func (c relayCommander) refine(bz []byte, sequence int64, passphrase string) []byte {
+func (c relayCommander) receiveMsg(bz []byte, sequence int64, height int64, proof []byte) ibc.IBCReceiveMsg {
+	var packet ibc.IBCPacket
+	if err := c.cdc.UnmarshalBinary(bz, &packet); err != nil {
+		panic(err)
+	}
+
+	return ibc.IBCReceiveMsg{
+		IBCPacket: packet,
+		Relayer:   c.address,
+		Sequence:  sequence,
+		Height:    height,
+		Proof:     proof,
+	}
+}
+
+func (c relayCommander) timeoutMsg(bz []byte, sequence int64, height int64, proof []byte) ibc.TimeoutMsg {
 	var packet ibc.IBCPacket
 	if err := c.cdc.UnmarshalBinary(bz, &packet); err != nil {
 		panic(err)
 	}
 
-	msg := ibc.IBCReceiveMsg{
+	return ibc.TimeoutMsg{
 		IBCPacket: packet,
 		Relayer:   c.address,
 		Sequence:  sequence,
+		Height:    height,
+		Proof:     proof,
 	}
+}
 
+func (c relayCommander) refine(msg sdk.Msg, sequence int64, passphrase string) []byte {
 	txBldr := authtxb.NewTxBuilderFromCLI().WithSequence(sequence).WithCodec(c.cdc)
 	cliCtx := context.NewCLIContext()
 
@@ -27,6 +27,7 @@ type transferBody struct {
 	LocalAccountName string    `json:"name"`
 	Password         string    `json:"password"`
 	SrcChainID       string    `json:"src_chain_id"`
+	Timeout          int64     `json:"timeout"`
 	AccountNumber    int64     `json:"account_number"`
 	Sequence         int64     `json:"sequence"`
 	Gas              string    `json:"gas"`
@@ -67,7 +68,7 @@ func TransferRequestHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx context.
 		}
 
 		// build message
-		packet := ibc.NewIBCPacket(sdk.AccAddress(info.GetPubKey().Address()), to, m.Amount, m.SrcChainID, destChainID)
+		packet := ibc.NewIBCPacket(sdk.AccAddress(info.GetPubKey().Address()), to, m.Amount, m.SrcChainID, destChainID, m.Timeout)
 		msg := ibc.IBCTransferMsg{packet}
 
 		simulateGas, gas, err := client.ReadGasFlag(m.Gas)
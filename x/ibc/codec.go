@@ -8,4 +8,6 @@ import (
 func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(IBCTransferMsg{}, "cosmos-sdk/IBCTransferMsg", nil)
 	cdc.RegisterConcrete(IBCReceiveMsg{}, "cosmos-sdk/IBCReceiveMsg", nil)
+	cdc.RegisterConcrete(TimeoutMsg{}, "cosmos-sdk/TimeoutMsg", nil)
+	cdc.RegisterConcrete(UpdateClientMsg{}, "cosmos-sdk/UpdateClientMsg", nil)
 }
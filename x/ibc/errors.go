@@ -1,6 +1,8 @@
 package ibc
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -11,6 +13,15 @@ const (
 	// IBC errors reserve 200 - 299.
 	CodeInvalidSequence sdk.CodeType = 200
 	CodeIdenticalChains sdk.CodeType = 201
+	CodeClientExists    sdk.CodeType = 202
+	CodeClientNotFound  sdk.CodeType = 203
+	CodeInvalidHeader   sdk.CodeType = 204
+	CodeInvalidProof    sdk.CodeType = 205
+	CodeWrongDestChain  sdk.CodeType = 206
+	CodeInvalidTimeout  sdk.CodeType = 207
+	CodePacketNotFound  sdk.CodeType = 208
+	CodeClientExpired   sdk.CodeType = 209
+	CodeInvalidClient   sdk.CodeType = 210
 	CodeUnknownRequest  sdk.CodeType = sdk.CodeUnknownRequest
 )
 
@@ -20,6 +31,24 @@ func codeToDefaultMsg(code sdk.CodeType) string {
 		return "invalid IBC packet sequence"
 	case CodeIdenticalChains:
 		return "source and destination chain cannot be identical"
+	case CodeClientExists:
+		return "light client of the chain already exists"
+	case CodeClientNotFound:
+		return "light client of the chain not found"
+	case CodeInvalidHeader:
+		return "invalid header"
+	case CodeInvalidProof:
+		return "invalid IBC packet proof"
+	case CodeWrongDestChain:
+		return "IBC packet is not destined for this chain"
+	case CodeInvalidTimeout:
+		return "invalid IBC packet timeout"
+	case CodePacketNotFound:
+		return "IBC packet not found in the egress queue"
+	case CodeClientExpired:
+		return "light client of the chain expired"
+	case CodeInvalidClient:
+		return "invalid light client consensus state"
 	default:
 		return sdk.CodeToDefaultMsg(code)
 	}
@@ -32,6 +61,33 @@ func ErrInvalidSequence(codespace sdk.CodespaceType) sdk.Error {
 func ErrIdenticalChains(codespace sdk.CodespaceType) sdk.Error {
 	return newError(codespace, CodeIdenticalChains, "")
 }
+func ErrClientExists(codespace sdk.CodespaceType, chainID string) sdk.Error {
+	return newError(codespace, CodeClientExists, fmt.Sprintf("light client of chain %s already exists", chainID))
+}
+func ErrClientNotFound(codespace sdk.CodespaceType, chainID string) sdk.Error {
+	return newError(codespace, CodeClientNotFound, fmt.Sprintf("light client of chain %s not found", chainID))
+}
+func ErrInvalidHeader(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidHeader, msg)
+}
+func ErrInvalidProof(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidProof, msg)
+}
+func ErrWrongDestChain(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeWrongDestChain, "")
+}
+func ErrInvalidTimeout(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodeInvalidTimeout, "")
+}
+func ErrPacketNotFound(codespace sdk.CodespaceType) sdk.Error {
+	return newError(codespace, CodePacketNotFound, "")
+}
+func ErrClientExpired(codespace sdk.CodespaceType, chainID string) sdk.Error {
+	return newError(codespace, CodeClientExpired, fmt.Sprintf("trusting period of the light client of chain %s elapsed", chainID))
+}
+func ErrInvalidConsensusState(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidClient, msg)
+}
 
 // -------------------------
 // Helpers
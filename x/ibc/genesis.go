@@ -0,0 +1,53 @@
+package ibc
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - the light clients of the counterparty chains, trusting the
+// validator sets they hold
+type GenesisState struct {
+	Clients []ConsensusState `json:"clients"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// InitGenesis - create the light clients
+func InitGenesis(ctx sdk.Context, ibcm Mapper, data GenesisState) error {
+	for _, cs := range data.Clients {
+		if err := ibcm.CreateClient(ctx, cs); err != nil {
+			return fmt.Errorf("invalid light client of chain %s: %v", cs.ChainID, err.Result().Log)
+		}
+	}
+	return nil
+}
+
+// WriteGenesis - output the light clients
+func WriteGenesis(ctx sdk.Context, ibcm Mapper) GenesisState {
+	var clients []ConsensusState
+	ibcm.IterateConsensusStates(ctx, func(cs ConsensusState) bool {
+		clients = append(clients, cs)
+		return false
+	})
+	return GenesisState{clients}
+}
+
+// ValidateGenesis checks the light clients
+func ValidateGenesis(data GenesisState) error {
+	chainIDs := make(map[string]bool, len(data.Clients))
+	for _, cs := range data.Clients {
+		if chainIDs[cs.ChainID] {
+			return fmt.Errorf("duplicate light client of chain %s", cs.ChainID)
+		}
+		chainIDs[cs.ChainID] = true
+		if err := cs.ValidateBasic(); err != nil {
+			return fmt.Errorf("invalid light client of chain %s: %v", cs.ChainID, err.Result().Log)
+		}
+	}
+	return nil
+}
@@ -1,6 +1,7 @@
 package ibc
 
 import (
+	"bytes"
 	"reflect"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -14,6 +15,10 @@ func NewHandler(ibcm Mapper, ck bank.Keeper) sdk.Handler {
 			return handleIBCTransferMsg(ctx, ibcm, ck, msg)
 		case IBCReceiveMsg:
 			return handleIBCReceiveMsg(ctx, ibcm, ck, msg)
+		case TimeoutMsg:
+			return handleTimeoutMsg(ctx, ibcm, ck, msg)
+		case UpdateClientMsg:
+			return handleUpdateClientMsg(ctx, ibcm, msg)
 		default:
 			errMsg := "Unrecognized IBC Msg type: " + reflect.TypeOf(msg).Name()
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -21,11 +26,11 @@ func NewHandler(ibcm Mapper, ck bank.Keeper) sdk.Handler {
 	}
 }
 
-// IBCTransferMsg deducts coins from the account and creates an egress IBC packet.
+// IBCTransferMsg escrows or burns the coins of the account and creates an egress IBC packet.
 func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCTransferMsg) sdk.Result {
 	packet := msg.IBCPacket
 
-	_, _, err := ck.SubtractCoins(ctx, packet.SrcAddr, packet.Coins)
+	err := sendPacketCoins(ctx, ck, packet)
 	if err != nil {
 		return err.Result()
 	}
@@ -38,7 +43,11 @@ func handleIBCTransferMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCT
 	return sdk.Result{}
 }
 
-// IBCReceiveMsg adds coins to the destination address and creates an ingress IBC packet.
+// IBCReceiveMsg verifies the proof of the packet, releases or mints the coins
+// to the destination address and creates an ingress IBC packet. If the packet
+// timed out or its coins cannot be received, a timeout receipt is recorded
+// instead so that it can be refunded, and the following packets can still be
+// received.
 func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCReceiveMsg) sdk.Result {
 	packet := msg.IBCPacket
 
@@ -47,12 +56,61 @@ func handleIBCReceiveMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg IBCRe
 		return ErrInvalidSequence(ibcm.codespace).Result()
 	}
 
-	_, _, err := ck.AddCoins(ctx, packet.DestAddr, packet.Coins)
+	err := ibcm.VerifyIBCPacket(ctx, msg)
 	if err != nil {
 		return err.Result()
 	}
 
+	var result sdk.Result
+	if packet.TimedOut(ctx.BlockHeight()) {
+		ibcm.setTimeoutReceipt(ctx, packet, seq)
+	} else {
+		cacheCtx, write := ctx.CacheContext()
+		err = receivePacketCoins(cacheCtx, ck, packet)
+		if err != nil {
+			ibcm.setTimeoutReceipt(ctx, packet, seq)
+			result.Log = err.ABCILog()
+		} else {
+			write()
+		}
+	}
+
 	ibcm.SetIngressSequence(ctx, packet.SrcChain, seq+1)
 
+	return result
+}
+
+// TimeoutMsg verifies the proof that the packet timed out on the destination
+// chain and refunds its coins to the sender.
+func handleTimeoutMsg(ctx sdk.Context, ibcm Mapper, ck bank.Keeper, msg TimeoutMsg) sdk.Result {
+	packet := msg.IBCPacket
+
+	egress, found := ibcm.GetIBCPacket(ctx, packet.DestChain, msg.Sequence)
+	if !found || !bytes.Equal(ibcm.cdc.MustMarshalBinary(egress), ibcm.cdc.MustMarshalBinary(packet)) {
+		return ErrPacketNotFound(ibcm.codespace).Result()
+	}
+
+	err := ibcm.VerifyTimeout(ctx, msg)
+	if err != nil {
+		return err.Result()
+	}
+
+	err = refundPacketCoins(ctx, ck, packet)
+	if err != nil {
+		return err.Result()
+	}
+
+	ibcm.deleteIBCPacket(ctx, packet.DestChain, msg.Sequence)
+
+	return sdk.Result{}
+}
+
+// UpdateClientMsg updates the light client of a counterparty chain with a new header.
+func handleUpdateClientMsg(ctx sdk.Context, ibcm Mapper, msg UpdateClientMsg) sdk.Result {
+	err := ibcm.UpdateClient(ctx, msg.Header)
+	if err != nil {
+		return err.Result()
+	}
+
 	return sdk.Result{}
 }
@@ -1,14 +1,18 @@
 package ibc
 
 import (
+	"fmt"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto"
 	"github.com/tendermint/tendermint/crypto/ed25519"
 	dbm "github.com/tendermint/tendermint/libs/db"
 	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	"github.com/cosmos/cosmos-sdk/store"
@@ -19,13 +23,70 @@ import (
 
 // AccountMapper(/Keeper) and IBCMapper should use different StoreKey later
 
-func defaultContext(key sdk.StoreKey) sdk.Context {
+func defaultContext(key sdk.StoreKey, chainID string) (sdk.Context, store.CommitMultiStore) {
 	db := dbm.NewMemDB()
 	cms := store.NewCommitMultiStore(db)
 	cms.MountStoreWithDB(key, sdk.StoreTypeIAVL, db)
 	cms.LoadLatestVersion()
-	ctx := sdk.NewContext(cms, abci.Header{}, false, log.NewNopLogger())
-	return ctx
+	ctx := sdk.NewContext(cms, abci.Header{ChainID: chainID}, false, log.NewNopLogger())
+	return ctx, cms
+}
+
+// query the value stored under the key along with its proof against the last commit
+func queryWithProof(t *testing.T, cms store.CommitMultiStore, key sdk.StoreKey, k []byte) (value []byte, proof []byte) {
+	res := cms.(store.Queryable).Query(abci.RequestQuery{
+		Path:  fmt.Sprintf("/%s/key", key.Name()),
+		Data:  k,
+		Prove: true,
+	})
+	require.True(t, res.IsOK(), res.Log)
+	return res.Value, res.Proof
+}
+
+// make a header of the chain signed by all the given validators
+func makeHeader(t *testing.T, chainID string, height int64, appHash []byte, privs []crypto.PrivKey) Header {
+	vals := make([]*tmtypes.Validator, len(privs))
+	for i, priv := range privs {
+		vals[i] = tmtypes.NewValidator(priv.PubKey(), 10)
+	}
+	valset := tmtypes.NewValidatorSet(vals)
+
+	header := &tmtypes.Header{
+		ChainID:        chainID,
+		Height:         height,
+		Time:           time.Now().UTC(),
+		ValidatorsHash: valset.Hash(),
+		AppHash:        appHash,
+	}
+	blockID := tmtypes.BlockID{Hash: header.Hash()}
+
+	precommits := make([]*tmtypes.Vote, len(privs))
+	for _, priv := range privs {
+		idx, val := valset.GetByAddress(priv.PubKey().Address())
+		vote := &tmtypes.Vote{
+			ValidatorAddress: val.Address,
+			ValidatorIndex:   idx,
+			Height:           height,
+			Timestamp:        time.Now().UTC(),
+			Type:             tmtypes.VoteTypePrecommit,
+			BlockID:          blockID,
+		}
+		sig, err := priv.Sign(vote.SignBytes(chainID))
+		require.Nil(t, err)
+		vote.Signature = sig
+		precommits[idx] = vote
+	}
+
+	commit := &tmtypes.Commit{BlockID: blockID, Precommits: precommits}
+	return NewHeader(tmtypes.SignedHeader{Header: header, Commit: commit}, valset)
+}
+
+func makePrivs(n int) []crypto.PrivKey {
+	privs := make([]crypto.PrivKey, n)
+	for i := range privs {
+		privs[i] = ed25519.GenPrivKey()
+	}
+	return privs
 }
 
 func newAddress() sdk.AccAddress {
@@ -47,6 +108,7 @@ func makeCodec() *codec.Codec {
 	cdc.RegisterConcrete(bank.MsgIssue{}, "test/ibc/Issue", nil)
 	cdc.RegisterConcrete(IBCTransferMsg{}, "test/ibc/IBCTransferMsg", nil)
 	cdc.RegisterConcrete(IBCReceiveMsg{}, "test/ibc/IBCReceiveMsg", nil)
+	cdc.RegisterConcrete(UpdateClientMsg{}, "test/ibc/UpdateClientMsg", nil)
 
 	// Register AppAccount
 	cdc.RegisterInterface((*auth.Account)(nil), nil)
@@ -62,75 +124,307 @@ func TestIBC(t *testing.T) {
 	cdc := makeCodec()
 
 	key := sdk.NewKVStoreKey("ibc")
-	ctx := defaultContext(key)
+	srcChain := "source-chain"
+	destChain := "dest-chain"
+	srcCtx, srcCms := defaultContext(key, srcChain)
+	destCtx, _ := defaultContext(key, destChain)
 
-	am := auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount)
-	ck := bank.NewBaseKeeper(am)
+	srcCk := bank.NewBaseKeeper(auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount))
+	destCk := bank.NewBaseKeeper(auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount))
 
 	src := newAddress()
 	dest := newAddress()
-	chainid := "ibcchain"
 	zero := sdk.Coins(nil)
 	mycoins := sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}
 
-	coins, _, err := ck.AddCoins(ctx, src, mycoins)
+	coins, _, err := srcCk.AddCoins(srcCtx, src, mycoins)
 	require.Nil(t, err)
 	require.Equal(t, mycoins, coins)
 
 	ibcm := NewMapper(cdc, key, DefaultCodespace)
-	h := NewHandler(ibcm, ck)
+	srcHandler := NewHandler(ibcm, srcCk)
+	destHandler := NewHandler(ibcm, destCk)
 	packet := IBCPacket{
 		SrcAddr:   src,
 		DestAddr:  dest,
 		Coins:     mycoins,
-		SrcChain:  chainid,
-		DestChain: chainid,
+		SrcChain:  srcChain,
+		DestChain: destChain,
 	}
 
-	store := ctx.KVStore(key)
-
 	var msg sdk.Msg
 	var res sdk.Result
 	var egl int64
 	var igs int64
 
-	egl = ibcm.getEgressLength(store, chainid)
+	egl = ibcm.getEgressLength(srcCtx.KVStore(key), destChain)
 	require.Equal(t, egl, int64(0))
 
 	msg = IBCTransferMsg{
 		IBCPacket: packet,
 	}
-	res = h(ctx, msg)
+	res = srcHandler(srcCtx, msg)
 	require.True(t, res.IsOK())
 
-	coins, err = getCoins(ck, ctx, src)
+	coins, err = getCoins(srcCk, srcCtx, src)
 	require.Nil(t, err)
 	require.Equal(t, zero, coins)
+	coins, err = getCoins(srcCk, srcCtx, EscrowAddress(destChain))
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
 
-	egl = ibcm.getEgressLength(store, chainid)
+	egl = ibcm.getEgressLength(srcCtx.KVStore(key), destChain)
 	require.Equal(t, egl, int64(1))
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	// commit the source chain and prove its egress queue
+	commitID := srcCms.Commit()
+	egressbz, proof := queryWithProof(t, srcCms, key, EgressKey(destChain, 0))
+	require.Equal(t, cdc.MustMarshalBinary(packet), egressbz)
+
+	igs = ibcm.GetIngressSequence(destCtx, srcChain)
 	require.Equal(t, igs, int64(0))
 
-	msg = IBCReceiveMsg{
+	// packets cannot be received before the light client of the source chain exists
+	receiveMsg := IBCReceiveMsg{
 		IBCPacket: packet,
 		Relayer:   src,
 		Sequence:  0,
+		Height:    commitID.Version + 1,
+		Proof:     proof,
 	}
-	res = h(ctx, msg)
-	require.True(t, res.IsOK())
+	res = destHandler(destCtx, receiveMsg)
+	require.False(t, res.IsOK())
+
+	privs := makePrivs(3)
+	cs := NewConsensusState(makeHeader(t, srcChain, commitID.Version, nil, privs), time.Hour)
+	require.Nil(t, ibcm.CreateClient(destCtx, cs))
+	require.NotNil(t, ibcm.CreateClient(destCtx, cs))
+
+	// nor before the header committing to the proven state has been verified
+	res = destHandler(destCtx, receiveMsg)
+	require.False(t, res.IsOK())
+
+	// headers which are not signed by the trusted validators are rejected
+	header := makeHeader(t, srcChain, commitID.Version+1, commitID.Hash, makePrivs(3))
+	res = destHandler(destCtx, NewUpdateClientMsg(header, src))
+	require.False(t, res.IsOK())
 
-	coins, err = getCoins(ck, ctx, dest)
+	header = makeHeader(t, srcChain, commitID.Version+1, commitID.Hash, privs)
+	res = destHandler(destCtx, NewUpdateClientMsg(header, src))
+	require.True(t, res.IsOK(), res.Log)
+
+	// packets which do not match the proof are rejected
+	forgedMsg := receiveMsg
+	forgedMsg.Coins = sdk.Coins{sdk.NewInt64Coin("mycoin", 1000)}
+	res = destHandler(destCtx, forgedMsg)
+	require.False(t, res.IsOK())
+
+	forgedMsg = receiveMsg
+	forgedMsg.Proof = []byte("proof")
+	res = destHandler(destCtx, forgedMsg)
+	require.False(t, res.IsOK())
+
+	res = destHandler(destCtx, receiveMsg)
+	require.True(t, res.IsOK(), res.Log)
+
+	// the coins arrive as vouchers of the source chain
+	coins, err = getCoins(destCk, destCtx, dest)
 	require.Nil(t, err)
-	require.Equal(t, mycoins, coins)
+	require.Equal(t, sdk.Coins{sdk.NewInt64Coin(VoucherDenom(srcChain, "mycoin"), 10)}, coins)
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	igs = ibcm.GetIngressSequence(destCtx, srcChain)
 	require.Equal(t, igs, int64(1))
 
-	res = h(ctx, msg)
+	res = destHandler(destCtx, receiveMsg)
 	require.False(t, res.IsOK())
 
-	igs = ibcm.GetIngressSequence(ctx, chainid)
+	igs = ibcm.GetIngressSequence(destCtx, srcChain)
 	require.Equal(t, igs, int64(1))
+
+	// packets destined for another chain are rejected
+	res = srcHandler(srcCtx, receiveMsg)
+	require.False(t, res.IsOK())
+}
+
+func TestUpdateClient(t *testing.T) {
+	cdc := makeCodec()
+	key := sdk.NewKVStoreKey("ibc")
+	ctx, _ := defaultContext(key, "dest-chain")
+	ibcm := NewMapper(cdc, key, DefaultCodespace)
+	chainID := "source-chain"
+
+	privs := makePrivs(3)
+	require.NotNil(t, ibcm.UpdateClient(ctx, makeHeader(t, chainID, 1, nil, privs)))
+	require.NotNil(t, ibcm.CreateClient(ctx, NewConsensusState(makeHeader(t, chainID, 1, nil, privs), 0)))
+	require.Nil(t, ibcm.CreateClient(ctx, NewConsensusState(makeHeader(t, chainID, 1, nil, privs), time.Hour)))
+
+	// headers must be above the latest verified height and for the same chain
+	require.NotNil(t, ibcm.UpdateClient(ctx, makeHeader(t, chainID, 1, nil, privs)))
+	require.NotNil(t, ibcm.UpdateClient(ctx, makeHeader(t, "other-chain", 2, nil, privs)))
+
+	// a changed validator set must be signed by the trusted validators
+	require.NotNil(t, ibcm.UpdateClient(ctx, makeHeader(t, chainID, 2, nil, makePrivs(4))))
+	newPrivs := append(privs, makePrivs(1)...)
+	require.Nil(t, ibcm.UpdateClient(ctx, makeHeader(t, chainID, 2, []byte("apphash"), newPrivs)))
+
+	cs, found := ibcm.GetConsensusState(ctx, chainID)
+	require.True(t, found)
+	require.Equal(t, int64(2), cs.Height)
+	require.Equal(t, 4, cs.Validators.Size())
+	appHash, found := ibcm.GetAppHash(ctx, chainID, 2)
+	require.True(t, found)
+	require.Equal(t, []byte("apphash"), appHash)
+	_, found = ibcm.GetAppHash(ctx, chainID, 1)
+	require.False(t, found)
+
+	// the client expires once the trusting period elapsed since the latest
+	// verified header
+	ctx = ctx.WithBlockHeader(abci.Header{ChainID: "dest-chain", Time: cs.Time.Add(time.Hour)})
+	err := ibcm.UpdateClient(ctx, makeHeader(t, chainID, 3, nil, newPrivs))
+	require.NotNil(t, err)
+	require.Equal(t, CodeClientExpired, err.Code())
+}
+
+func TestIBCGenesis(t *testing.T) {
+	cdc := makeCodec()
+	key := sdk.NewKVStoreKey("ibc")
+	ctx, _ := defaultContext(key, "dest-chain")
+	ibcm := NewMapper(cdc, key, DefaultCodespace)
+
+	cs := NewConsensusState(makeHeader(t, "source-chain", 1, []byte("apphash"), makePrivs(3)), time.Hour)
+	genesis := GenesisState{[]ConsensusState{cs}}
+	require.Nil(t, ValidateGenesis(genesis))
+	require.NotNil(t, ValidateGenesis(GenesisState{[]ConsensusState{cs, cs}}))
+	require.NotNil(t, ValidateGenesis(GenesisState{[]ConsensusState{NewConsensusState(makeHeader(t, "source-chain", 1, nil, makePrivs(3)), 0)}}))
+
+	// the light clients are created from the genesis state
+	require.Nil(t, InitGenesis(ctx, ibcm, genesis))
+	stored, found := ibcm.GetConsensusState(ctx, "source-chain")
+	require.True(t, found)
+	require.Equal(t, cs.Validators.Hash(), stored.Validators.Hash())
+	appHash, found := ibcm.GetAppHash(ctx, "source-chain", 1)
+	require.True(t, found)
+	require.Equal(t, []byte("apphash"), appHash)
+	require.Len(t, WriteGenesis(ctx, ibcm).Clients, 1)
+}
+
+func TestIBCTimeout(t *testing.T) {
+	cdc := makeCodec()
+
+	key := sdk.NewKVStoreKey("ibc")
+	srcChain := "source-chain"
+	destChain := "dest-chain"
+	srcCtx, srcCms := defaultContext(key, srcChain)
+	destCtx, destCms := defaultContext(key, destChain)
+
+	srcCk := bank.NewBaseKeeper(auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount))
+	destCk := bank.NewBaseKeeper(auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount))
+	ibcm := NewMapper(cdc, key, DefaultCodespace)
+	srcHandler := NewHandler(ibcm, srcCk)
+	destHandler := NewHandler(ibcm, destCk)
+	privs := makePrivs(3)
+
+	src := newAddress()
+	dest := newAddress()
+	mycoins := sdk.Coins{sdk.NewInt64Coin("mycoin", 10)}
+	_, _, err := srcCk.AddCoins(srcCtx, src, mycoins)
+	require.Nil(t, err)
+
+	packet := NewIBCPacket(src, dest, mycoins, srcChain, destChain, 5)
+	res := srcHandler(srcCtx, IBCTransferMsg{packet})
+	require.True(t, res.IsOK(), res.Log)
+
+	commitID := srcCms.Commit()
+	_, proof := queryWithProof(t, srcCms, key, EgressKey(destChain, 0))
+	header := makeHeader(t, srcChain, commitID.Version+1, commitID.Hash, privs)
+	require.Nil(t, ibcm.CreateClient(destCtx, NewConsensusState(header, time.Hour)))
+
+	// the packet is received after its timeout, so only a timeout receipt is recorded
+	destCtx = destCtx.WithBlockHeight(5)
+	res = destHandler(destCtx, IBCReceiveMsg{packet, dest, 0, header.Height(), proof})
+	require.True(t, res.IsOK(), res.Log)
+	coins, err := getCoins(destCk, destCtx, dest)
+	require.Nil(t, err)
+	require.True(t, coins.IsZero())
+	require.Equal(t, int64(1), ibcm.GetIngressSequence(destCtx, srcChain))
+
+	// refunds must be proven against a verified header of the destination chain
+	commitID = destCms.Commit()
+	_, proof = queryWithProof(t, destCms, key, TimeoutReceiptKey(srcChain, 0))
+	header = makeHeader(t, destChain, commitID.Version+1, commitID.Hash, privs)
+	timeoutMsg := TimeoutMsg{packet, src, 0, header.Height(), proof}
+	res = srcHandler(srcCtx, timeoutMsg)
+	require.False(t, res.IsOK())
+
+	require.Nil(t, ibcm.CreateClient(srcCtx, NewConsensusState(header, time.Hour)))
+	forgedMsg := timeoutMsg
+	forgedMsg.Coins = sdk.Coins{sdk.NewInt64Coin("mycoin", 1000)}
+	res = srcHandler(srcCtx, forgedMsg)
+	require.False(t, res.IsOK())
+
+	res = srcHandler(srcCtx, timeoutMsg)
+	require.True(t, res.IsOK(), res.Log)
+	coins, err = getCoins(srcCk, srcCtx, src)
+	require.Nil(t, err)
+	require.Equal(t, mycoins, coins)
+	coins, err = getCoins(srcCk, srcCtx, EscrowAddress(destChain))
+	require.Nil(t, err)
+	require.True(t, coins.IsZero())
+
+	// packets are only refunded once
+	res = srcHandler(srcCtx, timeoutMsg)
+	require.False(t, res.IsOK())
+}
+
+func TestIBCFailedReceive(t *testing.T) {
+	cdc := makeCodec()
+
+	key := sdk.NewKVStoreKey("ibc")
+	srcChain := "source-chain"
+	destChain := "dest-chain"
+	srcCtx, srcCms := defaultContext(key, srcChain)
+	destCtx, destCms := defaultContext(key, destChain)
+
+	srcCk := bank.NewBaseKeeper(auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount))
+	destCk := bank.NewBaseKeeper(auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount))
+	ibcm := NewMapper(cdc, key, DefaultCodespace)
+	srcHandler := NewHandler(ibcm, srcCk)
+	destHandler := NewHandler(ibcm, destCk)
+	privs := makePrivs(3)
+
+	// the vouchers sent back have no escrowed coins to release on the
+	// destination chain
+	src := newAddress()
+	dest := newAddress()
+	vouchers := sdk.Coins{sdk.NewInt64Coin(VoucherDenom(destChain, "mycoin"), 10)}
+	_, _, err := srcCk.AddCoins(srcCtx, src, vouchers)
+	require.Nil(t, err)
+
+	packet := NewIBCPacket(src, dest, vouchers, srcChain, destChain, 0)
+	res := srcHandler(srcCtx, IBCTransferMsg{packet})
+	require.True(t, res.IsOK(), res.Log)
+
+	commitID := srcCms.Commit()
+	_, proof := queryWithProof(t, srcCms, key, EgressKey(destChain, 0))
+	header := makeHeader(t, srcChain, commitID.Version+1, commitID.Hash, privs)
+	require.Nil(t, ibcm.CreateClient(destCtx, NewConsensusState(header, time.Hour)))
+
+	// the failed packet is recorded and the following packets can be received
+	res = destHandler(destCtx, IBCReceiveMsg{packet, dest, 0, header.Height(), proof})
+	require.True(t, res.IsOK(), res.Log)
+	coins, err := getCoins(destCk, destCtx, dest)
+	require.Nil(t, err)
+	require.True(t, coins.IsZero())
+	require.Equal(t, int64(1), ibcm.GetIngressSequence(destCtx, srcChain))
+
+	// the vouchers are refunded on the source chain
+	commitID = destCms.Commit()
+	_, proof = queryWithProof(t, destCms, key, TimeoutReceiptKey(srcChain, 0))
+	header = makeHeader(t, destChain, commitID.Version+1, commitID.Hash, privs)
+	require.Nil(t, ibcm.CreateClient(srcCtx, NewConsensusState(header, time.Hour)))
+	res = srcHandler(srcCtx, TimeoutMsg{packet, src, 0, header.Height(), proof})
+	require.True(t, res.IsOK(), res.Log)
+	coins, err = getCoins(srcCk, srcCtx, src)
+	require.Nil(t, err)
+	require.Equal(t, vouchers, coins)
 }
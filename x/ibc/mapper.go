@@ -114,6 +114,30 @@ func (ibcm Mapper) getEgressLength(store sdk.KVStore, destChain string) int64 {
 	return res
 }
 
+// GetIBCPacket returns the outgoing IBC packet to the chain at the given index
+func (ibcm Mapper) GetIBCPacket(ctx sdk.Context, destChain string, index int64) (packet IBCPacket, found bool) {
+	store := ctx.KVStore(ibcm.key)
+	bz := store.Get(EgressKey(destChain, index))
+	if bz == nil {
+		return packet, false
+	}
+	unmarshalBinaryPanic(ibcm.cdc, bz, &packet)
+	return packet, true
+}
+
+// remove an outgoing IBC packet which has been refunded
+func (ibcm Mapper) deleteIBCPacket(ctx sdk.Context, destChain string, index int64) {
+	store := ctx.KVStore(ibcm.key)
+	store.Delete(EgressKey(destChain, index))
+}
+
+// record that the incoming IBC packet with the given sequence timed out or
+// failed, so that the source chain can prove it and refund the packet
+func (ibcm Mapper) setTimeoutReceipt(ctx sdk.Context, packet IBCPacket, sequence int64) {
+	store := ctx.KVStore(ibcm.key)
+	store.Set(TimeoutReceiptKey(packet.SrcChain, sequence), marshalBinaryPanic(ibcm.cdc, packet))
+}
+
 // Stores an outgoing IBC packet under "egress/chain_id/index".
 func EgressKey(destChain string, index int64) []byte {
 	return []byte(fmt.Sprintf("egress/%s/%d", destChain, index))
@@ -128,3 +152,13 @@ func EgressLengthKey(destChain string) []byte {
 func IngressSequenceKey(srcChain string) []byte {
 	return []byte(fmt.Sprintf("ingress/%s", srcChain))
 }
+
+// Stores the incoming IBC packets which timed out or failed under "timeout/chain_id/index".
+func TimeoutReceiptKey(srcChain string, index int64) []byte {
+	return append(TimeoutReceiptPrefix(srcChain), []byte(fmt.Sprintf("%d", index))...)
+}
+
+// Prefix of the timed out incoming IBC packets of a chain, "timeout/chain_id/".
+func TimeoutReceiptPrefix(srcChain string) []byte {
+	return []byte(fmt.Sprintf("timeout/%s/", srcChain))
+}
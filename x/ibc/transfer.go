@@ -0,0 +1,92 @@
+package ibc
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// Coins sent over IBC are either native to the sending chain, in which case
+// they are locked in the escrow account of the destination chain and arrive
+// as vouchers, or vouchers of the destination chain, in which case they are
+// burned and the escrowed native coins are released on the other side. The
+// coins of a packet are always given in the denominations of the source chain.
+
+// EscrowAddress returns the address of the account holding the native coins
+// sent to the given chain
+func EscrowAddress(chainID string) sdk.AccAddress {
+	return sdk.AccAddress(tmhash.SumTruncated([]byte(fmt.Sprintf("ibc/escrow/%s", chainID))))
+}
+
+// VoucherPrefix returns the denomination prefix of the vouchers for coins
+// native to the given chain
+func VoucherPrefix(chainID string) string {
+	return fmt.Sprintf("ibc/%s/", chainID)
+}
+
+// VoucherDenom returns the denomination of the vouchers for the coins of the
+// given chain and denomination
+func VoucherDenom(chainID string, denom string) string {
+	return VoucherPrefix(chainID) + denom
+}
+
+// escrow native coins and burn the vouchers of the destination chain sent with the packet
+func sendPacketCoins(ctx sdk.Context, ck bank.Keeper, packet IBCPacket) sdk.Error {
+	escrow := EscrowAddress(packet.DestChain)
+	for _, coin := range packet.Coins {
+		coins := sdk.Coins{coin}
+		if strings.HasPrefix(coin.Denom, VoucherPrefix(packet.DestChain)) {
+			if _, _, err := ck.SubtractCoins(ctx, packet.SrcAddr, coins); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := ck.SendCoins(ctx, packet.SrcAddr, escrow, coins); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// release the native coins returning with the packet from escrow and mint
+// vouchers for the coins of the source chain
+func receivePacketCoins(ctx sdk.Context, ck bank.Keeper, packet IBCPacket) sdk.Error {
+	escrow := EscrowAddress(packet.SrcChain)
+	prefix := VoucherPrefix(packet.DestChain)
+	for _, coin := range packet.Coins {
+		if strings.HasPrefix(coin.Denom, prefix) {
+			coins := sdk.Coins{sdk.NewCoin(strings.TrimPrefix(coin.Denom, prefix), coin.Amount)}
+			if _, err := ck.SendCoins(ctx, escrow, packet.DestAddr, coins); err != nil {
+				return err
+			}
+			continue
+		}
+		coins := sdk.Coins{sdk.NewCoin(VoucherDenom(packet.SrcChain, coin.Denom), coin.Amount)}
+		if _, _, err := ck.AddCoins(ctx, packet.DestAddr, coins); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// refund the coins sent with a packet which timed out, releasing the native
+// coins from escrow and minting back the burned vouchers
+func refundPacketCoins(ctx sdk.Context, ck bank.Keeper, packet IBCPacket) sdk.Error {
+	escrow := EscrowAddress(packet.DestChain)
+	for _, coin := range packet.Coins {
+		coins := sdk.Coins{coin}
+		if strings.HasPrefix(coin.Denom, VoucherPrefix(packet.DestChain)) {
+			if _, _, err := ck.AddCoins(ctx, packet.SrcAddr, coins); err != nil {
+				return err
+			}
+			continue
+		}
+		if _, err := ck.SendCoins(ctx, escrow, packet.SrcAddr, coins); err != nil {
+			return err
+		}
+	}
+	return nil
+}
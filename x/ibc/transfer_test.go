@@ -0,0 +1,61 @@
+package ibc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+func TestPacketCoinsRoundTrip(t *testing.T) {
+	cdc := makeCodec()
+	key := sdk.NewKVStoreKey("ibc")
+	chainA, chainB := "chain-a", "chain-b"
+	ctxA, _ := defaultContext(key, chainA)
+	ctxB, _ := defaultContext(key, chainB)
+	ckA := bank.NewBaseKeeper(auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount))
+	ckB := bank.NewBaseKeeper(auth.NewAccountMapper(cdc, key, auth.ProtoBaseAccount))
+
+	addrA := newAddress()
+	addrB := newAddress()
+	atoms := sdk.Coins{sdk.NewInt64Coin("atom", 10)}
+	vouchers := sdk.Coins{sdk.NewInt64Coin(VoucherDenom(chainA, "atom"), 10)}
+	_, _, err := ckA.AddCoins(ctxA, addrA, atoms)
+	require.Nil(t, err)
+
+	// native coins are escrowed on the way out and arrive as vouchers
+	packet := NewIBCPacket(addrA, addrB, atoms, chainA, chainB, 0)
+	require.Nil(t, sendPacketCoins(ctxA, ckA, packet))
+	require.Nil(t, receivePacketCoins(ctxB, ckB, packet))
+
+	coins, err := getCoins(ckA, ctxA, EscrowAddress(chainB))
+	require.Nil(t, err)
+	require.Equal(t, atoms, coins)
+	coins, err = getCoins(ckB, ctxB, addrB)
+	require.Nil(t, err)
+	require.Equal(t, vouchers, coins)
+
+	// vouchers are burned on the way back and release the escrowed coins
+	packet = NewIBCPacket(addrB, addrA, vouchers, chainB, chainA, 0)
+	require.Nil(t, sendPacketCoins(ctxB, ckB, packet))
+	require.Nil(t, receivePacketCoins(ctxA, ckA, packet))
+
+	coins, err = getCoins(ckB, ctxB, addrB)
+	require.Nil(t, err)
+	require.True(t, coins.IsZero())
+	coins, err = getCoins(ckA, ctxA, EscrowAddress(chainB))
+	require.Nil(t, err)
+	require.True(t, coins.IsZero())
+	coins, err = getCoins(ckA, ctxA, addrA)
+	require.Nil(t, err)
+	require.Equal(t, atoms, coins)
+
+	// vouchers cannot release more than was escrowed
+	_, _, err = ckB.AddCoins(ctxB, addrB, vouchers)
+	require.Nil(t, err)
+	packet = NewIBCPacket(addrB, addrA, vouchers, chainB, chainA, 0)
+	require.NotNil(t, receivePacketCoins(ctxA, ckA, packet))
+}
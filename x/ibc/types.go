@@ -13,6 +13,7 @@ var (
 
 func init() {
 	msgCdc = codec.New()
+	codec.RegisterCrypto(msgCdc)
 }
 
 // ------------------------------
@@ -20,17 +21,20 @@ func init() {
 
 // nolint - TODO rename to Packet as IBCPacket stutters (golint)
 // IBCPacket defines a piece of data that can be send between two separate
-// blockchains.
+// blockchains. The coins are given in the denominations of the source chain.
+// A packet which is not received before the destination chain reaches the
+// timeout height is refunded to the sender, a zero timeout never expires.
 type IBCPacket struct {
 	SrcAddr   sdk.AccAddress `json:"src_addr"`
 	DestAddr  sdk.AccAddress `json:"dest_addr"`
 	Coins     sdk.Coins      `json:"coins"`
 	SrcChain  string         `json:"src_chain"`
 	DestChain string         `json:"dest_chain"`
+	Timeout   int64          `json:"timeout"`
 }
 
 func NewIBCPacket(srcAddr sdk.AccAddress, destAddr sdk.AccAddress, coins sdk.Coins,
-	srcChain string, destChain string) IBCPacket {
+	srcChain string, destChain string, timeout int64) IBCPacket {
 
 	return IBCPacket{
 		SrcAddr:   srcAddr,
@@ -38,9 +42,15 @@ func NewIBCPacket(srcAddr sdk.AccAddress, destAddr sdk.AccAddress, coins sdk.Coi
 		Coins:     coins,
 		SrcChain:  srcChain,
 		DestChain: destChain,
+		Timeout:   timeout,
 	}
 }
 
+// whether the packet can no longer be received at the given destination chain height
+func (p IBCPacket) TimedOut(height int64) bool {
+	return p.Timeout != 0 && height >= p.Timeout
+}
+
 //nolint
 func (p IBCPacket) GetSignBytes() []byte {
 	b, err := msgCdc.MarshalJSON(p)
@@ -58,6 +68,9 @@ func (p IBCPacket) ValidateBasic() sdk.Error {
 	if !p.Coins.IsValid() {
 		return sdk.ErrInvalidCoins("")
 	}
+	if p.Timeout < 0 {
+		return ErrInvalidTimeout(DefaultCodespace).TraceSDK("")
+	}
 	return nil
 }
 
@@ -92,17 +105,20 @@ func (msg IBCTransferMsg) ValidateBasic() sdk.Error {
 
 // nolint - TODO rename to ReceiveMsg as folks will reference with ibc.ReceiveMsg
 // IBCReceiveMsg defines the message that a relayer uses to post an IBCPacket
-// to the destination chain.
+// to the destination chain. The packet is only accepted along with a Merkle
+// proof of it being in the egress queue of the source chain, against the app
+// hash committed to by the source chain header at Height.
 type IBCReceiveMsg struct {
 	IBCPacket
 	Relayer  sdk.AccAddress
 	Sequence int64
+	Height   int64
+	Proof    []byte
 }
 
 // nolint
-func (msg IBCReceiveMsg) Type() string             { return "ibc" }
-func (msg IBCReceiveMsg) Name() string             { return "receive" }
-func (msg IBCReceiveMsg) ValidateBasic() sdk.Error { return msg.IBCPacket.ValidateBasic() }
+func (msg IBCReceiveMsg) Type() string { return "ibc" }
+func (msg IBCReceiveMsg) Name() string { return "receive" }
 
 // x/bank/tx.go MsgSend.GetSigners()
 func (msg IBCReceiveMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Relayer} }
@@ -113,13 +129,125 @@ func (msg IBCReceiveMsg) GetSignBytes() []byte {
 		IBCPacket json.RawMessage
 		Relayer   sdk.AccAddress
 		Sequence  int64
+		Height    int64
+		Proof     []byte
 	}{
 		IBCPacket: json.RawMessage(msg.IBCPacket.GetSignBytes()),
 		Relayer:   msg.Relayer,
 		Sequence:  msg.Sequence,
+		Height:    msg.Height,
+		Proof:     msg.Proof,
 	})
 	if err != nil {
 		panic(err)
 	}
 	return sdk.MustSortJSON(b)
 }
+
+// validate ibc receive message
+func (msg IBCReceiveMsg) ValidateBasic() sdk.Error {
+	if err := msg.IBCPacket.ValidateBasic(); err != nil {
+		return err
+	}
+	if msg.Height <= 0 {
+		return ErrInvalidProof(DefaultCodespace, "proof height must be positive")
+	}
+	if len(msg.Proof) == 0 {
+		return ErrInvalidProof(DefaultCodespace, "proof cannot be empty")
+	}
+	return nil
+}
+
+// ----------------------------------
+// TimeoutMsg
+
+// TimeoutMsg defines the message that a relayer uses to refund a packet which
+// timed out or failed on the destination chain. It carries a Merkle proof of the timeout
+// receipt of the packet on the destination chain, against the app hash
+// committed to by the destination chain header at Height.
+type TimeoutMsg struct {
+	IBCPacket
+	Relayer  sdk.AccAddress
+	Sequence int64
+	Height   int64
+	Proof    []byte
+}
+
+// nolint
+func (msg TimeoutMsg) Type() string                 { return "ibc" }
+func (msg TimeoutMsg) Name() string                 { return "timeout" }
+func (msg TimeoutMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Relayer} }
+
+// get the sign bytes for timeout message
+func (msg TimeoutMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(struct {
+		IBCPacket json.RawMessage
+		Relayer   sdk.AccAddress
+		Sequence  int64
+		Height    int64
+		Proof     []byte
+	}{
+		IBCPacket: json.RawMessage(msg.IBCPacket.GetSignBytes()),
+		Relayer:   msg.Relayer,
+		Sequence:  msg.Sequence,
+		Height:    msg.Height,
+		Proof:     msg.Proof,
+	})
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// validate timeout message
+func (msg TimeoutMsg) ValidateBasic() sdk.Error {
+	if err := msg.IBCPacket.ValidateBasic(); err != nil {
+		return err
+	}
+	if msg.Height <= 0 {
+		return ErrInvalidProof(DefaultCodespace, "proof height must be positive")
+	}
+	if len(msg.Proof) == 0 {
+		return ErrInvalidProof(DefaultCodespace, "proof cannot be empty")
+	}
+	return nil
+}
+
+// ----------------------------------
+// UpdateClientMsg
+
+// UpdateClientMsg submits a new header of a counterparty chain to its light
+// client, making the app hash of the header available for packet proofs.
+type UpdateClientMsg struct {
+	Header Header         `json:"header"`
+	Signer sdk.AccAddress `json:"signer"`
+}
+
+func NewUpdateClientMsg(header Header, signer sdk.AccAddress) UpdateClientMsg {
+	return UpdateClientMsg{
+		Header: header,
+		Signer: signer,
+	}
+}
+
+// nolint
+func (msg UpdateClientMsg) Type() string                 { return "ibc" }
+func (msg UpdateClientMsg) Name() string                 { return "update_client" }
+func (msg UpdateClientMsg) GetSigners() []sdk.AccAddress { return []sdk.AccAddress{msg.Signer} }
+
+// get the sign bytes for update client message
+func (msg UpdateClientMsg) GetSignBytes() []byte {
+	b, err := msgCdc.MarshalJSON(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// validate update client message
+func (msg UpdateClientMsg) ValidateBasic() sdk.Error {
+	if len(msg.Signer) == 0 {
+		return sdk.ErrInvalidAddress("signer cannot be empty")
+	}
+	return msg.Header.ValidateBasic()
+}
@@ -18,6 +18,8 @@ func TestIBCPacketValidation(t *testing.T) {
 	}{
 		{true, constructIBCPacket(true)},
 		{false, constructIBCPacket(false)},
+		{false, NewIBCPacket(sdk.AccAddress([]byte("source")), sdk.AccAddress([]byte("destination")),
+			sdk.Coins{sdk.NewInt64Coin("atom", 10)}, "source-chain", "dest-chain", -1)},
 	}
 
 	for i, tc := range cases {
@@ -67,7 +69,7 @@ func TestIBCTransferMsgValidation(t *testing.T) {
 
 func TestIBCReceiveMsg(t *testing.T) {
 	packet := constructIBCPacket(true)
-	msg := IBCReceiveMsg{packet, sdk.AccAddress([]byte("relayer")), 0}
+	msg := IBCReceiveMsg{packet, sdk.AccAddress([]byte("relayer")), 0, 1, []byte("proof")}
 
 	require.Equal(t, msg.Type(), "ibc")
 }
@@ -80,8 +82,55 @@ func TestIBCReceiveMsgValidation(t *testing.T) {
 		valid bool
 		msg   IBCReceiveMsg
 	}{
-		{true, IBCReceiveMsg{validPacket, sdk.AccAddress([]byte("relayer")), 0}},
-		{false, IBCReceiveMsg{invalidPacket, sdk.AccAddress([]byte("relayer")), 0}},
+		{true, IBCReceiveMsg{validPacket, sdk.AccAddress([]byte("relayer")), 0, 1, []byte("proof")}},
+		{false, IBCReceiveMsg{invalidPacket, sdk.AccAddress([]byte("relayer")), 0, 1, []byte("proof")}},
+		{false, IBCReceiveMsg{validPacket, sdk.AccAddress([]byte("relayer")), 0, 0, []byte("proof")}},
+		{false, IBCReceiveMsg{validPacket, sdk.AccAddress([]byte("relayer")), 0, 1, nil}},
+	}
+
+	for i, tc := range cases {
+		err := tc.msg.ValidateBasic()
+		if tc.valid {
+			require.Nil(t, err, "%d: %+v", i, err)
+		} else {
+			require.NotNil(t, err, "%d", i)
+		}
+	}
+}
+
+func TestIBCPacketTimedOut(t *testing.T) {
+	packet := constructIBCPacket(true)
+	require.False(t, packet.TimedOut(100))
+
+	packet.Timeout = 10
+	require.False(t, packet.TimedOut(9))
+	require.True(t, packet.TimedOut(10))
+}
+
+// -------------------------------
+// UpdateClientMsg Tests
+
+func TestUpdateClientMsgValidation(t *testing.T) {
+	signer := sdk.AccAddress([]byte("relayer"))
+	header := makeHeader(t, "source-chain", 1, nil, makePrivs(2))
+	otherHeader := makeHeader(t, "source-chain", 1, nil, makePrivs(2))
+
+	mismatchedValidators := header
+	mismatchedValidators.Validators = otherHeader.Validators
+	mismatchedCommit := header
+	mismatchedCommit.SignedHeader.Commit = otherHeader.SignedHeader.Commit
+	missingCommit := header
+	missingCommit.SignedHeader.Commit = nil
+
+	cases := []struct {
+		valid bool
+		msg   UpdateClientMsg
+	}{
+		{true, NewUpdateClientMsg(header, signer)},
+		{false, NewUpdateClientMsg(header, nil)},
+		{false, NewUpdateClientMsg(mismatchedValidators, signer)},
+		{false, NewUpdateClientMsg(mismatchedCommit, signer)},
+		{false, NewUpdateClientMsg(missingCommit, signer)},
 	}
 
 	for i, tc := range cases {
@@ -105,7 +154,7 @@ func constructIBCPacket(valid bool) IBCPacket {
 	destChain := "dest-chain"
 
 	if valid {
-		return NewIBCPacket(srcAddr, destAddr, coins, srcChain, destChain)
+		return NewIBCPacket(srcAddr, destAddr, coins, srcChain, destChain, 0)
 	}
-	return NewIBCPacket(srcAddr, destAddr, coins, srcChain, srcChain)
+	return NewIBCPacket(srcAddr, destAddr, coins, srcChain, srcChain, 0)
 }
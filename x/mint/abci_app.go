@@ -0,0 +1,31 @@
+package mint
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Mint new tokens into the fee collector and update the inflation rate once
+// every hour
+func BeginBlocker(ctx sdk.Context, k Keeper) {
+
+	blockTime := ctx.BlockHeader().Time
+	minter := k.GetMinter(ctx)
+	if blockTime.Sub(minter.InflationLastTime) < time.Hour { // only mint on the hour!
+		return
+	}
+
+	params := k.GetParams(ctx)
+	totalSupply := k.sk.TotalTokens(ctx)
+	bondedRatio := k.sk.BondedRatio(ctx)
+	minter.InflationLastTime = blockTime
+	minter, mintedCoin := minter.ProcessProvisions(params, totalSupply, bondedRatio)
+	k.SetMinter(ctx, minter)
+
+	if !mintedCoin.IsPositive() {
+		return
+	}
+	k.fck.AddCollectedFees(ctx, sdk.Coins{mintedCoin})
+	k.sk.InflateSupply(ctx, sdk.NewDecFromInt(mintedCoin.Amount))
+}
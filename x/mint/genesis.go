@@ -0,0 +1,50 @@
+package mint
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - all mint state that must be provided at genesis
+type GenesisState struct {
+	Minter Minter `json:"minter"` // minter object
+	Params Params `json:"params"` // inflation params
+}
+
+func NewGenesisState(minter Minter, params Params) GenesisState {
+	return GenesisState{
+		Minter: minter,
+		Params: params,
+	}
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Minter: InitialMinter(),
+		Params: DefaultParams(),
+	}
+}
+
+// new mint genesis
+func InitGenesis(ctx sdk.Context, keeper Keeper, data GenesisState) {
+	keeper.SetMinter(ctx, data.Minter)
+	keeper.SetParams(ctx, data.Params)
+}
+
+// WriteGenesis returns a GenesisState for a given context and keeper. The
+// GenesisState will contain the minter and params found in the keeper.
+func WriteGenesis(ctx sdk.Context, keeper Keeper) GenesisState {
+	minter := keeper.GetMinter(ctx)
+	params := keeper.GetParams(ctx)
+	return NewGenesisState(minter, params)
+}
+
+// ValidateGenesis validates the provided mint genesis state to ensure the
+// expected invariants holds. (i.e. params in correct bounds)
+func ValidateGenesis(data GenesisState) error {
+	err := validateParams(data.Params)
+	if err != nil {
+		return err
+	}
+	return validateMinter(data.Minter)
+}
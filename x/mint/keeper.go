@@ -0,0 +1,72 @@
+package mint
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// keeper of the mint store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *codec.Codec
+	sk       StakeKeeper
+	fck      FeeCollectionKeeper
+}
+
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey,
+	sk StakeKeeper, fck FeeCollectionKeeper) Keeper {
+
+	keeper := Keeper{
+		storeKey: key,
+		cdc:      cdc,
+		sk:       sk,
+		fck:      fck,
+	}
+	return keeper
+}
+
+// Keys for mint store items
+var (
+	minterKey = []byte{0x00} // the one key to use for the keeper store
+	paramsKey = []byte{0x01} // key for the mint params
+)
+
+//______________________________________________________________________
+
+// get the minter
+func (k Keeper) GetMinter(ctx sdk.Context) (minter Minter) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(minterKey)
+	if b == nil {
+		panic("Stored minter should not have been nil")
+	}
+	k.cdc.MustUnmarshalBinary(b, &minter)
+	return
+}
+
+// set the minter
+func (k Keeper) SetMinter(ctx sdk.Context, minter Minter) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(minter)
+	store.Set(minterKey, b)
+}
+
+//______________________________________________________________________
+
+// get the mint params
+func (k Keeper) GetParams(ctx sdk.Context) (params Params) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(paramsKey)
+	if b == nil {
+		panic("Stored mint params should not have been nil")
+	}
+	k.cdc.MustUnmarshalBinary(b, &params)
+	return
+}
+
+// set the mint params
+func (k Keeper) SetParams(ctx sdk.Context, params Params) {
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(params)
+	store.Set(paramsKey, b)
+}
@@ -0,0 +1,134 @@
+package params
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// ValidateFn checks a decoded parameter value before it is allowed to be stored
+type ValidateFn func(value interface{}) error
+
+// ApplyFn applies a decoded parameter value kept by a module outside of the
+// global param store, e.g. through the keeper of the module
+type ApplyFn func(ctx sdk.Context, value interface{}) error
+
+type registryEntry struct {
+	typ      reflect.Type
+	validate ValidateFn
+	apply    ApplyFn
+}
+
+// Registry holds the set of parameter keys which may be changed through
+// governance, along with the type of the value stored under each key
+type Registry struct {
+	entries map[string]registryEntry
+}
+
+// NewRegistry constructs an empty Registry
+func NewRegistry() Registry {
+	return Registry{
+		entries: make(map[string]registryEntry),
+	}
+}
+
+// Register marks a parameter key as governable. The prototype determines the
+// type that new values for the key are decoded into, validate may be nil.
+func (r Registry) Register(key string, prototype interface{}, validate ValidateFn) {
+	r.RegisterWithApply(key, prototype, validate, nil)
+}
+
+// RegisterWithApply marks a parameter key as governable, the new values for
+// the key being applied by apply instead of being set in the global param
+// store if apply is not nil.
+func (r Registry) RegisterWithApply(key string, prototype interface{}, validate ValidateFn, apply ApplyFn) {
+	if _, ok := r.entries[key]; ok {
+		panic(fmt.Sprintf("param key %s already registered", key))
+	}
+	typ := reflect.TypeOf(prototype)
+	if typ == nil {
+		panic(fmt.Sprintf("nil prototype registered for param key %s", key))
+	}
+	r.entries[key] = registryEntry{
+		typ:      typ,
+		validate: validate,
+		apply:    apply,
+	}
+}
+
+// Has returns whether a parameter key is governable
+func (r Registry) Has(key string) bool {
+	_, ok := r.entries[key]
+	return ok
+}
+
+// HasApply returns whether the values of a governable parameter key are
+// applied by an ApplyFn instead of being set in the global param store
+func (r Registry) HasApply(key string) bool {
+	return r.entries[key].apply != nil
+}
+
+// Keys returns all governable parameter keys in sorted order
+func (r Registry) Keys() []string {
+	keys := make([]string, 0, len(r.entries))
+	for key := range r.entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Parse decodes a JSON encoded value for a governable parameter key into the
+// registered type and runs the registered validation on it
+func (r Registry) Parse(cdc *codec.Codec, key string, value string) (interface{}, error) {
+	entry, ok := r.entries[key]
+	if !ok {
+		return nil, fmt.Errorf("param key %s is not governable", key)
+	}
+
+	ptr := reflect.New(entry.typ)
+	if err := cdc.UnmarshalJSON([]byte(value), ptr.Interface()); err != nil {
+		return nil, fmt.Errorf("cannot decode value for param key %s: %v", key, err)
+	}
+	param := ptr.Elem().Interface()
+
+	if entry.validate != nil {
+		if err := entry.validate(param); err != nil {
+			return nil, fmt.Errorf("invalid value for param key %s: %v", key, err)
+		}
+	}
+	return param, nil
+}
+
+// JSONFromBinary decodes a binary encoded value of a governable parameter key,
+// as set in the global param store, into the JSON encoding taken by Parse
+func (r Registry) JSONFromBinary(cdc *codec.Codec, key string, bz []byte) (string, error) {
+	entry, ok := r.entries[key]
+	if !ok {
+		return "", fmt.Errorf("param key %s is not governable", key)
+	}
+
+	ptr := reflect.New(entry.typ)
+	if err := cdc.UnmarshalBinary(bz, ptr.Interface()); err != nil {
+		return "", fmt.Errorf("cannot decode value for param key %s: %v", key, err)
+	}
+	value, err := cdc.MarshalJSON(ptr.Elem().Interface())
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+// Apply applies a parsed value for a governable parameter key registered with
+// an ApplyFn, returning false if the value is to be set in the global param
+// store instead
+func (r Registry) Apply(ctx sdk.Context, key string, value interface{}) (applied bool, err error) {
+	entry, ok := r.entries[key]
+	if !ok || entry.apply == nil {
+		return false, nil
+	}
+	return true, entry.apply(ctx, value)
+}
@@ -0,0 +1,83 @@
+package params
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestRegistry(t *testing.T) {
+	cdc := codec.New()
+	registry := NewRegistry()
+	registry.Register("key2", sdk.Dec{}, nil)
+	registry.Register("key1", int64(0), func(value interface{}) error {
+		if value.(int64) <= 0 {
+			return fmt.Errorf("must be positive")
+		}
+		return nil
+	})
+
+	require.True(t, registry.Has("key1"))
+	require.False(t, registry.Has("key3"))
+	require.Equal(t, []string{"key1", "key2"}, registry.Keys())
+	require.Panics(t, func() { registry.Register("key1", int64(0), nil) })
+
+	// parse values with the registered types
+	param, err := registry.Parse(cdc, "key1", `"10"`)
+	require.Nil(t, err)
+	require.Equal(t, int64(10), param)
+
+	bz, err := cdc.MarshalJSON(sdk.NewDecWithPrec(5, 1))
+	require.Nil(t, err)
+	param, err = registry.Parse(cdc, "key2", string(bz))
+	require.Nil(t, err)
+	require.True(t, sdk.NewDecWithPrec(5, 1).Equal(param.(sdk.Dec)))
+
+	// unregistered keys, undecodable and invalid values are rejected
+	_, err = registry.Parse(cdc, "key3", `"10"`)
+	require.NotNil(t, err)
+	_, err = registry.Parse(cdc, "key1", `"ten"`)
+	require.NotNil(t, err)
+	_, err = registry.Parse(cdc, "key1", `"-10"`)
+	require.NotNil(t, err)
+
+	// parsed values can be stored with the setter
+	skey := sdk.NewKVStoreKey("test")
+	ctx := defaultContext(skey)
+	setter := NewKeeper(cdc, skey).Setter()
+	param, err = registry.Parse(cdc, "key1", `"10"`)
+	require.Nil(t, err)
+	require.Nil(t, setter.Set(ctx, "key1", param))
+	res, err := setter.GetInt64(ctx, "key1")
+	require.Nil(t, err)
+	require.Equal(t, int64(10), res)
+
+	// the values of the params registered with an ApplyFn are applied by it
+	var applied interface{}
+	registry.RegisterWithApply("key3", int64(0), nil, func(ctx sdk.Context, value interface{}) error {
+		applied = value
+		return nil
+	})
+	param, err = registry.Parse(cdc, "key3", `"20"`)
+	require.Nil(t, err)
+	ok, err := registry.Apply(ctx, "key3", param)
+	require.True(t, ok)
+	require.Nil(t, err)
+	require.Equal(t, int64(20), applied)
+	ok, err = registry.Apply(ctx, "key1", int64(10))
+	require.False(t, ok)
+	require.Nil(t, err)
+	require.True(t, registry.HasApply("key3"))
+	require.False(t, registry.HasApply("key1"))
+
+	// the values set in the param store are encoded back to JSON
+	value, err := registry.JSONFromBinary(cdc, "key1", cdc.MustMarshalBinary(int64(10)))
+	require.Nil(t, err)
+	require.Equal(t, `"10"`, value)
+	_, err = registry.JSONFromBinary(cdc, "unknown", cdc.MustMarshalBinary(int64(10)))
+	require.NotNil(t, err)
+}
@@ -67,9 +67,10 @@ func TestJailedValidatorDelegations(t *testing.T) {
 	got = stake.NewHandler(stakeKeeper)(ctx, msgBeginUnbonding)
 	require.True(t, got.IsOK(), "expected begin unbonding validator msg to be ok, got: %v", got)
 
-	msgCompleteUnbonding := stake.NewMsgCompleteUnbonding(sdk.AccAddress(valAddr), valAddr)
-	got = stake.NewHandler(stakeKeeper)(ctx, msgCompleteUnbonding)
-	require.True(t, got.IsOK(), "expected complete unbonding validator msg to be ok, got: %v", got)
+	// complete the matured unbonding
+	stake.EndBlocker(ctx, stakeKeeper)
+	_, found := stakeKeeper.GetUnbondingDelegation(ctx, sdk.AccAddress(valAddr), valAddr)
+	require.False(t, found, "expected unbonding delegation to be completed")
 
 	// verify validator still exists and is jailed
 	validator, found := stakeKeeper.GetValidator(ctx, valAddr)
@@ -29,19 +29,27 @@ type Hooks struct {
 	k Keeper
 }
 
-var _ sdk.ValidatorHooks = Hooks{}
+var _ sdk.StakingHooks = Hooks{}
 
 // Return the wrapper struct
-func (k Keeper) ValidatorHooks() Hooks {
+func (k Keeper) Hooks() Hooks {
 	return Hooks{k}
 }
 
-// Implements sdk.ValidatorHooks
+// Implements sdk.StakingHooks
 func (h Hooks) OnValidatorBonded(ctx sdk.Context, address sdk.ConsAddress) {
 	h.k.onValidatorBonded(ctx, address)
 }
 
-// Implements sdk.ValidatorHooks
+// Implements sdk.StakingHooks
 func (h Hooks) OnValidatorBeginUnbonding(ctx sdk.Context, address sdk.ConsAddress) {
 	h.k.onValidatorBeginUnbonding(ctx, address)
 }
+
+// nolint - unused hooks
+func (h Hooks) OnValidatorCreated(_ sdk.Context, _ sdk.ValAddress)                           {}
+func (h Hooks) OnValidatorModified(_ sdk.Context, _ sdk.ValAddress)                          {}
+func (h Hooks) OnValidatorRemoved(_ sdk.Context, _ sdk.ValAddress)                           {}
+func (h Hooks) OnDelegationCreated(_ sdk.Context, _ sdk.AccAddress, _ sdk.ValAddress)        {}
+func (h Hooks) OnDelegationSharesModified(_ sdk.Context, _ sdk.AccAddress, _ sdk.ValAddress) {}
+func (h Hooks) OnDelegationRemoved(_ sdk.Context, _ sdk.AccAddress, _ sdk.ValAddress)        {}
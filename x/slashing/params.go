@@ -1,9 +1,11 @@
 package slashing
 
 import (
+	"fmt"
 	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
 )
 
 // nolint
@@ -17,6 +19,32 @@ const (
 	SlashFractionDowntimeKey    = "slashing/SlashFractionDowntime"
 )
 
+// RegisterParams registers the slashing params as governable params
+func RegisterParams(registry params.Registry) {
+	registry.Register(MaxEvidenceAgeKey, int64(0), validatePositiveInt64)
+	registry.Register(SignedBlocksWindowKey, int64(0), validatePositiveInt64)
+	registry.Register(MinSignedPerWindowKey, sdk.Dec{}, validateFraction)
+	registry.Register(DoubleSignUnbondDurationKey, int64(0), validatePositiveInt64)
+	registry.Register(DowntimeUnbondDurationKey, int64(0), validatePositiveInt64)
+	registry.Register(SlashFractionDoubleSignKey, sdk.Dec{}, validateFraction)
+	registry.Register(SlashFractionDowntimeKey, sdk.Dec{}, validateFraction)
+}
+
+func validatePositiveInt64(value interface{}) error {
+	if value.(int64) <= 0 {
+		return fmt.Errorf("value must be positive, is %d", value)
+	}
+	return nil
+}
+
+func validateFraction(value interface{}) error {
+	fraction := value.(sdk.Dec)
+	if fraction.LT(sdk.ZeroDec()) || fraction.GT(sdk.OneDec()) {
+		return fmt.Errorf("value must be within [0, 1], is %v", fraction)
+	}
+	return nil
+}
+
 // MaxEvidenceAge - Max age for evidence - 21 days (3 weeks)
 // MaxEvidenceAge = 60 * 60 * 24 * 7 * 3
 func (k Keeper) MaxEvidenceAge(ctx sdk.Context) time.Duration {
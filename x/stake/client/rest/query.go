@@ -164,16 +164,12 @@ func delegatorTxsHandlerFn(cliCtx context.CLIContext, cdc *codec.Codec) http.Han
 			actions = append(actions, string(tags.ActionDelegate))
 		case isUnbondTx:
 			actions = append(actions, string(tags.ActionBeginUnbonding))
-			actions = append(actions, string(tags.ActionCompleteUnbonding))
 		case isRedTx:
 			actions = append(actions, string(tags.ActionBeginRedelegation))
-			actions = append(actions, string(tags.ActionCompleteRedelegation))
 		case noQuery:
 			actions = append(actions, string(tags.ActionDelegate))
 			actions = append(actions, string(tags.ActionBeginUnbonding))
-			actions = append(actions, string(tags.ActionCompleteUnbonding))
 			actions = append(actions, string(tags.ActionBeginRedelegation))
-			actions = append(actions, string(tags.ActionCompleteRedelegation))
 		default:
 			w.WriteHeader(http.StatusNoContent)
 			return
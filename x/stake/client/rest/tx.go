@@ -38,35 +38,24 @@ type msgBeginRedelegateInput struct {
 	ValidatorDstAddr string `json:"validator_dst_addr"` // in bech32
 	SharesAmount     string `json:"shares"`
 }
-type msgCompleteRedelegateInput struct {
-	DelegatorAddr    string `json:"delegator_addr"`     // in bech32
-	ValidatorSrcAddr string `json:"validator_src_addr"` // in bech32
-	ValidatorDstAddr string `json:"validator_dst_addr"` // in bech32
-}
 type msgBeginUnbondingInput struct {
 	DelegatorAddr string `json:"delegator_addr"` // in bech32
 	ValidatorAddr string `json:"validator_addr"` // in bech32
 	SharesAmount  string `json:"shares"`
 }
-type msgCompleteUnbondingInput struct {
-	DelegatorAddr string `json:"delegator_addr"` // in bech32
-	ValidatorAddr string `json:"validator_addr"` // in bech32
-}
 
 // the request body for edit delegations
 type EditDelegationsBody struct {
-	LocalAccountName    string                       `json:"name"`
-	Password            string                       `json:"password"`
-	ChainID             string                       `json:"chain_id"`
-	AccountNumber       int64                        `json:"account_number"`
-	Sequence            int64                        `json:"sequence"`
-	Gas                 string                       `json:"gas"`
-	GasAdjustment       string                       `json:"gas_adjustment"`
-	Delegations         []msgDelegationsInput        `json:"delegations"`
-	BeginUnbondings     []msgBeginUnbondingInput     `json:"begin_unbondings"`
-	CompleteUnbondings  []msgCompleteUnbondingInput  `json:"complete_unbondings"`
-	BeginRedelegates    []msgBeginRedelegateInput    `json:"begin_redelegates"`
-	CompleteRedelegates []msgCompleteRedelegateInput `json:"complete_redelegates"`
+	LocalAccountName string                    `json:"name"`
+	Password         string                    `json:"password"`
+	ChainID          string                    `json:"chain_id"`
+	AccountNumber    int64                     `json:"account_number"`
+	Sequence         int64                     `json:"sequence"`
+	Gas              string                    `json:"gas"`
+	GasAdjustment    string                    `json:"gas_adjustment"`
+	Delegations      []msgDelegationsInput     `json:"delegations"`
+	BeginUnbondings  []msgBeginUnbondingInput  `json:"begin_unbondings"`
+	BeginRedelegates []msgBeginRedelegateInput `json:"begin_redelegates"`
 }
 
 // TODO: Split this up into several smaller functions, and remove the above nolint
@@ -99,9 +88,7 @@ func delegationsRequestHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx conte
 		// build messages
 		messages := make([]sdk.Msg, len(m.Delegations)+
 			len(m.BeginRedelegates)+
-			len(m.CompleteRedelegates)+
-			len(m.BeginUnbondings)+
-			len(m.CompleteUnbondings))
+			len(m.BeginUnbondings))
 
 		i := 0
 		for _, msg := range m.Delegations {
@@ -170,39 +157,6 @@ func delegationsRequestHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx conte
 			i++
 		}
 
-		for _, msg := range m.CompleteRedelegates {
-			delAddr, err := sdk.AccAddressFromBech32(msg.DelegatorAddr)
-			if err != nil {
-				utils.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Couldn't decode delegator. Error: %s", err.Error()))
-				return
-			}
-
-			valSrcAddr, err := sdk.ValAddressFromBech32(msg.ValidatorSrcAddr)
-			if err != nil {
-				utils.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Couldn't decode validator. Error: %s", err.Error()))
-				return
-			}
-
-			valDstAddr, err := sdk.ValAddressFromBech32(msg.ValidatorDstAddr)
-			if err != nil {
-				utils.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Couldn't decode validator. Error: %s", err.Error()))
-				return
-			}
-
-			if !bytes.Equal(info.GetPubKey().Address(), delAddr) {
-				utils.WriteErrorResponse(w, http.StatusUnauthorized, "Must use own delegator address")
-				return
-			}
-
-			messages[i] = stake.MsgCompleteRedelegate{
-				DelegatorAddr:    delAddr,
-				ValidatorSrcAddr: valSrcAddr,
-				ValidatorDstAddr: valDstAddr,
-			}
-
-			i++
-		}
-
 		for _, msg := range m.BeginUnbondings {
 			delAddr, err := sdk.AccAddressFromBech32(msg.DelegatorAddr)
 			if err != nil {
@@ -236,32 +190,6 @@ func delegationsRequestHandlerFn(cdc *codec.Codec, kb keys.Keybase, cliCtx conte
 			i++
 		}
 
-		for _, msg := range m.CompleteUnbondings {
-			delAddr, err := sdk.AccAddressFromBech32(msg.DelegatorAddr)
-			if err != nil {
-				utils.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Couldn't decode delegator. Error: %s", err.Error()))
-				return
-			}
-
-			valAddr, err := sdk.ValAddressFromBech32(msg.ValidatorAddr)
-			if err != nil {
-				utils.WriteErrorResponse(w, http.StatusInternalServerError, fmt.Sprintf("Couldn't decode validator. Error: %s", err.Error()))
-				return
-			}
-
-			if !bytes.Equal(info.GetPubKey().Address(), delAddr) {
-				utils.WriteErrorResponse(w, http.StatusUnauthorized, "Must use own delegator address")
-				return
-			}
-
-			messages[i] = stake.MsgCompleteUnbonding{
-				DelegatorAddr: delAddr,
-				ValidatorAddr: valAddr,
-			}
-
-			i++
-		}
-
 		simulateGas, gas, err := client.ReadGasFlag(m.Gas)
 		if err != nil {
 			utils.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
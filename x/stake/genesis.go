@@ -12,8 +12,9 @@ import (
 // InitGenesis sets the pool and parameters for the provided keeper and
 // initializes the IntraTxCounter. For each validator in data, it sets that
 // validator in the keeper along with manually setting the indexes. In
-// addition, it also sets any delegations found in data. Finally, it updates
-// the bonded validators.
+// addition, it also sets any delegations found in data, calling the creation
+// hooks for each validator and delegation. Finally, it updates the bonded
+// validators.
 // Returns final validator set after applying all declaration and delegations
 func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) (res []abci.Validator, err error) {
 	keeper.SetPool(ctx, data.Pool)
@@ -38,10 +39,13 @@ func InitGenesis(ctx sdk.Context, keeper Keeper, data types.GenesisState) (res [
 		if validator.Status == sdk.Bonded {
 			keeper.SetValidatorBondedIndex(ctx, validator)
 		}
+
+		keeper.OnValidatorCreated(ctx, validator.OperatorAddr)
 	}
 
 	for _, bond := range data.Bonds {
 		keeper.SetDelegation(ctx, bond)
+		keeper.OnDelegationCreated(ctx, bond.DelegatorAddr, bond.ValidatorAddr)
 	}
 
 	keeper.UpdateBondedValidatorsFull(ctx)
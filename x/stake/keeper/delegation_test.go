@@ -174,6 +174,53 @@ func TestUnbondingDelegation(t *testing.T) {
 
 }
 
+func TestUBDQueue(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 0)
+
+	ubd1 := types.UnbondingDelegation{
+		DelegatorAddr: addrDels[0],
+		ValidatorAddr: addrVals[0],
+		MinTime:       time.Unix(10, 0).UTC(),
+		Balance:       sdk.NewInt64Coin("steak", 5),
+	}
+	ubd2 := types.UnbondingDelegation{
+		DelegatorAddr: addrDels[1],
+		ValidatorAddr: addrVals[0],
+		MinTime:       time.Unix(10, 0).UTC(),
+		Balance:       sdk.NewInt64Coin("steak", 5),
+	}
+	ubd3 := types.UnbondingDelegation{
+		DelegatorAddr: addrDels[0],
+		ValidatorAddr: addrVals[1],
+		MinTime:       time.Unix(20, 0).UTC(),
+		Balance:       sdk.NewInt64Coin("steak", 5),
+	}
+	keeper.InsertUBDQueue(ctx, ubd1)
+	keeper.InsertUBDQueue(ctx, ubd2)
+	keeper.InsertUBDQueue(ctx, ubd3)
+
+	// records sharing a completion time are stored in the same timeslice
+	require.Equal(t, 2, len(keeper.GetUBDQueueTimeSlice(ctx, time.Unix(10, 0).UTC())))
+
+	// nothing has matured yet
+	mature := keeper.DequeueAllMatureUBDQueue(ctx, time.Unix(9, 0).UTC())
+	require.Equal(t, 0, len(mature))
+
+	// the end time is inclusive
+	mature = keeper.DequeueAllMatureUBDQueue(ctx, time.Unix(10, 0).UTC())
+	require.Equal(t, []types.DVPair{
+		{DelegatorAddr: addrDels[0], ValidatorAddr: addrVals[0]},
+		{DelegatorAddr: addrDels[1], ValidatorAddr: addrVals[0]},
+	}, mature)
+
+	// dequeued timeslices are removed from the queue
+	mature = keeper.DequeueAllMatureUBDQueue(ctx, time.Unix(15, 0).UTC())
+	require.Equal(t, 0, len(mature))
+
+	mature = keeper.DequeueAllMatureUBDQueue(ctx, time.Unix(30, 0).UTC())
+	require.Equal(t, []types.DVPair{{DelegatorAddr: addrDels[0], ValidatorAddr: addrVals[1]}}, mature)
+}
+
 func TestUnbondDelegation(t *testing.T) {
 	ctx, _, keeper := CreateTestInput(t, false, 0)
 	pool := keeper.GetPool(ctx)
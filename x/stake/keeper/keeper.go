@@ -14,7 +14,7 @@ type Keeper struct {
 	storeTKey  sdk.StoreKey
 	cdc        *codec.Codec
 	bankKeeper bank.Keeper
-	hooks      sdk.ValidatorHooks
+	hooks      sdk.StakingHooks
 
 	// codespace
 	codespace sdk.CodespaceType
@@ -32,10 +32,10 @@ func NewKeeper(cdc *codec.Codec, key, tkey sdk.StoreKey, ck bank.Keeper, codespa
 	return keeper
 }
 
-// Set the validator hooks
-func (k Keeper) WithValidatorHooks(sh sdk.ValidatorHooks) Keeper {
+// Set the staking hooks
+func (k Keeper) WithHooks(sh sdk.StakingHooks) Keeper {
 	if k.hooks != nil {
-		panic("cannot set validator hooks twice")
+		panic("cannot set staking hooks twice")
 	}
 	k.hooks = sh
 	return k
@@ -78,13 +78,14 @@ func (k Keeper) SetNewParams(ctx sdk.Context, params types.Params) {
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
 	store := ctx.KVStore(k.storeKey)
 	exParams := k.GetParams(ctx)
+	b := k.cdc.MustMarshalBinary(params)
+	store.Set(ParamKey, b)
 
-	// if max validator count changes, must recalculate validator set
+	// if max validator count changes, must recalculate validator set with the
+	// new count
 	if exParams.MaxValidators != params.MaxValidators {
 		k.UpdateBondedValidatorsFull(ctx)
 	}
-	b := k.cdc.MustMarshalBinary(params)
-	store.Set(ParamKey, b)
 }
 
 //_______________________________________________________________________
@@ -107,6 +108,13 @@ func (k Keeper) SetPool(ctx sdk.Context, pool types.Pool) {
 	store.Set(PoolKey, b)
 }
 
+// add newly minted tokens to the loose token supply
+func (k Keeper) InflateSupply(ctx sdk.Context, newTokens sdk.Dec) {
+	pool := k.GetPool(ctx)
+	pool.LooseTokens = pool.LooseTokens.Add(newTokens)
+	k.SetPool(ctx, pool)
+}
+
 //__________________________________________________________________________
 
 // get the current in-block validator operation counter
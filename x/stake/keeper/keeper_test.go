@@ -38,3 +38,14 @@ func TestPool(t *testing.T) {
 	resPool = keeper.GetPool(ctx)
 	require.True(t, expPool.Equal(resPool))
 }
+
+func TestInflateSupply(t *testing.T) {
+	ctx, _, keeper := CreateTestInput(t, false, 1000)
+	pool := keeper.GetPool(ctx)
+
+	// minted tokens are added to the loose tokens only
+	keeper.InflateSupply(ctx, sdk.NewDec(100))
+	resPool := keeper.GetPool(ctx)
+	require.True(sdk.DecEq(t, pool.LooseTokens.Add(sdk.NewDec(100)), resPool.LooseTokens))
+	require.True(sdk.DecEq(t, pool.BondedTokens, resPool.BondedTokens))
+}
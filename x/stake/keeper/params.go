@@ -0,0 +1,48 @@
+package keeper
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// nolint
+const (
+	ParamKeyUnbondingTime = "stake/UnbondingTime"
+	ParamKeyMaxValidators = "stake/MaxValidators"
+)
+
+// registers the staking params as governable params, the changes being applied
+// through the keeper as the params are kept in the stake store
+func RegisterParams(registry params.Registry, k Keeper) {
+	registry.RegisterWithApply(ParamKeyUnbondingTime, time.Duration(0), validateUnbondingTime,
+		func(ctx sdk.Context, value interface{}) error {
+			p := k.GetParams(ctx)
+			p.UnbondingTime = value.(time.Duration)
+			k.SetParams(ctx, p)
+			return nil
+		})
+	registry.RegisterWithApply(ParamKeyMaxValidators, uint16(0), validateMaxValidators,
+		func(ctx sdk.Context, value interface{}) error {
+			p := k.GetParams(ctx)
+			p.MaxValidators = value.(uint16)
+			k.SetParams(ctx, p)
+			return nil
+		})
+}
+
+func validateUnbondingTime(value interface{}) error {
+	if value.(time.Duration) <= 0 {
+		return fmt.Errorf("unbonding time must be positive, is %v", value)
+	}
+	return nil
+}
+
+func validateMaxValidators(value interface{}) error {
+	if value.(uint16) == 0 {
+		return fmt.Errorf("max validators must be positive")
+	}
+	return nil
+}
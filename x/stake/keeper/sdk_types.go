@@ -72,6 +72,18 @@ func (k Keeper) TotalPower(ctx sdk.Context) sdk.Dec {
 	return pool.BondedTokens
 }
 
+// total staking tokens supply, bonded and loose
+func (k Keeper) TotalTokens(ctx sdk.Context) sdk.Dec {
+	pool := k.GetPool(ctx)
+	return pool.TokenSupply()
+}
+
+// the fraction of the staking tokens which are currently bonded
+func (k Keeper) BondedRatio(ctx sdk.Context) sdk.Dec {
+	pool := k.GetPool(ctx)
+	return pool.BondedRatio()
+}
+
 //__________________________________________________________________________
 
 // Implements DelegationSet
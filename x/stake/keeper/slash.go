@@ -99,6 +99,7 @@ func (k Keeper) Slash(ctx sdk.Context, consAddr sdk.ConsAddress, infractionHeigh
 	tokensToBurn := sdk.MinDec(remainingSlashAmount, validator.Tokens)
 
 	// burn validator's tokens
+	k.OnValidatorModified(ctx, validator.OperatorAddr)
 	pool := k.GetPool(ctx)
 	validator, pool = validator.RemoveTokens(pool, tokensToBurn)
 	pool.LooseTokens = pool.LooseTokens.Sub(tokensToBurn)
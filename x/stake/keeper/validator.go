@@ -600,6 +600,7 @@ func (k Keeper) beginUnbondingValidator(ctx sdk.Context, validator types.Validat
 	}
 
 	// set the status
+	k.OnValidatorModified(ctx, validator.OperatorAddr)
 	validator, pool = validator.UpdateStatus(pool, sdk.Unbonding)
 	k.SetPool(ctx, pool)
 
@@ -618,9 +619,7 @@ func (k Keeper) beginUnbondingValidator(ctx sdk.Context, validator types.Validat
 	store.Delete(GetValidatorsBondedIndexKey(validator.OperatorAddr))
 
 	// call the unbond hook if present
-	if k.hooks != nil {
-		k.hooks.OnValidatorBeginUnbonding(ctx, validator.ConsAddress())
-	}
+	k.OnValidatorBeginUnbonding(ctx, validator.ConsAddress())
 
 	// return updated validator
 	return validator
@@ -640,6 +639,7 @@ func (k Keeper) bondValidator(ctx sdk.Context, validator types.Validator) types.
 	validator.BondHeight = ctx.BlockHeight()
 
 	// set the status
+	k.OnValidatorModified(ctx, validator.OperatorAddr)
 	validator, pool = validator.UpdateStatus(pool, sdk.Bonded)
 	k.SetPool(ctx, pool)
 
@@ -653,9 +653,7 @@ func (k Keeper) bondValidator(ctx sdk.Context, validator types.Validator) types.
 	tstore.Set(GetTendermintUpdatesTKey(validator.OperatorAddr), bzABCI)
 
 	// call the bond hook if present
-	if k.hooks != nil {
-		k.hooks.OnValidatorBonded(ctx, validator.ConsAddress())
-	}
+	k.OnValidatorBonded(ctx, validator.ConsAddress())
 
 	// return updated validator
 	return validator
@@ -669,6 +667,7 @@ func (k Keeper) RemoveValidator(ctx sdk.Context, address sdk.ValAddress) {
 	if !found {
 		return
 	}
+	k.OnValidatorRemoved(ctx, address)
 
 	// delete the old validator record
 	store := ctx.KVStore(k.storeKey)
@@ -699,6 +698,7 @@ func (k Keeper) UpdateValidatorCommission(ctx sdk.Context, validator types.Valid
 		return err
 	}
 
+	k.OnValidatorModified(ctx, validator.OperatorAddr)
 	validator.Commission.Rate = newRate
 	validator.Commission.UpdateTime = blockTime
 
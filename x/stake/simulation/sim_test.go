@@ -26,7 +26,7 @@ func TestStakeWithRandomMessages(t *testing.T) {
 	stakeKeeper := stake.NewKeeper(mapp.Cdc, stakeKey, stakeTKey, bankKeeper, stake.DefaultCodespace)
 	mapp.Router().AddRoute("stake", stake.NewHandler(stakeKeeper))
 	mapp.SetEndBlocker(func(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
-		validatorUpdates := stake.EndBlocker(ctx, stakeKeeper)
+		validatorUpdates, _ := stake.EndBlocker(ctx, stakeKeeper)
 		return abci.ResponseEndBlock{
 			ValidatorUpdates: validatorUpdates,
 		}
@@ -49,9 +49,7 @@ func TestStakeWithRandomMessages(t *testing.T) {
 			{5, SimulateMsgEditValidator(stakeKeeper)},
 			{15, SimulateMsgDelegate(mapper, stakeKeeper)},
 			{10, SimulateMsgBeginUnbonding(mapper, stakeKeeper)},
-			{3, SimulateMsgCompleteUnbonding(stakeKeeper)},
 			{10, SimulateMsgBeginRedelegate(mapper, stakeKeeper)},
-			{3, SimulateMsgCompleteRedelegate(stakeKeeper)},
 		}, []simulation.RandSetup{
 			Setup(mapp, stakeKeeper),
 		}, []simulation.Invariant{
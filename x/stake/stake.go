@@ -9,31 +9,30 @@ import (
 )
 
 type (
-	Keeper                = keeper.Keeper
-	Validator             = types.Validator
-	Description           = types.Description
-	Commission            = types.Commission
-	Delegation            = types.Delegation
-	DelegationSummary     = types.DelegationSummary
-	UnbondingDelegation   = types.UnbondingDelegation
-	Redelegation          = types.Redelegation
-	Params                = types.Params
-	Pool                  = types.Pool
-	MsgCreateValidator    = types.MsgCreateValidator
-	MsgEditValidator      = types.MsgEditValidator
-	MsgDelegate           = types.MsgDelegate
-	MsgBeginUnbonding     = types.MsgBeginUnbonding
-	MsgCompleteUnbonding  = types.MsgCompleteUnbonding
-	MsgBeginRedelegate    = types.MsgBeginRedelegate
-	MsgCompleteRedelegate = types.MsgCompleteRedelegate
-	GenesisState          = types.GenesisState
-	QueryDelegatorParams  = querier.QueryDelegatorParams
-	QueryValidatorParams  = querier.QueryValidatorParams
-	QueryBondsParams      = querier.QueryBondsParams
+	Keeper               = keeper.Keeper
+	Validator            = types.Validator
+	Description          = types.Description
+	Commission           = types.Commission
+	Delegation           = types.Delegation
+	DelegationSummary    = types.DelegationSummary
+	UnbondingDelegation  = types.UnbondingDelegation
+	Redelegation         = types.Redelegation
+	Params               = types.Params
+	Pool                 = types.Pool
+	MsgCreateValidator   = types.MsgCreateValidator
+	MsgEditValidator     = types.MsgEditValidator
+	MsgDelegate          = types.MsgDelegate
+	MsgBeginUnbonding    = types.MsgBeginUnbonding
+	MsgBeginRedelegate   = types.MsgBeginRedelegate
+	GenesisState         = types.GenesisState
+	QueryDelegatorParams = querier.QueryDelegatorParams
+	QueryValidatorParams = querier.QueryValidatorParams
+	QueryBondsParams     = querier.QueryBondsParams
 )
 
 var (
-	NewKeeper = keeper.NewKeeper
+	NewKeeper      = keeper.NewKeeper
+	RegisterParams = keeper.RegisterParams
 
 	GetValidatorKey              = keeper.GetValidatorKey
 	GetValidatorByConsAddrKey    = keeper.GetValidatorByConsAddrKey
@@ -43,6 +42,8 @@ var (
 	GetDelegationKey             = keeper.GetDelegationKey
 	GetDelegationsKey            = keeper.GetDelegationsKey
 	ParamKey                     = keeper.ParamKey
+	ParamKeyUnbondingTime        = keeper.ParamKeyUnbondingTime
+	ParamKeyMaxValidators        = keeper.ParamKeyMaxValidators
 	PoolKey                      = keeper.PoolKey
 	ValidatorsKey                = keeper.ValidatorsKey
 	ValidatorsByConsAddrKey      = keeper.ValidatorsByConsAddrKey
@@ -81,9 +82,7 @@ var (
 	NewMsgEditValidator             = types.NewMsgEditValidator
 	NewMsgDelegate                  = types.NewMsgDelegate
 	NewMsgBeginUnbonding            = types.NewMsgBeginUnbonding
-	NewMsgCompleteUnbonding         = types.NewMsgCompleteUnbonding
 	NewMsgBeginRedelegate           = types.NewMsgBeginRedelegate
-	NewMsgCompleteRedelegate        = types.NewMsgCompleteRedelegate
 
 	NewQuerier = querier.NewQuerier
 )
@@ -6,7 +6,6 @@ import (
 	"time"
 
 	"github.com/cosmos/cosmos-sdk/codec"
-	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
 // defaultUnbondingTime reflects three weeks in seconds as the default
@@ -15,11 +14,6 @@ const defaultUnbondingTime time.Duration = 60 * 60 * 24 * 3 * time.Second
 
 // Params defines the high level settings for staking
 type Params struct {
-	InflationRateChange sdk.Dec `json:"inflation_rate_change"` // maximum annual change in inflation rate
-	InflationMax        sdk.Dec `json:"inflation_max"`         // maximum inflation rate
-	InflationMin        sdk.Dec `json:"inflation_min"`         // minimum inflation rate
-	GoalBonded          sdk.Dec `json:"goal_bonded"`           // Goal of percent bonded atoms
-
 	UnbondingTime time.Duration `json:"unbonding_time"`
 
 	MaxValidators uint16 `json:"max_validators"` // maximum number of validators
@@ -36,13 +30,9 @@ func (p Params) Equal(p2 Params) bool {
 // DefaultParams returns a default set of parameters.
 func DefaultParams() Params {
 	return Params{
-		InflationRateChange: sdk.NewDecWithPrec(13, 2),
-		InflationMax:        sdk.NewDecWithPrec(20, 2),
-		InflationMin:        sdk.NewDecWithPrec(7, 2),
-		GoalBonded:          sdk.NewDecWithPrec(67, 2),
-		UnbondingTime:       defaultUnbondingTime,
-		MaxValidators:       100,
-		BondDenom:           "steak",
+		UnbondingTime: defaultUnbondingTime,
+		MaxValidators: 100,
+		BondDenom:     "steak",
 	}
 }
 
@@ -51,10 +41,6 @@ func DefaultParams() Params {
 func (p Params) HumanReadableString() string {
 
 	resp := "Pool \n"
-	resp += fmt.Sprintf("Maximum Annual Inflation Rate Change: %s\n", p.InflationRateChange)
-	resp += fmt.Sprintf("Max Inflation Rate: %s\n", p.InflationMax)
-	resp += fmt.Sprintf("Min Inflation Tate: %s\n", p.InflationMin)
-	resp += fmt.Sprintf("Bonded Token Goal (%s): %s\n", "s", p.GoalBonded)
 	resp += fmt.Sprintf("Unbonding Time: %s\n", p.UnbondingTime)
 	resp += fmt.Sprintf("Max Validators: %d: \n", p.MaxValidators)
 	resp += fmt.Sprintf("Bonded Coin Denomination: %s\n", p.BondDenom)
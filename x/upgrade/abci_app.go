@@ -0,0 +1,37 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// BeginBlocker applies the scheduled upgrade plan once it is due. If the
+// running binary has no handler registered for the plan the chain is halted,
+// so that operators can switch to a binary supporting the upgrade.
+func BeginBlocker(ctx sdk.Context, k Keeper) {
+	plan, found := k.GetUpgradePlan(ctx)
+	if !found {
+		return
+	}
+	logger := ctx.Logger().With("module", "x/upgrade")
+
+	if !plan.ShouldExecute(ctx) {
+		// a binary which already knows the upgrade must not run the blocks before it
+		if k.HasUpgradeHandler(plan.Name) {
+			msg := fmt.Sprintf("BINARY UPDATED BEFORE TRIGGER! UPGRADE \"%s\" - in binary but not executed on chain", plan.Name)
+			logger.Error(msg)
+			panic(msg)
+		}
+		return
+	}
+
+	if !k.HasUpgradeHandler(plan.Name) {
+		msg := fmt.Sprintf("UPGRADE \"%s\" NEEDED at %s: %s", plan.Name, plan.DueAt(), plan.Info)
+		logger.Error(msg)
+		panic(msg)
+	}
+
+	logger.Info(fmt.Sprintf("applying upgrade \"%s\" at %s", plan.Name, plan.DueAt()))
+	k.applyUpgrade(ctx, plan)
+}
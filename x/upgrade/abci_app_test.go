@@ -0,0 +1,155 @@
+package upgrade
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func createTestInput(t *testing.T) (sdk.Context, Keeper) {
+	keyUpgrade := sdk.NewKVStoreKey("upgrade")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyUpgrade, sdk.StoreTypeIAVL, db)
+	err := ms.LoadLatestVersion()
+	require.Nil(t, err)
+
+	ctx := sdk.NewContext(ms, abci.Header{Height: 10, Time: time.Unix(1000, 0).UTC()}, false, log.NewNopLogger())
+	return ctx, NewKeeper(codec.New(), keyUpgrade, DefaultCodespace)
+}
+
+func withHeight(ctx sdk.Context, height int64) sdk.Context {
+	header := ctx.BlockHeader()
+	header.Height = height
+	return ctx.WithBlockHeader(header)
+}
+
+func TestPlanValidateBasic(t *testing.T) {
+	tests := []struct {
+		plan       Plan
+		expectPass bool
+	}{
+		{NewPlan("test", 100, time.Time{}, ""), true},
+		{NewPlan("test", 0, time.Unix(2000, 0), ""), true},
+		{NewPlan("", 100, time.Time{}, ""), false},
+		{NewPlan("test", 0, time.Time{}, ""), false},
+		{NewPlan("test", -1, time.Time{}, ""), false},
+		{NewPlan("test", 100, time.Unix(2000, 0), ""), false},
+	}
+
+	for i, tc := range tests {
+		if tc.expectPass {
+			require.Nil(t, tc.plan.ValidateBasic(), "test: %v", i)
+		} else {
+			require.NotNil(t, tc.plan.ValidateBasic(), "test: %v", i)
+		}
+	}
+}
+
+func TestScheduleUpgrade(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+
+	// plans in the past are rejected
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, NewPlan("test", 10, time.Time{}, "")))
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, NewPlan("test", 0, time.Unix(1000, 0), "")))
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+
+	// a new plan replaces the scheduled one
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, NewPlan("test", 15, time.Time{}, "")))
+	plan := NewPlan("test2", 20, time.Time{}, "info")
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, plan))
+	res, found := keeper.GetUpgradePlan(ctx)
+	require.True(t, found)
+	require.Equal(t, plan, res)
+
+	keeper.ClearUpgradePlan(ctx)
+	_, found = keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+}
+
+func TestBeginBlockerAppliesUpgrade(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+
+	plan := NewPlan("test", 15, time.Time{}, "")
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, plan))
+
+	// nothing happens before the upgrade height
+	BeginBlocker(ctx, keeper)
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.True(t, found)
+
+	// the chain halts at the upgrade height without a handler
+	ctx = withHeight(ctx, 15)
+	require.Panics(t, func() { BeginBlocker(ctx, keeper) })
+
+	// the handler is run at the upgrade height
+	applied := false
+	keeper.SetUpgradeHandler("test", func(ctx sdk.Context, plan Plan) { applied = true })
+	BeginBlocker(ctx, keeper)
+	require.True(t, applied)
+
+	_, found = keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+	height, found := keeper.GetDoneHeight(ctx, "test")
+	require.True(t, found)
+	require.Equal(t, int64(15), height)
+
+	// applied plans cannot be scheduled again
+	require.NotNil(t, keeper.ScheduleUpgrade(ctx, NewPlan("test", 20, time.Time{}, "")))
+}
+
+func TestBeginBlockerHaltsEarlyBinary(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+
+	keeper.SetUpgradeHandler("test", func(ctx sdk.Context, plan Plan) {})
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, NewPlan("test", 0, time.Unix(2000, 0), "")))
+
+	// a binary with the handler cannot run blocks before the upgrade
+	require.Panics(t, func() { BeginBlocker(ctx, keeper) })
+
+	header := ctx.BlockHeader()
+	header.Time = time.Unix(2000, 0).UTC()
+	ctx = ctx.WithBlockHeader(header)
+	BeginBlocker(ctx, keeper)
+	_, found := keeper.GetUpgradePlan(ctx)
+	require.False(t, found)
+}
+
+func TestGenesisExportImport(t *testing.T) {
+	ctx, keeper := createTestInput(t)
+
+	keeper.SetUpgradeHandler("first", func(ctx sdk.Context, plan Plan) {})
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, NewPlan("first", 15, time.Time{}, "")))
+	BeginBlocker(withHeight(ctx, 15), keeper)
+	require.Nil(t, keeper.ScheduleUpgrade(ctx, NewPlan("second", 20, time.Time{}, "info")))
+
+	data := WriteGenesis(ctx, keeper)
+	require.Nil(t, ValidateGenesis(data))
+	require.Equal(t, []DoneUpgrade{{"first", 15}}, data.Done)
+
+	// the exported state is restored on a new chain
+	ctx2, keeper2 := createTestInput(t)
+	InitGenesis(ctx2, keeper2, data)
+	plan, found := keeper2.GetUpgradePlan(ctx2)
+	require.True(t, found)
+	require.Equal(t, "second", plan.Name)
+	require.Equal(t, "info", plan.Info)
+	height, found := keeper2.GetDoneHeight(ctx2, "first")
+	require.True(t, found)
+	require.Equal(t, int64(15), height)
+
+	// a plan which has already been applied is rejected
+	data.Plan = &Plan{Name: "first", Height: 30}
+	require.NotNil(t, ValidateGenesis(data))
+}
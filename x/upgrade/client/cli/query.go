@@ -0,0 +1,40 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+)
+
+// GetCmdQueryPlan implements the query scheduled upgrade plan command.
+func GetCmdQueryPlan(storeName string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "query-upgrade-plan",
+		Short: "Query the currently scheduled software upgrade plan",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, err := cliCtx.QueryStore(upgrade.PlanKey, storeName)
+			if err != nil {
+				return err
+			} else if len(res) == 0 {
+				return fmt.Errorf("No upgrade plan is scheduled")
+			}
+
+			var plan upgrade.Plan
+			cdc.MustUnmarshalBinary(res, &plan)
+
+			output, err := codec.MarshalJSONIndent(cdc, plan)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,23 @@
+//nolint
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+const (
+	DefaultCodespace sdk.CodespaceType = 8
+
+	CodeInvalidPlan sdk.CodeType = 1
+	CodePlanDone    sdk.CodeType = 2
+)
+
+func ErrInvalidPlan(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return sdk.NewError(codespace, CodeInvalidPlan, fmt.Sprintf("invalid upgrade plan: %s", msg))
+}
+
+func ErrPlanDone(codespace sdk.CodespaceType, name string) sdk.Error {
+	return sdk.NewError(codespace, CodePlanDone, fmt.Sprintf("upgrade %s has already been applied", name))
+}
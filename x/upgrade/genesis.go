@@ -0,0 +1,72 @@
+package upgrade
+
+import (
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - the scheduled upgrade plan and the applied ones
+type GenesisState struct {
+	Plan *Plan         `json:"plan"` // scheduled plan, nil if none
+	Done []DoneUpgrade `json:"done"`
+}
+
+// DoneUpgrade - the height at which the named plan was applied
+type DoneUpgrade struct {
+	Name   string `json:"name"`
+	Height int64  `json:"height"`
+}
+
+// get raw genesis raw message for testing
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// InitGenesis - store the scheduled plan and the applied ones
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	if data.Plan != nil {
+		store := ctx.KVStore(k.storeKey)
+		store.Set(PlanKey, k.cdc.MustMarshalBinary(*data.Plan))
+	}
+	for _, done := range data.Done {
+		k.setDoneHeight(ctx, done.Name, done.Height)
+	}
+}
+
+// WriteGenesis - output the scheduled plan and the applied ones
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	var data GenesisState
+	if plan, found := k.GetUpgradePlan(ctx); found {
+		data.Plan = &plan
+	}
+	k.IterateDoneUpgrades(ctx, func(name string, height int64) bool {
+		data.Done = append(data.Done, DoneUpgrade{name, height})
+		return false
+	})
+	return data
+}
+
+// ValidateGenesis checks the scheduled plan has not been applied already
+func ValidateGenesis(data GenesisState) error {
+	done := make(map[string]bool, len(data.Done))
+	for _, d := range data.Done {
+		if len(d.Name) == 0 {
+			return fmt.Errorf("applied upgrade without name")
+		}
+		if done[d.Name] {
+			return fmt.Errorf("duplicate applied upgrade %s", d.Name)
+		}
+		done[d.Name] = true
+	}
+	if data.Plan == nil {
+		return nil
+	}
+	if err := data.Plan.ValidateBasic(); err != nil {
+		return fmt.Errorf("invalid upgrade plan %s: %v", data.Plan.Name, err.Result().Log)
+	}
+	if done[data.Plan.Name] {
+		return fmt.Errorf("upgrade plan %s has already been applied", data.Plan.Name)
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package upgrade
+
+import (
+	"encoding/binary"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Handler applies the state migrations of an upgrade plan, it is run in the
+// BeginBlock of the block at which the plan is due
+type Handler func(ctx sdk.Context, plan Plan)
+
+// keeper of the upgrade store
+type Keeper struct {
+	storeKey sdk.StoreKey
+	cdc      *codec.Codec
+	handlers map[string]Handler
+
+	// codespace
+	codespace sdk.CodespaceType
+}
+
+func NewKeeper(cdc *codec.Codec, key sdk.StoreKey, codespace sdk.CodespaceType) Keeper {
+	return Keeper{
+		storeKey:  key,
+		cdc:       cdc,
+		handlers:  make(map[string]Handler),
+		codespace: codespace,
+	}
+}
+
+// Keys for upgrade store items
+var (
+	PlanKey       = []byte{0x00} // key for the currently scheduled plan
+	DonePrefixKey = []byte{0x01} // prefix for the heights at which plans were applied
+)
+
+// gets the key for the height at which the named plan was applied
+func GetDoneKey(name string) []byte {
+	return append(DonePrefixKey, []byte(name)...)
+}
+
+//______________________________________________________________________
+
+// SetUpgradeHandler registers the handler which applies the upgrade plan with
+// the given name. Registering a handler signals that the running binary
+// supports the upgrade, so it must only be done by the upgraded binary.
+func (k Keeper) SetUpgradeHandler(name string, handler Handler) {
+	k.handlers[name] = handler
+}
+
+// HasUpgradeHandler returns whether a handler is registered for the plan name
+func (k Keeper) HasUpgradeHandler(name string) bool {
+	_, ok := k.handlers[name]
+	return ok
+}
+
+// ValidatePlan checks that the plan is valid, has not been applied before and is still in the future
+func (k Keeper) ValidatePlan(ctx sdk.Context, plan Plan) sdk.Error {
+	if err := plan.ValidateBasic(); err != nil {
+		return err
+	}
+	if _, done := k.GetDoneHeight(ctx, plan.Name); done {
+		return ErrPlanDone(k.codespace, plan.Name)
+	}
+	if !plan.IsInFuture(ctx) {
+		return ErrInvalidPlan(k.codespace, "upgrade cannot be scheduled in the past")
+	}
+	return nil
+}
+
+// ScheduleUpgrade schedules an upgrade plan, replacing any plan which is
+// already scheduled
+func (k Keeper) ScheduleUpgrade(ctx sdk.Context, plan Plan) sdk.Error {
+	if err := k.ValidatePlan(ctx, plan); err != nil {
+		return err
+	}
+	store := ctx.KVStore(k.storeKey)
+	b := k.cdc.MustMarshalBinary(plan)
+	store.Set(PlanKey, b)
+	return nil
+}
+
+// GetUpgradePlan returns the currently scheduled plan, if any
+func (k Keeper) GetUpgradePlan(ctx sdk.Context) (plan Plan, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(PlanKey)
+	if b == nil {
+		return plan, false
+	}
+	k.cdc.MustUnmarshalBinary(b, &plan)
+	return plan, true
+}
+
+// ClearUpgradePlan removes the currently scheduled plan
+func (k Keeper) ClearUpgradePlan(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(PlanKey)
+}
+
+// GetDoneHeight returns the height at which the plan with the given name was applied
+func (k Keeper) GetDoneHeight(ctx sdk.Context, name string) (height int64, found bool) {
+	store := ctx.KVStore(k.storeKey)
+	b := store.Get(GetDoneKey(name))
+	if b == nil {
+		return 0, false
+	}
+	return int64(binary.BigEndian.Uint64(b)), true
+}
+
+// record the height at which the plan with the given name was applied
+func (k Keeper) setDone(ctx sdk.Context, name string) {
+	k.setDoneHeight(ctx, name, ctx.BlockHeight())
+}
+
+func (k Keeper) setDoneHeight(ctx sdk.Context, name string, height int64) {
+	store := ctx.KVStore(k.storeKey)
+	b := make([]byte, 8)
+	binary.BigEndian.PutUint64(b, uint64(height))
+	store.Set(GetDoneKey(name), b)
+}
+
+// IterateDoneUpgrades iterates over the applied plans, by name, with the
+// heights at which they were applied
+func (k Keeper) IterateDoneUpgrades(ctx sdk.Context, fn func(name string, height int64) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), DonePrefixKey)
+	defer iterator.Close()
+	for ; iterator.Valid(); iterator.Next() {
+		name := string(iterator.Key()[len(DonePrefixKey):])
+		height := int64(binary.BigEndian.Uint64(iterator.Value()))
+		if fn(name, height) {
+			break
+		}
+	}
+}
+
+// applies the plan with its registered handler, the handler must exist
+func (k Keeper) applyUpgrade(ctx sdk.Context, plan Plan) {
+	handler := k.handlers[plan.Name]
+	handler(ctx, plan)
+	k.ClearUpgradePlan(ctx)
+	k.setDone(ctx, plan.Name)
+}
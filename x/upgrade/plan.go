@@ -0,0 +1,69 @@
+package upgrade
+
+import (
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Plan specifies a named software upgrade which is to be applied either at a
+// given block height or at the first block at or after a given time
+type Plan struct {
+	Name   string    `json:"name"`   // name of the upgrade, upgrade handlers are registered under it
+	Time   time.Time `json:"time"`   // time at which the upgrade is applied, zero if the height is used instead
+	Height int64     `json:"height"` // height at which the upgrade is applied, zero if the time is used instead
+	Info   string    `json:"info"`   // any application specific info, e.g. where to get the new binary
+}
+
+// NewPlan creates a new upgrade plan
+func NewPlan(name string, height int64, t time.Time, info string) Plan {
+	return Plan{
+		Name:   name,
+		Time:   t,
+		Height: height,
+		Info:   info,
+	}
+}
+
+// ValidateBasic performs a stateless validation of the plan
+func (plan Plan) ValidateBasic() sdk.Error {
+	if len(plan.Name) == 0 {
+		return ErrInvalidPlan(DefaultCodespace, "name cannot be empty")
+	}
+	if plan.Height < 0 {
+		return ErrInvalidPlan(DefaultCodespace, "height cannot be negative")
+	}
+	if plan.Time.IsZero() == (plan.Height == 0) {
+		return ErrInvalidPlan(DefaultCodespace, "exactly one of height or time must be set")
+	}
+	return nil
+}
+
+// IsInFuture returns whether the plan has not been reached yet in the given context
+func (plan Plan) IsInFuture(ctx sdk.Context) bool {
+	if !plan.Time.IsZero() {
+		return plan.Time.After(ctx.BlockHeader().Time)
+	}
+	return plan.Height > ctx.BlockHeight()
+}
+
+// ShouldExecute returns whether the plan has to be applied in the given context
+func (plan Plan) ShouldExecute(ctx sdk.Context) bool {
+	return !plan.IsInFuture(ctx)
+}
+
+// DueAt returns a human readable description of when the plan is applied
+func (plan Plan) DueAt() string {
+	if !plan.Time.IsZero() {
+		return fmt.Sprintf("time: %s", plan.Time.UTC().Format(time.RFC3339))
+	}
+	return fmt.Sprintf("height: %d", plan.Height)
+}
+
+func (plan Plan) String() string {
+	return fmt.Sprintf(`Upgrade Plan
+  Name: %s
+  %s
+  Info: %s`, plan.Name, plan.DueAt(), plan.Info)
+}